@@ -60,6 +60,7 @@ type Tier1Config struct {
 	StateStoreDefaultTag string
 	BlockType            string
 	StateBundleSize      uint64
+	FirstStreamableBlock uint64 // if 0, bstream.GetProtocolFirstStreamableBlock is used
 
 	MaxSubrequests       uint64
 	SubrequestsEndpoint  string
@@ -177,9 +178,14 @@ func (a *Tier1App) Run() error {
 		wasmModules = a.config.WASMExtensions.Params()
 	}
 
+	firstStreamableBlock := bstream.GetProtocolFirstStreamableBlock
+	if a.config.FirstStreamableBlock != 0 {
+		firstStreamableBlock = a.config.FirstStreamableBlock
+	}
+
 	tier2RequestParameters := reqctx.Tier2RequestParameters{
 		MeteringConfig:       a.config.MeteringConfig,
-		FirstStreamableBlock: bstream.GetProtocolFirstStreamableBlock,
+		FirstStreamableBlock: firstStreamableBlock,
 		MergedBlockStoreURL:  a.config.MergedBlocksStoreURL,
 		StateStoreURL:        a.config.StateStoreURL,
 		StateBundleSize:      a.config.StateBundleSize,
@@ -24,6 +24,10 @@ var buildCmd = &cobra.Command{
 		Build the project according to the specifications in substreams.yaml.
 		This command will check for dependencies, run the appropriate build commands,
 		and handle code generation steps.
+
+		The resolved location, content hash and module hashes of every import are recorded
+		in a 'substreams.lock' file next to the manifest. Subsequent loads of the manifest
+		fail if an import's content does not match it anymore, use '--update-lock' to refresh it.
 	`),
 	RunE:         runBuildE,
 	SilenceUsage: true,
@@ -33,6 +37,8 @@ func init() {
 	buildCmd.Flags().Bool("no-pack", false, "Do not pack the build output (default false)")
 	buildCmd.Flags().String("manifest", "", "Path to the manifest file")
 	buildCmd.Flags().String("binary", "default", "binary label to build from manifest")
+	buildCmd.Flags().Bool("update-lock", false, "Refresh the 'substreams.lock' file from the imports as they resolve now, instead of verifying them against it")
+	buildCmd.Flags().String("sign", "", "Path to an ed25519 private key (PEM or hex encoded) used to sign the package, see 'substreams pack --help'")
 	rootCmd.AddCommand(buildCmd)
 }
 
@@ -55,9 +61,20 @@ func runBuildE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error reading manifest: %w", err)
 	}
 
+	signingKeyPath := sflags.MustGetString(cmd, "sign")
+	if signingKeyPath != "" {
+		// pack runs from the manifest's directory
+		signingKeyPath, err = filepath.Abs(signingKeyPath)
+		if err != nil {
+			return fmt.Errorf("resolving signing key path: %w", err)
+		}
+	}
+
 	info := &manifestInfo{
-		Path:     manifestPath,
-		Manifest: manif,
+		Path:           manifestPath,
+		Manifest:       manif,
+		UpdateLock:     sflags.MustGetBool(cmd, "update-lock"),
+		SigningKeyPath: signingKeyPath,
 	}
 
 	binaryLabel := sflags.MustGetString(cmd, "binary")
@@ -83,6 +100,10 @@ func runBuildE(cmd *cobra.Command, args []string) error {
 
 	noPack := sflags.MustGetBool(cmd, "no-pack")
 	if noPack {
+		if err := lockManifest(manifestPath, info.UpdateLock); err != nil {
+			return fmt.Errorf("error writing lock file: %w", err)
+		}
+
 		fmt.Printf("--no-pack flag detected, skipping creation of .spkg file.\n")
 		fmt.Printf("Build complete.\n")
 		return nil
@@ -120,8 +141,28 @@ func readManifestYaml(manifestPath string) (manifest.Manifest, error) {
 }
 
 type manifestInfo struct {
-	Path     string
-	Manifest manifest.Manifest
+	Path           string
+	Manifest       manifest.Manifest
+	UpdateLock     bool
+	SigningKeyPath string
+
+	// Output receives the messages of the builders and the output of the commands they run,
+	// instead of the standard output and error streams when set.
+	Output io.Writer
+}
+
+func (m *manifestInfo) stdout() io.Writer {
+	if m.Output != nil {
+		return m.Output
+	}
+	return os.Stdout
+}
+
+func (m *manifestInfo) stderr() io.Writer {
+	if m.Output != nil {
+		return m.Output
+	}
+	return os.Stderr
 }
 
 type ProtoBuilder struct {
@@ -139,14 +180,14 @@ func newProtoBuilder(manifInfo *manifestInfo, binaryLabel string) (*ProtoBuilder
 func (p *ProtoBuilder) Build(ctx context.Context) error {
 
 	if len(p.manifInfo.Manifest.Binaries) == 0 || !strings.HasPrefix(p.manifInfo.Manifest.Binaries[p.binaryLabel].Type, "wasm/rust-v1") {
-		fmt.Println("Notice: No binaries found of type `wasm/rust-v1`, not generating rust bindings...")
+		fmt.Fprintln(p.manifInfo.stdout(), "Notice: No binaries found of type `wasm/rust-v1`, not generating rust bindings...")
 		return nil
 	}
 
 	excludes := strings.Join(p.manifInfo.Manifest.Protobuf.ExcludePaths, ",")
 	if excludes == "" {
-		fmt.Printf("Notice: No exclude paths found:\n")
-		fmt.Printf("* Typically, `google` and `sf/substreams` are excluded. If build fails, consider adding these exclude paths.\n")
+		fmt.Fprintf(p.manifInfo.stdout(), "Notice: No exclude paths found:\n")
+		fmt.Fprintf(p.manifInfo.stdout(), "* Typically, `google` and `sf/substreams` are excluded. If build fails, consider adding these exclude paths.\n")
 	}
 
 	defaultCmd := []string{"substreams", "protogen", p.manifInfo.Path}
@@ -154,12 +195,12 @@ func (p *ProtoBuilder) Build(ctx context.Context) error {
 		defaultCmd = append(defaultCmd, []string{"--exclude-paths", excludes}...)
 	}
 
-	err := runCommandInDir(ctx, filepath.Dir(p.manifInfo.Path), defaultCmd)
+	err := runCommandInDir(ctx, filepath.Dir(p.manifInfo.Path), defaultCmd, p.manifInfo.stdout(), p.manifInfo.stderr())
 	if err != nil {
 		return fmt.Errorf("error running protogen: %w", err)
 	}
 
-	fmt.Printf("Protogen complete.\n")
+	fmt.Fprintf(p.manifInfo.stdout(), "Protogen complete.\n")
 	return nil
 }
 
@@ -186,7 +227,7 @@ func (b *BinaryBuilder) isBuildRequired() bool {
 		}
 	}
 	if allUse {
-		fmt.Printf("All modules have a 'use' field\n")
+		fmt.Fprintf(b.manifInfo.stdout(), "All modules have a 'use' field\n")
 		buildRequired = false
 	} else {
 		buildRequired = true
@@ -200,8 +241,8 @@ func (b *BinaryBuilder) getCmdArgs(ctx context.Context, binaryType string) ([][]
 
 	switch binaryTypeID {
 	case "wasip1/tinygo-v1":
-		fmt.Printf("`wasip1/tinygo-v1` binary type found...\n")
-		depValidator := &TinyGoDependencyValidator{}
+		fmt.Fprintf(b.manifInfo.stdout(), "`wasip1/tinygo-v1` binary type found...\n")
+		depValidator := &TinyGoDependencyValidator{out: b.manifInfo.stdout()}
 		err := depValidator.ValidateDependency(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("validating tinygo dependency: %w", err)
@@ -209,8 +250,8 @@ func (b *BinaryBuilder) getCmdArgs(ctx context.Context, binaryType string) ([][]
 
 		return [][]string{{"tinygo", "build", "-o", "main.wasm", "-target", "wasi", "-gc", "leaking", "-scheduler", "none", "."}}, nil
 	case "wasm/rust-v1":
-		fmt.Printf("`wasm/rust-v1` binary type found...\n")
-		depValidator := &CargoDependencyValidator{}
+		fmt.Fprintf(b.manifInfo.stdout(), "`wasm/rust-v1` binary type found...\n")
+		depValidator := &CargoDependencyValidator{out: b.manifInfo.stdout()}
 		err := depValidator.ValidateDependency(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("validating cargo dependency: %w", err)
@@ -224,12 +265,12 @@ func (b *BinaryBuilder) getCmdArgs(ctx context.Context, binaryType string) ([][]
 
 func (b *BinaryBuilder) Build(ctx context.Context) error {
 	if b.manifInfo.Manifest.Binaries == nil || len(b.manifInfo.Manifest.Binaries) == 0 {
-		fmt.Printf("No binaries to build\n")
+		fmt.Fprintf(b.manifInfo.stdout(), "No binaries to build\n")
 		return nil
 	}
 
 	if !b.isBuildRequired() {
-		fmt.Printf("No build required.\n")
+		fmt.Fprintf(b.manifInfo.stdout(), "No build required.\n")
 		return nil
 	}
 
@@ -253,7 +294,7 @@ func (b *BinaryBuilder) Build(ctx context.Context) error {
 		}
 
 		for _, cmdArgs := range cmds {
-			err = runCommandInDir(ctx, filepath.Dir(b.manifInfo.Path), cmdArgs)
+			err = runCommandInDir(ctx, filepath.Dir(b.manifInfo.Path), cmdArgs, b.manifInfo.stdout(), b.manifInfo.stderr())
 			if err != nil {
 				return fmt.Errorf("error running `%s`: %w", strings.Join(cmdArgs, " "), err)
 			}
@@ -264,7 +305,7 @@ func (b *BinaryBuilder) Build(ctx context.Context) error {
 		return fmt.Errorf("binary label %q not found in manifest", b.binaryLabel)
 	}
 
-	fmt.Printf("Binary build complete.\n")
+	fmt.Fprintf(b.manifInfo.stdout(), "Binary build complete.\n")
 	return nil
 }
 
@@ -272,11 +313,13 @@ type DependencyValidator interface {
 	ValidateDependency(ctx context.Context) error
 }
 
-type TinyGoDependencyValidator struct{}
+type TinyGoDependencyValidator struct {
+	out io.Writer
+}
 
 func (t *TinyGoDependencyValidator) ValidateDependency(ctx context.Context) error {
 	//run tinygo version on the machine.  error if exit code not 0
-	fmt.Printf("Checking for tinygo on the system...\n")
+	fmt.Fprintf(t.out, "Checking for tinygo on the system...\n")
 	cmd := exec.CommandContext(ctx, "tinygo", "version")
 	cmd.Env = os.Environ()
 	err := cmd.Run()
@@ -285,15 +328,17 @@ func (t *TinyGoDependencyValidator) ValidateDependency(ctx context.Context) erro
 Consider installing tinygo from https://tinygo.org/getting-started/install\n`, err)
 	}
 
-	fmt.Printf("tinygo found on the system\n")
+	fmt.Fprintf(t.out, "tinygo found on the system\n")
 	return nil
 }
 
-type CargoDependencyValidator struct{}
+type CargoDependencyValidator struct {
+	out io.Writer
+}
 
 func (c *CargoDependencyValidator) ValidateDependency(ctx context.Context) error {
 	//run cargo version on the machine.  error if exit code not 0
-	fmt.Printf("Checking for cargo on the system...\n")
+	fmt.Fprintf(c.out, "Checking for cargo on the system...\n")
 	cmd := exec.CommandContext(ctx, "cargo", "--version")
 	cmd.Env = os.Environ()
 	err := cmd.Run()
@@ -304,7 +349,7 @@ On Linux and macOS systems, this is done as follows:
 "curl https://sh.rustup.rs -sSf | sh"`, err)
 	}
 
-	fmt.Printf("cargo found on the system\n")
+	fmt.Fprintf(c.out, "cargo found on the system\n")
 	return nil
 }
 
@@ -320,12 +365,18 @@ func newSPKGPacker(manifInfo *manifestInfo) (*SPKGPacker, error) {
 
 func (s *SPKGPacker) Build(ctx context.Context) error {
 	defaultCmd := []string{"substreams", "pack", s.manifInfo.Path}
-	err := runCommandInDir(ctx, filepath.Dir(s.manifInfo.Path), defaultCmd)
+	if s.manifInfo.UpdateLock {
+		defaultCmd = append(defaultCmd, "--update-lock")
+	}
+	if s.manifInfo.SigningKeyPath != "" {
+		defaultCmd = append(defaultCmd, "--sign", s.manifInfo.SigningKeyPath)
+	}
+	err := runCommandInDir(ctx, filepath.Dir(s.manifInfo.Path), defaultCmd, s.manifInfo.stdout(), s.manifInfo.stderr())
 	if err != nil {
 		return fmt.Errorf("error running pack: %w", err)
 	}
 
-	fmt.Printf("Pack complete.\n")
+	fmt.Fprintf(s.manifInfo.stdout(), "Pack complete.\n")
 	return nil
 }
 
@@ -363,30 +414,16 @@ func findManifest() (string, error) {
 	return "", fmt.Errorf("substreams.yaml file not found anywhere in directory path from %s to %s", originalDir, homeDir)
 }
 
-// runCommandInDir runs a command in the specified directory.
-func runCommandInDir(ctx context.Context, dir string, cmdArgs []string) error {
+// runCommandInDir runs a command in the specified directory, copying its output to stdout and stderr.
+func runCommandInDir(ctx context.Context, dir string, cmdArgs []string, stdout, stderr io.Writer) error {
 	cmd := exec.CommandContext(ctx, cmdArgs[0], cmdArgs[1:]...)
 	cmd.Env = append(os.Environ(), "CARGO_TERM_COLOR=always")
 	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("error creating stdout pipe: %w", err)
-	}
-	stderrPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("error creating stderr pipe: %w", err)
-	}
-
-	go func() {
-		_, _ = io.Copy(os.Stdout, stdoutPipe)
-	}()
-	go func() {
-		_, _ = io.Copy(os.Stderr, stderrPipe)
-	}()
-
-	fmt.Printf("Running command in %s: `%s`...\n", dir, strings.Join(cmdArgs, " "))
-	err = cmd.Start()
+	fmt.Fprintf(stdout, "Running command in %s: `%s`...\n", dir, strings.Join(cmdArgs, " "))
+	err := cmd.Start()
 	if err != nil {
 		return fmt.Errorf("error starting `%s`: %w", strings.Join(cmdArgs, " "), err)
 	}
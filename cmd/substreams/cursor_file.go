@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+)
+
+// cursorFile persists the cursor of the last block handled by `run`, so that a run
+// started again with the same file resumes where the previous one stopped.
+type cursorFile struct {
+	path string
+}
+
+// read returns the persisted cursor, or an empty string when the file does not exist yet.
+func (f *cursorFile) read() (string, error) {
+	cnt, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading cursor file: %w", err)
+	}
+	return strings.TrimSpace(string(cnt)), nil
+}
+
+// write replaces the persisted cursor atomically, by renaming a temporary file over the
+// previous one.
+func (f *cursorFile) write(cursor string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating cursor file: %w", err)
+	}
+
+	if _, err := tmp.WriteString(cursor + "\n"); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cursor file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cursor file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("replacing cursor file: %w", err)
+	}
+	return nil
+}
+
+// responseCursor returns the cursor to resume from once `resp` is handled, for the
+// responses that move the stream: block data, and undo signals rewinding it.
+func responseCursor(resp *pbsubstreamsrpc.Response) (string, bool) {
+	switch m := resp.Message.(type) {
+	case *pbsubstreamsrpc.Response_BlockScopedData:
+		return m.BlockScopedData.Cursor, true
+	case *pbsubstreamsrpc.Response_BlockUndoSignal:
+		return m.BlockUndoSignal.LastValidCursor, true
+	}
+	return "", false
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorFile(t *testing.T) {
+	dir := t.TempDir()
+	f := &cursorFile{path: filepath.Join(dir, "cursor.txt")}
+
+	cursor, err := f.read()
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor, "no cursor before the first write")
+
+	require.NoError(t, f.write("c1"))
+	require.NoError(t, f.write("c2"))
+
+	cursor, err = f.read()
+	require.NoError(t, err)
+	assert.Equal(t, "c2", cursor)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files are renamed over the cursor file")
+}
+
+func TestResponseCursor(t *testing.T) {
+	cursor, ok := responseCursor(&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockScopedData{
+		BlockScopedData: &pbsubstreamsrpc.BlockScopedData{Cursor: "block"},
+	}})
+	assert.True(t, ok)
+	assert.Equal(t, "block", cursor)
+
+	cursor, ok = responseCursor(&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockUndoSignal{
+		BlockUndoSignal: &pbsubstreamsrpc.BlockUndoSignal{LastValidCursor: "last valid"},
+	}})
+	assert.True(t, ok)
+	assert.Equal(t, "last valid", cursor)
+
+	_, ok = responseCursor(&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_Progress{}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+
+	"github.com/streamingfast/substreams/info"
+	"github.com/streamingfast/substreams/manifest"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old_package> <new_package> [<output_module>]",
+	Short: "Compare the modules of two packages and report which module hashes change",
+	Long: cli.Dedent(`
+		Compare two packages and report added, removed and modified modules (inputs, initial blocks,
+		params, binaries, block filters), changed protobuf message types, and every module whose hash
+		changes, directly or transitively through one of its ancestors. A module with a new hash does not
+		reuse any of the caches produced for the old one.
+
+		Packages can be a 'substreams.yaml' manifest, a directory containing one, a local '.spkg' file or a
+		link to a remote .spkg file, using urls gs://, http(s)://, ipfs://, etc. When '<output_module>' is
+		given, only the modules it depends on, and the protobuf message types they use, are compared.
+	`),
+	Example: cli.Dedent(`
+		substreams diff uniswap-v3-v0.2.7.spkg uniswap-v3-v0.2.8.spkg
+		substreams diff ./substreams.yaml https://example.com/previous.spkg map_pools --json
+	`),
+	RunE:         runDiff,
+	Args:         cobra.RangeArgs(2, 3),
+	SilenceUsage: true,
+}
+
+func init() {
+	diffCmd.Flags().Bool("json", false, "Output the differences as JSON")
+	diffCmd.Flags().Bool("exit-code", false, "Exit with an error when the packages differ, useful in CI")
+	diffCmd.Flags().StringP("network", "n", "", "Network used to resolve network-specific initial blocks and params of both packages, defaults to each package's network")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	var outputModule string
+	if len(args) == 3 {
+		outputModule = args[2]
+	}
+
+	var readerOptions []manifest.Option
+	if network := sflags.MustGetString(cmd, "network"); network != "" {
+		readerOptions = append(readerOptions, manifest.WithOverrideNetwork(network))
+	}
+
+	readPackage := func(input string) (*manifest.PackageBundle, error) {
+		reader, err := manifest.NewReader(input, readerOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("manifest reader: %w", err)
+		}
+		pkgBundle, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read manifest %q: %w", input, err)
+		}
+		return pkgBundle, nil
+	}
+
+	oldBundle, err := readPackage(args[0])
+	if err != nil {
+		return err
+	}
+	newBundle, err := readPackage(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff, err := info.Diff(args[0], oldBundle.Package, oldBundle.Graph, args[1], newBundle.Package, newBundle.Graph, outputModule)
+	if err != nil {
+		return fmt.Errorf("diff packages: %w", err)
+	}
+
+	if sflags.MustGetBool(cmd, "json") {
+		res, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(res))
+	} else {
+		printDiff(diff)
+	}
+
+	if sflags.MustGetBool(cmd, "exit-code") && diff.HasChanges() {
+		return fmt.Errorf("packages differ")
+	}
+	return nil
+}
+
+func printDiff(diff *info.PackageDiff) {
+	fmt.Printf("Comparing %s -> %s\n", diff.Old, diff.New)
+	if !diff.HasChanges() {
+		fmt.Printf("No differences (%d modules compared)\n", diff.UnchangedCount)
+		return
+	}
+
+	if len(diff.AddedModules) != 0 {
+		fmt.Println("")
+		fmt.Println("Added modules:")
+		for _, name := range diff.AddedModules {
+			fmt.Printf("  + %s\n", name)
+		}
+	}
+
+	if len(diff.RemovedModules) != 0 {
+		fmt.Println("")
+		fmt.Println("Removed modules:")
+		for _, name := range diff.RemovedModules {
+			fmt.Printf("  - %s\n", name)
+		}
+	}
+
+	if len(diff.ModifiedModules) != 0 {
+		fmt.Println("")
+		fmt.Println("Modified modules:")
+		for _, mod := range diff.ModifiedModules {
+			hash := "hash unchanged"
+			if mod.HashChanged() {
+				hash = fmt.Sprintf("hash %s -> %s", mod.OldHash, mod.NewHash)
+			}
+			fmt.Printf("  ~ %s (%s)\n", mod.Name, hash)
+			for _, change := range mod.Changes {
+				fmt.Printf("      %s: %s -> %s\n", change.Field, printableDiffValue(change.Old), printableDiffValue(change.New))
+			}
+			if len(mod.ChangedAncestors) != 0 {
+				fmt.Printf("      changed ancestors: %s\n", strings.Join(mod.ChangedAncestors, ", "))
+			}
+		}
+	}
+
+	if types := diff.ProtoTypes; types != nil {
+		fmt.Println("")
+		fmt.Println("Protobuf message types:")
+		for _, name := range types.Added {
+			fmt.Printf("  + %s\n", name)
+		}
+		for _, name := range types.Removed {
+			fmt.Printf("  - %s\n", name)
+		}
+		for _, name := range types.Modified {
+			fmt.Printf("  ~ %s\n", name)
+		}
+	}
+
+	fmt.Println("")
+	fmt.Printf("%d unchanged module(s)\n", diff.UnchangedCount)
+}
+
+func printableDiffValue(in string) string {
+	if in == "" {
+		return "<none>"
+	}
+	return fmt.Sprintf("%q", in)
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,6 +18,7 @@ import (
 	"github.com/streamingfast/substreams/manifest"
 	"github.com/streamingfast/substreams/tools"
 	"github.com/streamingfast/substreams/tui2"
+	"github.com/streamingfast/substreams/tui2/pages/build"
 	"github.com/streamingfast/substreams/tui2/pages/request"
 )
 
@@ -36,6 +40,7 @@ func init() {
 	guiCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY")
 	guiCmd.Flags().Bool("replay", false, "Replay saved session into GUI from replay.bin")
 	guiCmd.Flags().Bool("skip-package-validation", false, "Do not perform any validation when reading substreams package")
+	guiCmd.Flags().Bool("watch", false, "Build the local manifest with 'substreams build --no-pack' first, then rebuild it each time its manifest, protobuf or binary source files change, restarting the stream from the same start block when the resulting module hashes change")
 	rootCmd.AddCommand(guiCmd)
 }
 
@@ -150,6 +155,14 @@ func runGui(cmd *cobra.Command, args []string) (err error) {
 		homeDir = filepath.Join(homeDir, ".config", "substreams")
 	}
 
+	var watch func(ctx context.Context, prog *tea.Program)
+	if sflags.MustGetBool(cmd, "watch") {
+		watch, err = setupGuiWatch(cmd, manifestPath, network, requestParams)
+		if err != nil {
+			return err
+		}
+	}
+
 	cursor := sflags.MustGetString(cmd, "cursor")
 
 	fmt.Println("Launching Substreams GUI...")
@@ -187,6 +200,11 @@ func runGui(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 	prog := tea.NewProgram(ui, tea.WithAltScreen())
+	if watch != nil {
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		go watch(ctx, prog)
+	}
 	if _, err := prog.Run(); err != nil {
 		return fmt.Errorf("gui error: %w", err)
 	}
@@ -194,6 +212,65 @@ func runGui(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// setupGuiWatch builds the manifest, returning the function rebuilding it each time its sources change, which
+// has the GUI restart the stream when the hashes of its modules change.
+func setupGuiWatch(cmd *cobra.Command, manifestPath, network string, requestParams []string) (func(ctx context.Context, prog *tea.Program), error) {
+	manifestPath, err := watchedManifestPath(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving manifest: %w", err)
+	}
+
+	params, err := manifest.ParseParams(requestParams)
+	if err != nil {
+		return nil, fmt.Errorf("parsing params: %w", err)
+	}
+	readerOptions := []manifest.Option{
+		manifest.WithOverrideNetwork(network),
+		manifest.WithParams(params),
+	}
+	if sflags.MustGetBool(cmd, "skip-package-validation") {
+		readerOptions = append(readerOptions, manifest.SkipPackageValidationReader())
+	}
+
+	watcher, err := newSourceWatcher(manifestPath, "default")
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Building %s\n", manifestPath)
+	if err := watcher.Build(cmd.Context(), os.Stdout); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("building %q: %w", manifestPath, err)
+	}
+	hashes, err := packageModuleHashes(manifestPath, readerOptions, nil)
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	return func(ctx context.Context, prog *tea.Program) {
+		defer watcher.Close()
+		for {
+			if err := watcher.Next(ctx); err != nil {
+				return
+			}
+
+			// the output of the build is shown in the build page instead of the terminal, taken by the GUI
+			output := &bytes.Buffer{}
+			msg := build.WatchBuildMsg{}
+			if msg.Err = watcher.Build(ctx, output); msg.Err == nil {
+				var newHashes map[string]string
+				if newHashes, msg.Err = packageModuleHashes(manifestPath, readerOptions, nil); msg.Err == nil && !maps.Equal(hashes, newHashes) {
+					hashes = newHashes
+					msg.Restart = true
+				}
+			}
+			msg.Output = output.String()
+			prog.Send(msg)
+		}
+	}, nil
+}
+
 func loadSubstreamsAuthEnvFile(manifestPath string) {
 	projectPath := filepath.Dir(manifestPath)
 	authFile := filepath.Join(projectPath, ".substreams.env")
@@ -106,9 +106,16 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	for _, mod := range pkgInfo.Modules {
 		fmt.Println("Name:", mod.Name)
 		fmt.Println("Initial block:", mod.InitialBlock)
+		if mod.EndBlock != nil {
+			fmt.Println("End block:", *mod.EndBlock)
+		}
 		fmt.Println("Kind:", mod.Kind)
 		for _, input := range mod.Inputs {
 			fmt.Printf("Input: %s: %s\n", input.Type, input.Name)
+			if input.ParamsType != nil {
+				fmt.Println("Params type:", *input.ParamsType)
+				fmt.Println("Params shape:\n  " + strings.Replace(strings.TrimSpace(*input.ParamsShape), "\n", "\n  ", -1))
+			}
 		}
 		if mod.BlockFilter != nil {
 			fmt.Printf("Block Filter: (using *%s*): `%s`\n", mod.BlockFilter.Module, mod.BlockFilter.Query)
@@ -122,6 +129,15 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		case "store":
 			fmt.Println("Value Type:", *mod.ValueType)
 			fmt.Println("Update Policy:", *mod.UpdatePolicy)
+			if mod.RetainBlocks != nil {
+				fmt.Println("Retain blocks:", *mod.RetainBlocks)
+			}
+			if len(mod.Indexes) != 0 {
+				fmt.Println("Indexes:", strings.Join(mod.Indexes, ", "))
+			}
+			if mod.MergeEntrypoint != nil {
+				fmt.Println("Merge entrypoint:", *mod.MergeEntrypoint)
+			}
 		default:
 			fmt.Println("Kind: Unknown")
 		}
@@ -133,11 +149,27 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		fmt.Println("")
 	}
 
+	if len(pkgInfo.ResolvedImports) != 0 {
+		fmt.Println("Resolved imports:")
+		for _, resolved := range pkgInfo.ResolvedImports {
+			fmt.Printf("  %s: %s (%s)\n", resolved.Package, resolved.Version, resolved.URL)
+			for _, c := range resolved.Constraints {
+				fmt.Printf("    - %q required by %s\n", c.Constraint, c.RequiredBy)
+			}
+		}
+		fmt.Println("")
+	}
+
 	if pkgInfo.Network != "" {
 		fmt.Printf("Network: %s\n", pkgInfo.Network)
 		fmt.Println("")
 	}
 
+	if pkgInfo.SignedBy != "" {
+		fmt.Printf("Signed by: %s\n", pkgInfo.SignedBy)
+		fmt.Println("")
+	}
+
 	if pkgInfo.Networks != nil {
 		fmt.Println("Networks:")
 		for network, params := range pkgInfo.Networks {
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+
+	"github.com/streamingfast/substreams/manifest"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [<manifest_file>]",
+	Short: "Validate a manifest and report all its issues",
+	RunE:  runLint,
+	Long: cli.Dedent(`
+		Validate a manifest against the manifest JSON Schema (see 'schemas/manifest-schema.json') and
+		the semantic rules applied when building a package, then print every issue found along with its
+		file, line and column. The manifest is optional as it will try to find a file named 'substreams.yaml'
+		in current working directory if nothing entered. You may enter a directory that contains a
+		'substreams.yaml' file in place of '<manifest_file>'.
+
+		When the manifest is structurally valid, the package is also fully built (imports, protobuf
+		definitions, binaries) to report any remaining error.
+	`),
+	Args:         cobra.RangeArgs(0, 1),
+	SilenceUsage: true,
+}
+
+func init() {
+	lintCmd.Flags().Bool("json", false, "Output issues as JSON")
+	rootCmd.AddCommand(lintCmd)
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	manifestPath := ""
+	if len(args) == 1 {
+		manifestPath = args[0]
+	}
+
+	issues, err := manifest.LintManifest(manifestPath)
+	if err != nil {
+		return fmt.Errorf("lint manifest: %w", err)
+	}
+
+	if sflags.MustGetBool(cmd, "json") {
+		if issues == nil {
+			issues = manifest.ManifestIssues{}
+		}
+		res, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(res))
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue.Error())
+		}
+	}
+
+	if len(issues) != 0 {
+		return fmt.Errorf("%d issue(s) found", len(issues))
+	}
+
+	if !sflags.MustGetBool(cmd, "json") {
+		fmt.Println("No issues found")
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dauth"
+	dauthnull "github.com/streamingfast/dauth/null"
+	dauthtrust "github.com/streamingfast/dauth/trust"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/substreams/app"
+	"go.uber.org/zap"
+)
+
+func init() {
+	dauthnull.Register()
+	dauthtrust.Register()
+	dmetering.RegisterNull()
+}
+
+// localEngineConfig holds the configuration of the embedded tier1 and tier2 services
+// started by `substreams run --local-blocks`.
+type localEngineConfig struct {
+	MergedBlocksStoreURL string
+	StateStoreURL        string // if empty, a temporary directory is used and removed on shutdown
+	FirstStreamableBlock uint64
+	BlockType            string // if empty, it is inferred from the first merged block
+	StateBundleSize      uint64
+	MaxSubrequests       uint64
+}
+
+func addLocalEngineFlags(flags *pflag.FlagSet) {
+	flags.String("local-blocks", "", "Run offline against a local merged-blocks store (any dstore URL), using an embedded tier1 and tier2 instead of a remote endpoint")
+	flags.String("local-state-store", "", "State store URL used by the embedded engine when --local-blocks is set. If empty, a temporary directory is used and removed on exit")
+	flags.Uint64("local-first-streamable-block", 0, "First block available in the --local-blocks store")
+	flags.Uint64("local-state-bundle-size", 1000, "Interval in blocks at which the embedded engine saves store snapshots when --local-blocks is set")
+	flags.Uint64("local-max-subrequests", 4, "Number of parallel subrequests the embedded engine sends to its tier2 when --local-blocks is set")
+}
+
+// newLocalEngineConfig reads the `--local-*` flags of `cmd`, for an engine reading
+// blocks from `mergedBlocksStoreURL`.
+func newLocalEngineConfig(cmd *cobra.Command, mergedBlocksStoreURL string) *localEngineConfig {
+	return &localEngineConfig{
+		MergedBlocksStoreURL: mergedBlocksStoreURL,
+		StateStoreURL:        sflags.MustGetString(cmd, "local-state-store"),
+		FirstStreamableBlock: sflags.MustGetUint64(cmd, "local-first-streamable-block"),
+		StateBundleSize:      sflags.MustGetUint64(cmd, "local-state-bundle-size"),
+		MaxSubrequests:       sflags.MustGetUint64(cmd, "local-max-subrequests"),
+	}
+}
+
+// localEngine runs a tier1 and a tier2 in the current process, listening on
+// loopback addresses, so that a regular substreams client can connect to it
+// without any network access.
+type localEngine struct {
+	tier1 *app.Tier1App
+	tier2 *app.Tier2App
+
+	// Endpoint is the plaintext address of the embedded tier1
+	Endpoint string
+
+	tmpDir string
+	logger *zap.Logger
+}
+
+func startLocalEngine(ctx context.Context, config *localEngineConfig, logger *zap.Logger) (*localEngine, error) {
+	tmpDir, err := os.MkdirTemp("", "substreams-local-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+
+	engine := &localEngine{
+		tmpDir: tmpDir,
+		logger: logger,
+	}
+
+	stateStoreURL := config.StateStoreURL
+	if stateStoreURL == "" {
+		stateStoreURL = filepath.Join(tmpDir, "states")
+	}
+
+	tier1Addr, err := freeLocalAddr()
+	if err != nil {
+		engine.cleanup()
+		return nil, err
+	}
+	tier2Addr, err := freeLocalAddr()
+	if err != nil {
+		engine.cleanup()
+		return nil, err
+	}
+
+	authenticator, err := dauth.New("null://", logger)
+	if err != nil {
+		engine.cleanup()
+		return nil, fmt.Errorf("setting up authenticator: %w", err)
+	}
+
+	engine.tier2 = app.NewTier2(logger.Named("tier2"), &app.Tier2Config{
+		GRPCListenAddr: tier2Addr,
+		TmpDir:         tmpDir,
+	}, &app.Tier2Modules{})
+
+	engine.tier1 = app.NewTier1(logger.Named("tier1"), &app.Tier1Config{
+		MeteringConfig:       "null://",
+		MergedBlocksStoreURL: config.MergedBlocksStoreURL,
+		OneBlocksStoreURL:    filepath.Join(tmpDir, "one-blocks"),
+		GRPCListenAddr:       tier1Addr,
+		TmpDir:               tmpDir,
+		StateStoreURL:        stateStoreURL,
+		StateStoreDefaultTag: "local",
+		BlockType:            config.BlockType,
+		StateBundleSize:      config.StateBundleSize,
+		FirstStreamableBlock: config.FirstStreamableBlock,
+		MaxSubrequests:       config.MaxSubrequests,
+		SubrequestsEndpoint:  tier2Addr,
+		SubrequestsPlaintext: true,
+	}, &app.Tier1Modules{
+		Authenticator: authenticator,
+	})
+
+	logger.Info("starting local substreams engine",
+		zap.String("merged_blocks_store_url", config.MergedBlocksStoreURL),
+		zap.String("state_store_url", stateStoreURL),
+		zap.String("tier1_addr", tier1Addr),
+		zap.String("tier2_addr", tier2Addr),
+	)
+
+	if err := engine.tier2.Run(); err != nil {
+		engine.Shutdown()
+		return nil, fmt.Errorf("running tier2: %w", err)
+	}
+	if err := engine.tier1.Run(); err != nil {
+		engine.Shutdown()
+		return nil, fmt.Errorf("running tier1: %w", err)
+	}
+
+	for _, addr := range []string{tier2Addr, tier1Addr} {
+		if err := waitForListener(ctx, addr, engine); err != nil {
+			engine.Shutdown()
+			return nil, err
+		}
+	}
+
+	engine.Endpoint = tier1Addr
+	return engine, nil
+}
+
+func (e *localEngine) terminatedErr() error {
+	if e.tier1 != nil && e.tier1.IsTerminated() {
+		return fmt.Errorf("local tier1 terminated: %w", e.tier1.Err())
+	}
+	if e.tier2 != nil && e.tier2.IsTerminated() {
+		return fmt.Errorf("local tier2 terminated: %w", e.tier2.Err())
+	}
+	return nil
+}
+
+func (e *localEngine) Shutdown() {
+	if e.tier1 != nil {
+		e.tier1.Shutdown(nil)
+		<-e.tier1.Terminated()
+	}
+	if e.tier2 != nil {
+		e.tier2.Shutdown(nil)
+		<-e.tier2.Terminated()
+	}
+	e.cleanup()
+}
+
+func (e *localEngine) cleanup() {
+	if err := os.RemoveAll(e.tmpDir); err != nil {
+		e.logger.Warn("unable to remove temporary directory", zap.String("dir", e.tmpDir), zap.Error(err))
+	}
+}
+
+func waitForListener(ctx context.Context, addr string, engine *localEngine) error {
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+
+		if err := engine.terminatedErr(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func freeLocalAddr() (string, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return "", fmt.Errorf("finding free local port: %w", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String(), nil
+}
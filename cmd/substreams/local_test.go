@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewLocalEngineConfig(t *testing.T) {
+	cmd := &cobra.Command{}
+	addLocalEngineFlags(cmd.Flags())
+
+	require.NoError(t, cmd.Flags().Parse(nil))
+	assert.Equal(t, &localEngineConfig{
+		MergedBlocksStoreURL: "file:///blocks",
+		StateBundleSize:      1000,
+		MaxSubrequests:       4,
+	}, newLocalEngineConfig(cmd, "file:///blocks"))
+
+	require.NoError(t, cmd.Flags().Parse([]string{
+		"--local-state-store", "file:///states",
+		"--local-first-streamable-block", "100",
+		"--local-state-bundle-size", "10",
+		"--local-max-subrequests", "2",
+	}))
+	assert.Equal(t, &localEngineConfig{
+		MergedBlocksStoreURL: "file:///blocks",
+		StateStoreURL:        "file:///states",
+		FirstStreamableBlock: 100,
+		StateBundleSize:      10,
+		MaxSubrequests:       2,
+	}, newLocalEngineConfig(cmd, "file:///blocks"))
+}
+
+func TestStartLocalEngine(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	engine, err := startLocalEngine(ctx, &localEngineConfig{
+		MergedBlocksStoreURL: t.TempDir(),
+		BlockType:            "sf.substreams.v1.test.Block",
+		FirstStreamableBlock: 100,
+		StateBundleSize:      10,
+		MaxSubrequests:       2,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", engine.Endpoint)
+	require.NoError(t, err)
+	conn.Close()
+	assert.DirExists(t, engine.tmpDir)
+
+	engine.Shutdown()
+	assert.NoDirExists(t, engine.tmpDir)
+}
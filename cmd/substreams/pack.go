@@ -1,11 +1,14 @@
 package main
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli"
 	"github.com/streamingfast/cli/sflags"
@@ -37,6 +40,12 @@ func init() {
 		replaced by "-") and "<version>" is "package.version" value. You can use "{version}" which resolves
 		to "package.version".
 	`))
+	packCmd.Flags().String("sign", "", cli.FlagDescription(`
+		Path to an ed25519 private key, PEM encoded (as generated by 'openssl genpkey -algorithm ed25519') or
+		hex encoded, used to sign the package. Readers verify the signature and, with '--require-signature',
+		refuse packages not signed by one of their '--trusted-keys'.
+	`))
+	packCmd.Flags().Bool("update-lock", false, "Ignore the content hashes recorded in the manifest's 'substreams.lock' file and refresh it from the imports as they resolve now")
 	//packCmd.Flags().StringArrayP("config", "c", []string{}, cli.FlagDescription(`path to a configuration file that contains overrides for the manifest`))
 }
 
@@ -46,7 +55,25 @@ func runPack(cmd *cobra.Command, args []string) error {
 		manifestPath = args[0]
 	}
 
-	manifestReader, err := manifest.NewReader(manifestPath)
+	var readerOptions []manifest.Option
+	updateLock := sflags.MustGetBool(cmd, "update-lock")
+	if updateLock {
+		readerOptions = append(readerOptions, manifest.WithUpdateLock())
+	}
+
+	var signingKey ed25519.PrivateKey
+	if keyPath := sflags.MustGetString(cmd, "sign"); keyPath != "" {
+		cnt, err := os.ReadFile(keyPath)
+		if err != nil {
+			return fmt.Errorf("reading signing key: %w", err)
+		}
+		signingKey, err = manifest.ParseSigningKey(cnt)
+		if err != nil {
+			return fmt.Errorf("signing key %q: %w", keyPath, err)
+		}
+	}
+
+	manifestReader, err := manifest.NewReader(manifestPath, readerOptions...)
 	if err != nil {
 		return fmt.Errorf("manifest reader: %w", err)
 	}
@@ -80,9 +107,17 @@ func runPack(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("create output directories: %w", err)
 	}
 
-	cnt, err := proto.Marshal(pkgBundle.Package)
-	if err != nil {
-		return fmt.Errorf("marshalling package: %w", err)
+	var cnt []byte
+	if signingKey != nil {
+		cnt, err = manifest.SignPackage(pkgBundle.Package, signingKey)
+		if err != nil {
+			return fmt.Errorf("signing package: %w", err)
+		}
+	} else {
+		cnt, err = proto.Marshal(pkgBundle.Package)
+		if err != nil {
+			return fmt.Errorf("marshalling package: %w", err)
+		}
 	}
 
 	if err := os.WriteFile(resolvedOutputFile, cnt, 0644); err != nil {
@@ -91,7 +126,63 @@ func runPack(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Successfully wrote %q.\n", resolvedOutputFile)
+	if signingKey != nil {
+		fmt.Printf("Signed with key %s.\n", hex.EncodeToString(signingKey.Public().(ed25519.PublicKey)))
+	}
+	if dedup := manifestReader.BinaryDeduplication(); dedup.Count > 0 {
+		fmt.Printf("Deduplicated identical binaries: %d removed, saving %s.\n", dedup.Count, humanize.Bytes(dedup.SavedBytes))
+	}
+
+	if err := writeLockFile(pkgBundle.ManifestPath, manifestReader.ResolvedLock(), updateLock); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// lockManifest reads the manifest at `manifestPath`, verifying its imports against its lock
+// file unless `update` is set, and writes the lock file, for builds that do not pack it.
+func lockManifest(manifestPath string, update bool) error {
+	var readerOptions []manifest.Option
+	if update {
+		readerOptions = append(readerOptions, manifest.WithUpdateLock())
+	}
+
+	manifestReader, err := manifest.NewReader(manifestPath, readerOptions...)
+	if err != nil {
+		return fmt.Errorf("manifest reader: %w", err)
+	}
+
+	pkgBundle, err := manifestReader.Read()
+	if err != nil {
+		return fmt.Errorf("reading manifest %q: %w", manifestPath, err)
+	}
+
+	if pkgBundle == nil {
+		return fmt.Errorf("no package found")
+	}
+
+	return writeLockFile(pkgBundle.ManifestPath, manifestReader.ResolvedLock(), update)
+}
+
+// writeLockFile records the imports of the manifest in its lock file. Imports were verified
+// against the existing lock file while reading, so this only adds newly declared imports
+// (or refreshes everything when `update` is set).
+func writeLockFile(manifestPath string, lock *manifest.Lock, update bool) error {
+	if lock == nil {
+		return nil
+	}
+
+	lockPath := manifest.LockFilePath(manifestPath)
+	if len(lock.Imports) == 0 && !update && !cli.FileExists(lockPath) {
+		return nil
+	}
+
+	if err := lock.WriteFile(lockPath); err != nil {
+		return err
+	}
 
+	fmt.Printf("Successfully wrote %q.\n", lockPath)
 	return nil
 }
 
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/streamingfast/substreams/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	writeFile("dep.yaml", "specVersion: v0.1.0\npackage:\n  name: dep\n  version: v0.0.0\n")
+	writeFile("substreams.yaml", "specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\nimports:\n  dep: ./dep.yaml\n")
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	lockPath := manifest.LockFilePath(manifestPath)
+
+	require.NoError(t, lockManifest(manifestPath, false))
+	lock, err := manifest.ReadLockFile(lockPath)
+	require.NoError(t, err)
+	require.Len(t, lock.Imports, 1)
+	assert.Equal(t, "dep", lock.Imports[0].Name)
+
+	writeFile("dep.yaml", "specVersion: v0.1.0\npackage:\n  name: dep\n  version: v0.0.1\n")
+	assert.Error(t, lockManifest(manifestPath, false))
+
+	require.NoError(t, lockManifest(manifestPath, true))
+	updated, err := manifest.ReadLockFile(lockPath)
+	require.NoError(t, err)
+	assert.NotEqual(t, lock.Imports[0].Sha256, updated.Imports[0].Sha256)
+}
@@ -22,4 +22,7 @@ func init() {
 	// From https://thegraph.com/docs/en/operating-graph-node/
 	rootCmd.PersistentFlags().String("ipfs-url", "https://ipfs.network.thegraph.com", "IPFS endpoint to resolve substreams-based subgraphs as manifest")
 	rootCmd.PersistentFlags().Duration("ipfs-timeout", time.Second*10, "IPFS timeout when resolving substreams-based subgraphs as manifest")
+	rootCmd.PersistentFlags().StringSlice("trusted-keys", nil, "Ed25519 public keys trusted to sign packages, hex encoded or as paths to PEM or hex files")
+	rootCmd.PersistentFlags().Bool("require-signature", false, "Refuse .spkg packages, including imported ones, that are not signed by one of the '--trusted-keys'")
+	rootCmd.PersistentFlags().String("package-index", "", "Local directory or store URL (gs://, s3://, etc.) listing '<name>-<version>.spkg' files, used to resolve versioned imports like 'uniswap-v3: uniswap-v3@^0.2'")
 }
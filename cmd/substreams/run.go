@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli"
@@ -16,6 +18,8 @@ import (
 	"github.com/streamingfast/substreams/tui"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func init() {
@@ -25,11 +29,16 @@ func init() {
 	runCmd.Flags().String("network", "", "Specify the network to use for params and initialBlocks, overriding the 'network' field in the substreams package")
 	runCmd.Flags().StringP("start-block", "s", "", "Start block to stream from. If empty, will be replaced by initialBlock of the first module you are streaming. If negative, will be resolved by the server relative to the chain head")
 	runCmd.Flags().StringP("cursor", "c", "", "Cursor to stream from. Leave blank for no cursor")
+	runCmd.Flags().String("cursor-file", "", "File where the cursor of the last block handled is saved, and from which the stream resumes when it exists. With the 'parquet' and 'csv' output modes, the cursor is saved once the files holding the block are written")
 	runCmd.Flags().StringP("stop-block", "t", "0", "Stop block to end stream at, exclusively. If the start-block is positive, a '+' prefix can indicate 'relative to start-block'")
 	runCmd.Flags().Bool("final-blocks-only", false, "Only process blocks that have pass finality, to prevent any reorg and undo signal by staying further away from the chain HEAD")
 	runCmd.Flags().Bool("insecure", false, "Skip certificate validation on GRPC connection")
 	runCmd.Flags().Bool("plaintext", false, "Establish GRPC connection in plaintext")
-	runCmd.Flags().StringP("output", "o", "", "Output mode, one of: [ui, json, jsonl, clock] Defaults to 'ui' when in a TTY is present, and 'json' otherwise")
+	runCmd.Flags().StringP("output", "o", "", "Output mode, one of: [ui, json, jsonl, ndjson, clock, parquet, csv] Defaults to 'ui' when in a TTY is present, and 'json' otherwise. 'ndjson' writes one versioned event per line (session, data, undo, progress and error), other messages going to stderr. 'parquet' and 'csv' write the flattened output module to files under '--output-dir', and imply '--final-blocks-only'")
+	runCmd.Flags().String("output-dir", "./output", "Directory where the 'parquet' and 'csv' output modes write one sub-directory per table")
+	runCmd.Flags().Uint64("output-rotation-blocks", 0, "With the 'parquet' and 'csv' output modes, start new files every N blocks (aligned on multiples of N). 0 writes a single file per table")
+	runCmd.Flags().String("output-filter", "", "Only receive the blocks whose output module's output matches this expression, evaluated by the server, like 'transfers[].to == \"0xabc\" && amount > 1000'. Blocks with an empty output are not received either")
+	runCmd.Flags().StringSlice("output-fields", nil, "Only receive these fields of the output module's message, removed by the server, like 'transfers.to,transfers.amount'. Paths through repeated fields and maps apply to each of their elements")
 	runCmd.Flags().StringSlice("debug-modules-initial-snapshot", nil, "List of 'store' modules from which to print the initial data snapshot (Unavailable in Production Mode)")
 	runCmd.Flags().StringSlice("debug-modules-output", nil, "List of modules from which to print outputs, deltas and logs (Unavailable in Production Mode)")
 	runCmd.Flags().StringSliceP("header", "H", nil, "Additional headers to be sent in the substreams request")
@@ -38,17 +47,28 @@ func init() {
 	runCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY")
 	runCmd.Flags().String("test-file", "", "runs a test file")
 	runCmd.Flags().Bool("test-verbose", false, "print out all the results")
+	runCmd.Flags().String("compare", "", "Stream the same request from this second endpoint too, reporting the blocks whose outputs differ at the protobuf field level instead of printing them, and exiting with an error when any does")
+	runCmd.Flags().String("compare-manifest", "", "With '--compare', or alone to compare against the same endpoint, take the modules of the second stream from this package")
+	runCmd.Flags().Bool("watch", false, "Build the local manifest with 'substreams build --no-pack' first, then rebuild it each time its manifest, protobuf or binary source files change, restarting the stream from the same start block when the resulting module hashes change")
+	addLocalEngineFlags(runCmd.Flags())
 	rootCmd.AddCommand(runCmd)
 }
 
 // runCmd represents the command to run substreams remotely
 var runCmd = &cobra.Command{
-	Use:   "run [<manifest>] <module_name>",
+	Use:   "run [<manifest>] <module_name>[,<module_name>...]",
 	Short: "Stream module outputs from a given package on a remote endpoint",
 	Long: cli.Dedent(`
 		Stream module outputs from a given package on a remote endpoint. The manifest is optional as it will try to find a file named
 		'substreams.yaml' in current working directory if nothing entered. You may enter a directory that contains a 'substreams.yaml'
 		'substreams.yaml' file in place of '<manifest_file>', or a link to a remote .spkg file, using urls gs://, http(s)://, ipfs://, etc.'.
+
+		Multiple map modules can be streamed together by separating their names with commas, like 'map_a,map_b': their
+		common ancestors are executed only once and each block holds the outputs of all of them.
+
+		With '--local-blocks <merged_blocks_store_url>', no remote endpoint is used: a tier1 and a tier2 are started in
+		process, reading blocks from the given merged-blocks store and writing their state to '--local-state-store'
+		(a temporary directory by default).
 	`),
 	RunE:         runRun,
 	Args:         cobra.RangeArgs(1, 2),
@@ -56,38 +76,73 @@ var runCmd = &cobra.Command{
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
-	ctx := cmd.Context()
+	if sflags.MustGetBool(cmd, "watch") {
+		return runWatch(cmd, args)
+	}
+	return runStream(cmd.Context(), cmd, args)
+}
 
-	var manifestPath, outputModule string
+// runArgs returns the manifest, empty for the default one, and the output modules given to 'run'
+func runArgs(args []string) (manifestPath string, outputModules []string, err error) {
+	var outputModule string
 	if len(args) == 1 {
 		outputModule = args[0]
 
 		// Check common error where manifest is provided by module name is missing
 		if manifest.IsLikelyManifestInput(outputModule) {
-			return fmt.Errorf("missing <module_name> argument, check 'substreams run --help' for more information")
+			return "", nil, fmt.Errorf("missing <module_name> argument, check 'substreams run --help' for more information")
 		}
 	} else {
 		manifestPath = args[0]
 		outputModule = args[1]
 	}
 
-	outputMode := sflags.MustGetString(cmd, "output")
+	return manifestPath, strings.Split(outputModule, ","), nil
+}
 
-	network := sflags.MustGetString(cmd, "network")
-	paramsString := sflags.MustGetStringArray(cmd, "params")
-	params, err := manifest.ParseParams(paramsString)
+// runReaderOptions returns the options with which 'run' reads its manifest
+func runReaderOptions(cmd *cobra.Command, outputModules []string) ([]manifest.Option, error) {
+	params, err := manifest.ParseParams(sflags.MustGetStringArray(cmd, "params"))
 	if err != nil {
-		return fmt.Errorf("parsing params: %w", err)
+		return nil, fmt.Errorf("parsing params: %w", err)
 	}
 
 	readerOptions := []manifest.Option{
-		manifest.WithOverrideOutputModule(outputModule),
-		manifest.WithOverrideNetwork(network),
+		manifest.WithOverrideOutputModules(outputModules...),
+		manifest.WithOverrideNetwork(sflags.MustGetString(cmd, "network")),
 		manifest.WithParams(params),
 	}
 	if sflags.MustGetBool(cmd, "skip-package-validation") {
 		readerOptions = append(readerOptions, manifest.SkipPackageValidationReader())
 	}
+	return readerOptions, nil
+}
+
+func runStream(ctx context.Context, cmd *cobra.Command, args []string) error {
+	manifestPath, outputModules, err := runArgs(args)
+	if err != nil {
+		return err
+	}
+	outputModule := outputModules[0]
+
+	outputMode := sflags.MustGetString(cmd, "output")
+	fileOutput := false
+	// statusOut receives the messages that are not part of the output, kept out of the NDJSON events
+	var statusOut io.Writer = os.Stdout
+	if mode, err := tui.ParseOutputMode(outputMode); err == nil {
+		fileOutput = outputMode != "" && (mode == tui.OutputModePARQUET || mode == tui.OutputModeCSV)
+		if mode == tui.OutputModeNDJSON {
+			statusOut = os.Stderr
+		}
+	}
+	if fileOutput && len(outputModules) > 1 {
+		return fmt.Errorf("output mode %q writes a single output module, got %d", outputMode, len(outputModules))
+	}
+
+	readerOptions, err := runReaderOptions(cmd, outputModules)
+	if err != nil {
+		return err
+	}
 
 	manifestReader, err := manifest.NewReader(manifestPath, readerOptions...)
 	if err != nil {
@@ -103,9 +158,13 @@ func runRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no package found")
 	}
 
-	endpoint, err := manifest.ExtractNetworkEndpoint(pkgBundle.Package.Network, sflags.MustGetString(cmd, "substreams-endpoint"), zlog)
-	if err != nil {
-		return fmt.Errorf("extracting endpoint: %w", err)
+	localBlocks := sflags.MustGetString(cmd, "local-blocks")
+	var endpoint string
+	if localBlocks == "" {
+		endpoint, err = manifest.ExtractNetworkEndpoint(pkgBundle.Package.Network, sflags.MustGetString(cmd, "substreams-endpoint"), zlog)
+		if err != nil {
+			return fmt.Errorf("extracting endpoint: %w", err)
+		}
 	}
 
 	msgDescs, err := manifest.BuildMessageDescriptors(pkgBundle.Package)
@@ -143,20 +202,44 @@ func runRun(cmd *cobra.Command, args []string) error {
 	}
 
 	if readFromModule {
-		sb, err := pkgBundle.Graph.ModuleInitialBlock(outputModule)
-		if err != nil {
-			return fmt.Errorf("getting module start block: %w", err)
+		for _, mod := range outputModules {
+			sb, err := pkgBundle.Graph.ModuleInitialBlock(mod)
+			if err != nil {
+				return fmt.Errorf("getting module start block: %w", err)
+			}
+			startBlock = max(startBlock, int64(sb))
 		}
-		startBlock = int64(sb)
 	}
 
 	authToken, authType := tools.GetAuth(cmd, "substreams-api-key-envvar", "substreams-api-token-envvar")
+	insecure := sflags.MustGetBool(cmd, "insecure")
+	plaintext := sflags.MustGetBool(cmd, "plaintext")
+
+	var compareClientConfig *client.SubstreamsClientConfig
+	compareEndpoint := sflags.MustGetString(cmd, "compare")
+	if compareEndpoint != "" {
+		// the local engine replaces the first endpoint only
+		compareClientConfig = client.NewSubstreamsClientConfig(compareEndpoint, authToken, authType, insecure, plaintext)
+	}
+
+	if localBlocks != "" {
+		engine, err := startLocalEngine(ctx, newLocalEngineConfig(cmd, localBlocks), zlog)
+		if err != nil {
+			return fmt.Errorf("starting local engine: %w", err)
+		}
+		defer engine.Shutdown()
+
+		endpoint = engine.Endpoint
+		authToken, authType = "", client.None
+		insecure, plaintext = false, true
+	}
+
 	substreamsClientConfig := client.NewSubstreamsClientConfig(
 		endpoint,
 		authToken,
 		authType,
-		sflags.MustGetBool(cmd, "insecure"),
-		sflags.MustGetBool(cmd, "plaintext"),
+		insecure,
+		plaintext,
 	)
 
 	ssClient, connClose, callOpts, headers, err := client.NewSubstreamsClient(substreamsClientConfig)
@@ -172,26 +255,126 @@ func runRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("stop block: %w", err)
 	}
 
+	var cursors *cursorFile
+	if path := sflags.MustGetString(cmd, "cursor-file"); path != "" {
+		if cursorStr != "" {
+			return fmt.Errorf("cannot use both --cursor and --cursor-file")
+		}
+
+		cursors = &cursorFile{path: path}
+		cursorStr, err = cursors.read()
+		if err != nil {
+			return err
+		}
+		if cursorStr != "" {
+			fmt.Fprintf(statusOut, "Resuming from cursor saved in %q\n", path)
+		}
+	}
+
 	req := &pbsubstreamsrpc.Request{
 		StartBlockNum:                       startBlock,
 		StartCursor:                         cursorStr,
 		StopBlockNum:                        stopBlock,
-		FinalBlocksOnly:                     sflags.MustGetBool(cmd, "final-blocks-only"),
+		FinalBlocksOnly:                     sflags.MustGetBool(cmd, "final-blocks-only") || fileOutput,
 		Modules:                             pkgBundle.Package.Modules,
 		OutputModule:                        outputModule,
 		ProductionMode:                      productionMode,
 		DebugInitialStoreSnapshotForModules: debugModulesInitialSnapshot,
 	}
+	if len(outputModules) > 1 {
+		req.OutputModules = outputModules
+	}
+	if expression := sflags.MustGetString(cmd, "output-filter"); expression != "" {
+		req.OutputFilter = &pbsubstreamsrpc.OutputFilter{Expression: expression}
+		req.OutputProtoFiles = pkgBundle.Package.ProtoFiles
+	}
+	if paths := sflags.MustGetStringSlice(cmd, "output-fields"); len(paths) != 0 {
+		req.OutputFieldMask = &fieldmaskpb.FieldMask{Paths: paths}
+		req.OutputProtoFiles = pkgBundle.Package.ProtoFiles
+	}
 
 	if err := req.Validate(); err != nil {
 		return fmt.Errorf("validate request: %w", err)
 	}
+
+	if compareManifest := sflags.MustGetString(cmd, "compare-manifest"); compareEndpoint != "" || compareManifest != "" {
+		if fileOutput || cursors != nil || testRunner != nil {
+			return fmt.Errorf("cannot use --compare or --compare-manifest with the %q output mode, --cursor-file or --test-file", outputMode)
+		}
+
+		first := &compareSide{
+			name:     endpoint,
+			client:   ssClient,
+			callOpts: callOpts,
+			headers:  outgoingHeaders(cmd, headers),
+			request:  req,
+			msgDescs: msgDescs,
+		}
+		second := *first
+		if compareClientConfig != nil {
+			compareClient, compareConnClose, compareCallOpts, compareHeaders, err := client.NewSubstreamsClient(compareClientConfig)
+			if err != nil {
+				return fmt.Errorf("substreams client setup for %q: %w", compareEndpoint, err)
+			}
+			defer compareConnClose()
+
+			second.name = compareEndpoint
+			second.client, second.callOpts, second.headers = compareClient, compareCallOpts, outgoingHeaders(cmd, compareHeaders)
+		}
+
+		if compareManifest != "" {
+			firstManifest := manifestPath
+			if firstManifest == "" {
+				firstManifest = "substreams.yaml"
+			}
+			first.name += " with " + firstManifest
+			second.name += " with " + compareManifest
+
+			compareReader, err := manifest.NewReader(compareManifest, readerOptions...)
+			if err != nil {
+				return fmt.Errorf("manifest reader: %w", err)
+			}
+			comparePkgBundle, err := compareReader.Read()
+			if err != nil {
+				return fmt.Errorf("read manifest %q: %w", compareManifest, err)
+			}
+
+			second.msgDescs, err = manifest.BuildMessageDescriptors(comparePkgBundle.Package)
+			if err != nil {
+				return fmt.Errorf("building message descriptors of %q: %w", compareManifest, err)
+			}
+
+			second.request = proto.Clone(req).(*pbsubstreamsrpc.Request)
+			second.request.Modules = comparePkgBundle.Package.Modules
+			if second.request.OutputProtoFiles != nil {
+				second.request.OutputProtoFiles = comparePkgBundle.Package.ProtoFiles
+			}
+			if err := second.request.Validate(); err != nil {
+				return fmt.Errorf("validate request of %q: %w", compareManifest, err)
+			}
+		}
+
+		return runCompare(ctx, [2]*compareSide{first, &second}, os.Stdout)
+	}
 	toPrint := debugModulesOutput
 	if toPrint == nil {
-		toPrint = []string{outputModule}
+		toPrint = req.OutputModuleNames()
+	}
+
+	// lastCursor is the cursor of the last block (or undo signal) handled
+	var lastCursor string
+	var saveCursorErr error
+	saveCursor := func() {
+		if cursors != nil && lastCursor != "" && saveCursorErr == nil {
+			saveCursorErr = cursors.write(lastCursor)
+		}
 	}
 
 	ui := tui.New(req, pkgBundle.Package, toPrint)
+	ui.SetFileOutput(sflags.MustGetString(cmd, "output-dir"), sflags.MustGetUint64(cmd, "output-rotation-blocks"))
+	if fileOutput {
+		ui.OnFileOutputClosed(saveCursor)
+	}
 	if err := ui.Init(outputMode); err != nil {
 		return fmt.Errorf("TUI initialization: %w", err)
 	}
@@ -200,25 +383,14 @@ func runRun(cmd *cobra.Command, args []string) error {
 	streamCtx, cancel := context.WithCancel(ctx)
 	ui.OnTerminated(func(err error) {
 		if err != nil {
-			fmt.Printf("UI terminated with error %q\n", err)
+			fmt.Fprintf(statusOut, "UI terminated with error %q\n", err)
 		}
 
 		cancel()
 	})
 	defer cancel()
 
-	// add additional authorization headers
-	if headers.IsSet() {
-		streamCtx = metadata.AppendToOutgoingContext(streamCtx, headers.ToArray()...)
-	}
-	//parse additional-headers flag
-	additionalHeaders := sflags.MustGetStringSlice(cmd, "header")
-	if additionalHeaders != nil {
-		res := parseHeaders(additionalHeaders)
-		headerArray := make([]string, 0, len(res)*2)
-		for k, v := range res {
-			headerArray = append(headerArray, k, v)
-		}
+	if headerArray := outgoingHeaders(cmd, headers); len(headerArray) != 0 {
 		streamCtx = metadata.AppendToOutgoingContext(streamCtx, headerArray...)
 	}
 
@@ -226,7 +398,9 @@ func runRun(cmd *cobra.Command, args []string) error {
 	ui.Connecting()
 	cli, err := ssClient.Blocks(streamCtx, req, callOpts...)
 	if err != nil && streamCtx.Err() != context.Canceled {
-		return fmt.Errorf("call sf.substreams.rpc.v2.Stream/Blocks: %w", err)
+		err = fmt.Errorf("call sf.substreams.rpc.v2.Stream/Blocks: %w", err)
+		ui.StreamError(err)
+		return err
 	}
 	ui.Connected()
 
@@ -234,14 +408,35 @@ func runRun(cmd *cobra.Command, args []string) error {
 		resp, err := cli.Recv()
 		if resp != nil {
 			if err := ui.IncomingMessage(ctx, resp, testRunner); err != nil {
-				fmt.Printf("RETURN HANDLER ERROR: %s\n", err)
+				if fileOutput {
+					// the files would silently miss some blocks
+					return err
+				}
+				fmt.Fprintf(statusOut, "RETURN HANDLER ERROR: %s\n", err)
+			}
+
+			if cursor, ok := responseCursor(resp); ok {
+				lastCursor = cursor
+				// with file output, the cursor is saved once the files holding the block are written
+				if !fileOutput {
+					saveCursor()
+				}
+				if saveCursorErr != nil {
+					return saveCursorErr
+				}
 			}
 		}
 		if err != nil {
 			if err == io.EOF {
 				ui.Cancel()
-				fmt.Println("Total Read Bytes (server-side consumption):", ui.TotalReadBytes)
-				fmt.Println("all done")
+				if err := ui.CloseFileOutput(); err != nil {
+					return fmt.Errorf("writing output files: %w", err)
+				}
+				if saveCursorErr != nil {
+					return saveCursorErr
+				}
+				fmt.Fprintln(statusOut, "Total Read Bytes (server-side consumption):", ui.TotalReadBytes)
+				fmt.Fprintln(statusOut, "all done")
 				if testRunner != nil {
 					testRunner.LogResults()
 				}
@@ -252,10 +447,30 @@ func runRun(cmd *cobra.Command, args []string) error {
 			// Special handling if interrupted the context ourselves, no error
 			if streamCtx.Err() == context.Canceled {
 				ui.Cancel()
-				return nil
+				if err := ui.CloseFileOutput(); err != nil {
+					return err
+				}
+				return saveCursorErr
 			}
 
+			if closeErr := ui.CloseFileOutput(); closeErr != nil {
+				zlog.Warn("unable to write output files", zap.Error(closeErr))
+			}
+			ui.StreamError(err)
 			return err
 		}
 	}
 }
+
+// outgoingHeaders returns the authorization headers of the client followed by the ones of the
+// 'header' flag, as key/value pairs
+func outgoingHeaders(cmd *cobra.Command, headers client.Headers) []string {
+	var headerArray []string
+	if headers.IsSet() {
+		headerArray = append(headerArray, headers.ToArray()...)
+	}
+	for k, v := range parseHeaders(sflags.MustGetStringSlice(cmd, "header")) {
+		headerArray = append(headerArray, k, v)
+	}
+	return headerArray
+}
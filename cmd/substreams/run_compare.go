@@ -0,0 +1,438 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+)
+
+// compareSide is one of the two streams of 'run --compare'
+type compareSide struct {
+	name     string
+	client   pbsubstreamsrpc.StreamClient
+	callOpts []grpc.CallOption
+	headers  []string
+	request  *pbsubstreamsrpc.Request
+	msgDescs map[string]*manifest.ModuleDescriptor
+}
+
+type compareEvent struct {
+	side int
+	resp *pbsubstreamsrpc.Response
+	err  error
+}
+
+// runCompare streams the request of both sides and reports the blocks whose outputs differ
+// to `out`, returning an error when any does.
+func runCompare(ctx context.Context, sides [2]*compareSide, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fmt.Fprintf(out, "Comparing the outputs of %s and %s\n", sides[0].name, sides[1].name)
+
+	events := make(chan *compareEvent)
+	for i, side := range sides {
+		go side.stream(ctx, i, events)
+	}
+
+	comparator := newStreamComparator(
+		[2]string{sides[0].name, sides[1].name},
+		[2]map[string]*manifest.ModuleDescriptor{sides[0].msgDescs, sides[1].msgDescs},
+		out,
+	)
+	for ended := 0; ended < len(sides); {
+		var event *compareEvent
+		select {
+		case event = <-events:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if event.err == io.EOF {
+			comparator.end(event.side)
+			ended++
+			continue
+		}
+		if event.err != nil {
+			return fmt.Errorf("%s: %w", sides[event.side].name, event.err)
+		}
+		if fatal := event.resp.GetFatalError(); fatal != nil {
+			return fmt.Errorf("%s: fatal error: %s", sides[event.side].name, fatal.Reason)
+		}
+		comparator.handle(event.side, event.resp)
+	}
+
+	fmt.Fprintf(out, "Compared %d blocks, %d differ\n", comparator.compared, comparator.differing)
+	if comparator.differing != 0 {
+		return fmt.Errorf("outputs differ on %d of %d blocks", comparator.differing, comparator.compared)
+	}
+	return nil
+}
+
+func (s *compareSide) stream(ctx context.Context, index int, events chan<- *compareEvent) {
+	send := func(event *compareEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if len(s.headers) != 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, s.headers...)
+	}
+
+	stream, err := s.client.Blocks(ctx, s.request, s.callOpts...)
+	if err != nil {
+		send(&compareEvent{side: index, err: fmt.Errorf("call sf.substreams.rpc.v2.Stream/Blocks: %w", err)})
+		return
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if resp != nil && !send(&compareEvent{side: index, resp: resp}) {
+			return
+		}
+		if err != nil {
+			send(&compareEvent{side: index, err: err})
+			return
+		}
+	}
+}
+
+// streamComparator aligns the blocks of two streams by number and compares them once they
+// are final on both sides, so that blocks undone on either side are never compared.
+type streamComparator struct {
+	names    [2]string
+	msgDescs [2]map[string]*manifest.ModuleDescriptor
+	out      io.Writer
+
+	blocks      [2]map[uint64]*pbsubstreamsrpc.BlockScopedData
+	lastBlock   [2]uint64
+	finalHeight [2]uint64
+	ended       [2]bool
+
+	compared  int
+	differing int
+}
+
+func newStreamComparator(names [2]string, msgDescs [2]map[string]*manifest.ModuleDescriptor, out io.Writer) *streamComparator {
+	return &streamComparator{
+		names:    names,
+		msgDescs: msgDescs,
+		out:      out,
+		blocks: [2]map[uint64]*pbsubstreamsrpc.BlockScopedData{
+			make(map[uint64]*pbsubstreamsrpc.BlockScopedData),
+			make(map[uint64]*pbsubstreamsrpc.BlockScopedData),
+		},
+	}
+}
+
+func (c *streamComparator) handle(side int, resp *pbsubstreamsrpc.Response) {
+	switch m := resp.Message.(type) {
+	case *pbsubstreamsrpc.Response_BlockScopedData:
+		data := m.BlockScopedData
+		num := data.Clock.GetNumber()
+		c.blocks[side][num] = data
+		c.lastBlock[side] = num
+		c.finalHeight[side] = data.FinalBlockHeight
+		c.flush()
+
+	case *pbsubstreamsrpc.Response_BlockUndoSignal:
+		lastValid := m.BlockUndoSignal.LastValidBlock.GetNumber()
+		for num := range c.blocks[side] {
+			if num > lastValid {
+				delete(c.blocks[side], num)
+			}
+		}
+		c.lastBlock[side] = lastValid
+	}
+}
+
+// end marks the stream of `side` as complete, every block it did not send is then missing
+func (c *streamComparator) end(side int) {
+	c.ended[side] = true
+	c.flush()
+}
+
+// resolved returns whether both sides went past block `num` and cannot undo it anymore
+func (c *streamComparator) resolved(num uint64) bool {
+	for side := range c.blocks {
+		if !c.ended[side] && (num > c.lastBlock[side] || num > c.finalHeight[side]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *streamComparator) flush() {
+	var nums []uint64
+	for side := range c.blocks {
+		for num := range c.blocks[side] {
+			if side == 1 && c.blocks[0][num] != nil {
+				continue
+			}
+			if c.resolved(num) {
+				nums = append(nums, num)
+			}
+		}
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+	for _, num := range nums {
+		c.compareBlock(num, c.blocks[0][num], c.blocks[1][num])
+		delete(c.blocks[0], num)
+		delete(c.blocks[1], num)
+	}
+}
+
+func (c *streamComparator) compareBlock(num uint64, a, b *pbsubstreamsrpc.BlockScopedData) {
+	c.compared++
+
+	var diffs []string
+	var id string
+	switch {
+	case b == nil:
+		id = a.Clock.GetId()
+		diffs = []string{fmt.Sprintf("block only received from %s", c.names[0])}
+	case a == nil:
+		id = b.Clock.GetId()
+		diffs = []string{fmt.Sprintf("block only received from %s", c.names[1])}
+	case a.Clock.GetId() != b.Clock.GetId():
+		id = a.Clock.GetId()
+		diffs = []string{fmt.Sprintf("block id: %s != %s", a.Clock.GetId(), b.Clock.GetId())}
+	default:
+		id = a.Clock.GetId()
+		diffs = c.diffOutputs(a, b)
+	}
+
+	if len(diffs) == 0 {
+		return
+	}
+
+	c.differing++
+	fmt.Fprintf(c.out, "Block #%d (%s): %d differences\n", num, id, len(diffs))
+	for _, diff := range diffs {
+		fmt.Fprintf(c.out, "  %s\n", diff)
+	}
+}
+
+func blockOutputs(data *pbsubstreamsrpc.BlockScopedData) []*pbsubstreamsrpc.MapModuleOutput {
+	if len(data.Outputs) != 0 {
+		return data.Outputs
+	}
+	if data.Output != nil {
+		return []*pbsubstreamsrpc.MapModuleOutput{data.Output}
+	}
+	return nil
+}
+
+func (c *streamComparator) diffOutputs(a, b *pbsubstreamsrpc.BlockScopedData) (diffs []string) {
+	outputsB := map[string]*pbsubstreamsrpc.MapModuleOutput{}
+	for _, output := range blockOutputs(b) {
+		outputsB[output.Name] = output
+	}
+
+	for _, outputA := range blockOutputs(a) {
+		outputB, found := outputsB[outputA.Name]
+		if !found {
+			diffs = append(diffs, fmt.Sprintf("%s: output only received from %s", outputA.Name, c.names[0]))
+			continue
+		}
+		delete(outputsB, outputA.Name)
+		diffs = append(diffs, c.diffOutput(outputA.Name, outputA.GetMapOutput().GetValue(), outputB.GetMapOutput().GetValue())...)
+	}
+
+	for _, outputB := range blockOutputs(b) {
+		if _, found := outputsB[outputB.Name]; found {
+			diffs = append(diffs, fmt.Sprintf("%s: output only received from %s", outputB.Name, c.names[1]))
+		}
+	}
+	return diffs
+}
+
+func (c *streamComparator) diffOutput(module string, a, b []byte) []string {
+	descA, descB := c.msgDescs[0][module], c.msgDescs[1][module]
+	if descA == nil || descA.MessageDescriptor == nil || descB == nil || descB.MessageDescriptor == nil {
+		if bytes.Equal(a, b) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: output bytes differ (%d bytes != %d bytes)", module, len(a), len(b))}
+	}
+
+	msgA := dynamicpb.NewMessage(descA.MessageDescriptor.UnwrapMessage())
+	if err := proto.Unmarshal(a, msgA); err != nil {
+		return []string{fmt.Sprintf("%s: decoding output from %s: %s", module, c.names[0], err)}
+	}
+	msgB := dynamicpb.NewMessage(descB.MessageDescriptor.UnwrapMessage())
+	if err := proto.Unmarshal(b, msgB); err != nil {
+		return []string{fmt.Sprintf("%s: decoding output from %s: %s", module, c.names[1], err)}
+	}
+
+	return diffMessages(module, msgA, msgB, nil)
+}
+
+// diffMessages appends to `diffs` the fields that differ between `a` and `b`, matched by
+// number as the two messages may come from different versions of the protobuf definitions.
+func diffMessages(path string, a, b protoreflect.Message, diffs []string) []string {
+	fieldsA, fieldsB := a.Descriptor().Fields(), b.Descriptor().Fields()
+	for i := 0; i < fieldsA.Len(); i++ {
+		fieldA := fieldsA.Get(i)
+		fieldB := fieldsB.ByNumber(fieldA.Number())
+		fieldPath := path + "." + string(fieldA.Name())
+
+		if fieldB == nil || fieldB.Kind() != fieldA.Kind() || fieldB.IsList() != fieldA.IsList() || fieldB.IsMap() != fieldA.IsMap() {
+			if a.Has(fieldA) {
+				diffs = append(diffs, fmt.Sprintf("%s: %s != <undefined>", fieldPath, formatField(fieldA, a.Get(fieldA))))
+			}
+			if fieldB != nil && b.Has(fieldB) {
+				diffs = append(diffs, fmt.Sprintf("%s: <undefined> != %s", fieldPath, formatField(fieldB, b.Get(fieldB))))
+			}
+			continue
+		}
+
+		switch {
+		case fieldA.IsList():
+			diffs = diffLists(fieldPath, fieldA, fieldB, a.Get(fieldA).List(), b.Get(fieldB).List(), diffs)
+		case fieldA.IsMap():
+			diffs = diffMaps(fieldPath, fieldA, fieldB, a.Get(fieldA).Map(), b.Get(fieldB).Map(), diffs)
+		case fieldA.Message() != nil && (!a.Has(fieldA) || !b.Has(fieldB)):
+			if a.Has(fieldA) || b.Has(fieldB) {
+				diffs = append(diffs, fmt.Sprintf("%s: %s != %s", fieldPath, formatField(fieldA, a.Get(fieldA)), formatField(fieldB, b.Get(fieldB))))
+			}
+		default:
+			diffs = diffValues(fieldPath, fieldA, fieldB, a.Get(fieldA), b.Get(fieldB), diffs)
+		}
+	}
+
+	for i := 0; i < fieldsB.Len(); i++ {
+		fieldB := fieldsB.Get(i)
+		if fieldsA.ByNumber(fieldB.Number()) == nil && b.Has(fieldB) {
+			diffs = append(diffs, fmt.Sprintf("%s.%s: <undefined> != %s", path, fieldB.Name(), formatField(fieldB, b.Get(fieldB))))
+		}
+	}
+	return diffs
+}
+
+func diffLists(path string, fieldA, fieldB protoreflect.FieldDescriptor, a, b protoreflect.List, diffs []string) []string {
+	for i := 0; i < max(a.Len(), b.Len()); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= a.Len():
+			diffs = append(diffs, fmt.Sprintf("%s: <missing> != %s", elemPath, formatValue(fieldB, b.Get(i))))
+		case i >= b.Len():
+			diffs = append(diffs, fmt.Sprintf("%s: %s != <missing>", elemPath, formatValue(fieldA, a.Get(i))))
+		default:
+			diffs = diffValues(elemPath, fieldA, fieldB, a.Get(i), b.Get(i), diffs)
+		}
+	}
+	return diffs
+}
+
+func diffMaps(path string, fieldA, fieldB protoreflect.FieldDescriptor, a, b protoreflect.Map, diffs []string) []string {
+	keys := map[string]protoreflect.MapKey{}
+	collect := func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[formatValue(fieldA.MapKey(), key.Value())] = key
+		return true
+	}
+	a.Range(collect)
+	b.Range(collect)
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := keys[name]
+		entryPath := fmt.Sprintf("%s[%s]", path, name)
+		switch {
+		case !a.Has(key):
+			diffs = append(diffs, fmt.Sprintf("%s: <missing> != %s", entryPath, formatValue(fieldB.MapValue(), b.Get(key))))
+		case !b.Has(key):
+			diffs = append(diffs, fmt.Sprintf("%s: %s != <missing>", entryPath, formatValue(fieldA.MapValue(), a.Get(key))))
+		default:
+			diffs = diffValues(entryPath, fieldA.MapValue(), fieldB.MapValue(), a.Get(key), b.Get(key), diffs)
+		}
+	}
+	return diffs
+}
+
+// diffValues compares a singular value, or an element of a list or map
+func diffValues(path string, fieldA, fieldB protoreflect.FieldDescriptor, a, b protoreflect.Value, diffs []string) []string {
+	if fieldA.Message() != nil {
+		return diffMessages(path, a.Message(), b.Message(), diffs)
+	}
+
+	formattedA, formattedB := formatValue(fieldA, a), formatValue(fieldB, b)
+	if formattedA != formattedB {
+		diffs = append(diffs, fmt.Sprintf("%s: %s != %s", path, formattedA, formattedB))
+	}
+	return diffs
+}
+
+// formatField formats the whole value of a field, lists and maps included
+func formatField(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch {
+	case field.IsList():
+		list := value.List()
+		elements := make([]string, list.Len())
+		for i := range elements {
+			elements[i] = formatValue(field, list.Get(i))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case field.IsMap():
+		var entries []string
+		value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			entries = append(entries, formatValue(field.MapKey(), key.Value())+": "+formatValue(field.MapValue(), value))
+			return true
+		})
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ", ") + "}"
+	case field.Message() != nil && !value.Message().IsValid():
+		return "<unset>"
+	}
+	return formatValue(field, value)
+}
+
+// formatValue formats a singular value, or an element of a list or map
+func formatValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return strconv.Quote(value.String())
+	case protoreflect.BytesKind:
+		return "0x" + hex.EncodeToString(value.Bytes())
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := value.Message()
+		var fields []string
+		for i := 0; i < msg.Descriptor().Fields().Len(); i++ {
+			field := msg.Descriptor().Fields().Get(i)
+			if msg.Has(field) {
+				fields = append(fields, string(field.Name())+": "+formatField(field, msg.Get(field)))
+			}
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+	return value.String()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestStreamComparator(t *testing.T) {
+	msgDescs, err := manifest.BuildMessageDescriptors(&pbsubstreams.Package{
+		ProtoFiles: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(pbsubstreams.File_sf_substreams_v1_modules_proto)},
+		Modules: &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{{
+			Name: "map_a",
+			Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:sf.substreams.v1.Modules"}},
+		}}},
+	})
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	c := newStreamComparator([2]string{"a", "b"}, [2]map[string]*manifest.ModuleDescriptor{msgDescs, msgDescs}, out)
+
+	block := func(side int, num uint64, id string, finalHeight uint64, output *pbsubstreams.Modules) {
+		value, err := proto.Marshal(output)
+		require.NoError(t, err)
+		c.handle(side, &pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockScopedData{BlockScopedData: &pbsubstreamsrpc.BlockScopedData{
+			Clock:            &pbsubstreams.Clock{Number: num, Id: id},
+			FinalBlockHeight: finalHeight,
+			Output:           &pbsubstreamsrpc.MapModuleOutput{Name: "map_a", MapOutput: &anypb.Any{Value: value}},
+		}}})
+	}
+	undo := func(side int, num uint64) {
+		c.handle(side, &pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockUndoSignal{BlockUndoSignal: &pbsubstreamsrpc.BlockUndoSignal{
+			LastValidBlock: &pbsubstreams.BlockRef{Number: num},
+		}}})
+	}
+	modules := func(names ...string) *pbsubstreams.Modules {
+		out := &pbsubstreams.Modules{}
+		for _, name := range names {
+			out.Modules = append(out.Modules, &pbsubstreams.Module{Name: name, InitialBlock: 10})
+		}
+		return out
+	}
+
+	block(0, 1, "1a", 1, modules("x"))
+	block(1, 1, "1a", 1, modules("x"))
+	assert.Equal(t, 1, c.compared, "final on both sides")
+
+	block(0, 2, "2a", 1, modules("x"))
+	block(0, 3, "3a", 1, modules("x"))
+	block(1, 2, "2b", 1, modules("y"))
+	undo(1, 1)
+	block(1, 2, "2a", 1, modules("x", "z"))
+	block(1, 3, "3a", 3, &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{{Name: "x", InitialBlock: 11}}})
+	assert.Equal(t, 1, c.compared, "blocks are not final on the first side")
+
+	block(0, 4, "4a", 4, modules("x"))
+	assert.Equal(t, 3, c.compared)
+
+	block(0, 5, "5a", 4, modules("x"))
+	c.end(0)
+	c.end(1)
+	assert.Equal(t, 5, c.compared)
+	assert.Equal(t, 4, c.differing)
+
+	assert.Equal(t, `Block #2 (2a): 1 differences
+  map_a.modules[1]: <missing> != {name: "z", initial_block: 10}
+Block #3 (3a): 1 differences
+  map_a.modules[0].initial_block: 10 != 11
+Block #4 (4a): 1 differences
+  block only received from a
+Block #5 (5a): 1 differences
+  block only received from a
+`, out.String())
+}
+
+func TestDiffMessages(t *testing.T) {
+	a := &pbsubstreams.Module{
+		Name:         "map_a",
+		Kind:         &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:a"}},
+		BlockFilter:  &pbsubstreams.Module_BlockFilter{Module: "index"},
+		Inputs:       []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Params_{Params: &pbsubstreams.Module_Input_Params{Value: "1"}}}},
+		InitialBlock: 10,
+	}
+	b := &pbsubstreams.Module{
+		Name:         "map_a",
+		Kind:         &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{UpdatePolicy: pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD}},
+		Inputs:       []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Params_{Params: &pbsubstreams.Module_Input_Params{Value: "2"}}}},
+		InitialBlock: 10,
+	}
+
+	assert.Equal(t, []string{
+		`m.kind_map: {output_type: "proto:a"} != <unset>`,
+		`m.kind_store: <unset> != {update_policy: UPDATE_POLICY_ADD}`,
+		`m.inputs[0].params.value: "1" != "2"`,
+		`m.block_filter: {module: "index"} != <unset>`,
+	}, diffMessages("m", a.ProtoReflect(), b.ProtoReflect(), nil))
+
+	assert.Empty(t, diffMessages("m", a.ProtoReflect(), proto.Clone(a).ProtoReflect(), nil))
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+
+	"github.com/streamingfast/substreams/manifest"
+	"github.com/streamingfast/substreams/tui"
+)
+
+// runWatch streams like 'run' does, rebuilding the manifest each time its sources change and restarting the
+// stream from the same start block when the hashes of the output modules change. The messages of the watcher
+// and the output of the builds go to stderr, leaving the output of the stream alone on stdout.
+func runWatch(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	manifestPath, outputModules, err := runArgs(args)
+	if err != nil {
+		return err
+	}
+	if sflags.MustGetString(cmd, "cursor") != "" || sflags.MustGetString(cmd, "cursor-file") != "" {
+		return fmt.Errorf("cannot use --watch with --cursor or --cursor-file, the stream restarting from its start block")
+	}
+	if sflags.MustGetString(cmd, "compare") != "" || sflags.MustGetString(cmd, "compare-manifest") != "" {
+		return fmt.Errorf("cannot use --watch with --compare or --compare-manifest")
+	}
+	if mode, err := tui.ParseOutputMode(sflags.MustGetString(cmd, "output")); err == nil && (mode == tui.OutputModePARQUET || mode == tui.OutputModeCSV) {
+		return fmt.Errorf("cannot use --watch with the %q output mode", mode)
+	}
+
+	manifestPath, err = watchedManifestPath(manifestPath)
+	if err != nil {
+		return fmt.Errorf("resolving manifest: %w", err)
+	}
+	readerOptions, err := runReaderOptions(cmd, outputModules)
+	if err != nil {
+		return err
+	}
+	streamArgs := []string{manifestPath, strings.Join(outputModules, ",")}
+
+	watcher, err := newSourceWatcher(manifestPath, "default")
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	fmt.Fprintf(os.Stderr, "Building %s\n", manifestPath)
+	if err := watcher.Build(ctx, os.Stderr); err != nil {
+		return fmt.Errorf("building %q: %w", manifestPath, err)
+	}
+	hashes, err := packageModuleHashes(manifestPath, readerOptions, outputModules)
+	if err != nil {
+		return err
+	}
+
+	for {
+		streamCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := runStream(streamCtx, cmd, streamArgs)
+			if streamCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Stream failed: %s\n", err)
+			}
+			fmt.Fprintln(os.Stderr, "Waiting for changes to the sources, press Ctrl-C to exit")
+		}()
+
+		err := waitModulesChange(ctx, watcher, manifestPath, readerOptions, outputModules, hashes)
+		cancel()
+		<-done
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "\n--- Modules changed, restarting the stream ---\n\n")
+	}
+}
+
+// waitModulesChange rebuilds the manifest on each change of its sources until the hashes of the given modules
+// differ from hashes, which are updated. Failed builds are reported, leaving the current stream running.
+func waitModulesChange(ctx context.Context, watcher *sourceWatcher, manifestPath string, readerOptions []manifest.Option, modules []string, hashes map[string]string) error {
+	for {
+		if err := watcher.Next(ctx); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "Sources changed, rebuilding...")
+		if err := watcher.Build(ctx, os.Stderr); err != nil {
+			fmt.Fprintf(os.Stderr, "Build failed, keeping the current stream: %s\n", err)
+			continue
+		}
+
+		newHashes, err := packageModuleHashes(manifestPath, readerOptions, modules)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Reading the package failed, keeping the current stream: %s\n", err)
+			continue
+		}
+		if maps.Equal(hashes, newHashes) {
+			fmt.Fprintln(os.Stderr, "Modules unchanged, keeping the current stream")
+			continue
+		}
+
+		maps.Copy(hashes, newHashes)
+		return nil
+	}
+}
@@ -5,6 +5,7 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
 	"github.com/streamingfast/cli/sflags"
 	"github.com/streamingfast/logging"
 	"github.com/streamingfast/substreams/manifest"
@@ -15,6 +16,16 @@ import (
 func setup(cmd *cobra.Command, loglevel zapcore.Level) {
 	setupProfiler()
 	manifest.IPFSURL = sflags.MustGetString(cmd, "ipfs-url")
+	manifest.PackageIndexURL = sflags.MustGetString(cmd, "package-index")
+
+	trustedKeys, err := manifest.LoadTrustedKeys(sflags.MustGetStringSlice(cmd, "trusted-keys"))
+	cli.NoError(err, "invalid --trusted-keys")
+	manifest.TrustedKeys = trustedKeys
+	manifest.RequireSignature = sflags.MustGetBool(cmd, "require-signature")
+	if manifest.RequireSignature && len(trustedKeys) == 0 {
+		cli.Quit("--require-signature needs at least one key in --trusted-keys")
+	}
+
 	logging.InstantiateLoggers(logging.WithLogLevelSwitcherServerAutoStart(), logging.WithDefaultLevel(loglevel))
 }
 
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams/manifest"
+)
+
+// sourceExtensions are the extensions of the files in the directory of a manifest that are sources
+// of its binaries
+var sourceExtensions = map[string]bool{
+	".rs":   true,
+	".go":   true,
+	".toml": true,
+	".mod":  true,
+	".sum":  true,
+}
+
+// skippedSourceDirs are the directories never holding sources, like build outputs
+var skippedSourceDirs = map[string]bool{
+	"target":       true,
+	"node_modules": true,
+}
+
+// protogenOutputDir is where 'substreams protogen' writes the rust bindings by default, relative to the manifest
+const protogenOutputDir = "src/pb"
+
+// watchedSources are the files a local manifest is built from
+type watchedSources struct {
+	// files are watched whatever their extension: the manifest, its protobuf files and its binaries
+	files map[string]bool
+	// sourceDirs have their files with one of the sourceExtensions watched
+	sourceDirs map[string]bool
+
+	// buildOutputs are the files written by the build, its binaries, and buildOutputDirs hold files
+	// written by the build, like the generated protobuf bindings
+	buildOutputs    map[string]bool
+	buildOutputDirs []string
+}
+
+// collectWatchedSources lists the sources of the manifest at manifestPath: the manifest itself, the
+// protobuf files it lists, resolved like the manifest reader does, the files of its binaries and,
+// when it builds some, the source files found under its directory.
+func collectWatchedSources(manifestPath string, manif *manifest.Manifest) (*watchedSources, error) {
+	manifestPath, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving manifest path: %w", err)
+	}
+	manifestDir := filepath.Dir(manifestPath)
+
+	resolve := func(path string) string {
+		path = os.ExpandEnv(path)
+		if filepath.IsAbs(path) {
+			return filepath.Clean(path)
+		}
+		return filepath.Join(manifestDir, path)
+	}
+
+	out := &watchedSources{
+		files:        map[string]bool{manifestPath: true},
+		sourceDirs:   map[string]bool{},
+		buildOutputs: map[string]bool{},
+	}
+
+	var importPaths []string
+	for _, importPath := range manif.Protobuf.ImportPaths {
+		importPaths = append(importPaths, resolve(importPath))
+	}
+	importPaths = append(importPaths, manifestDir)
+	for _, file := range manif.Protobuf.Files {
+		for _, importPath := range importPaths {
+			if path := filepath.Join(importPath, file); fileExists(path) {
+				out.files[path] = true
+				break
+			}
+		}
+	}
+
+	buildsBinaries := false
+	for _, binary := range manif.Binaries {
+		if binary.File != "" {
+			out.files[resolve(binary.File)] = true
+			out.buildOutputs[resolve(binary.File)] = true
+		}
+		buildsBinaries = true
+	}
+	if !buildsBinaries {
+		return out, nil
+	}
+	out.buildOutputDirs = append(out.buildOutputDirs, filepath.Join(manifestDir, protogenOutputDir))
+
+	err = filepath.WalkDir(manifestDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != manifestDir && (strings.HasPrefix(entry.Name(), ".") || skippedSourceDirs[entry.Name()]) {
+			return filepath.SkipDir
+		}
+		out.sourceDirs[path] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing sources of %q: %w", manifestDir, err)
+	}
+
+	return out, nil
+}
+
+// dirs returns the existing directories holding the sources, watched for their changes
+func (s *watchedSources) dirs() map[string]bool {
+	out := map[string]bool{}
+	for dir := range s.sourceDirs {
+		out[dir] = true
+	}
+	for file := range s.files {
+		if dir := filepath.Dir(file); fileExists(dir) {
+			out[dir] = true
+		}
+	}
+	return out
+}
+
+func (s *watchedSources) matches(path string) bool {
+	path = filepath.Clean(path)
+	if s.files[path] {
+		return true
+	}
+	return s.sourceDirs[filepath.Dir(path)] && sourceExtensions[filepath.Ext(path)]
+}
+
+// isBuildOutput tells if the file at path is written by the build
+func (s *watchedSources) isBuildOutput(path string) bool {
+	path = filepath.Clean(path)
+	if s.buildOutputs[path] {
+		return true
+	}
+	for _, dir := range s.buildOutputDirs {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// sourceWatcher watches the sources of a local manifest, and rebuilds its protobuf bindings and binaries
+// when they change.
+type sourceWatcher struct {
+	manifestPath string
+	binaryLabel  string
+	// debounce is how long the sources must stay unchanged before a change is reported, editors and
+	// tools often writing several files in a row
+	debounce time.Duration
+
+	watcher *fsnotify.Watcher
+	sources *watchedSources
+	dirs    map[string]bool
+	// changedDuringBuild is set when sources other than the build outputs changed during the last build
+	changedDuringBuild bool
+}
+
+func newSourceWatcher(manifestPath, binaryLabel string) (*sourceWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating file watcher: %w", err)
+	}
+
+	w := &sourceWatcher{
+		manifestPath: manifestPath,
+		binaryLabel:  binaryLabel,
+		debounce:     300 * time.Millisecond,
+		watcher:      watcher,
+		dirs:         map[string]bool{},
+	}
+	if err := w.refresh(); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *sourceWatcher) Close() error {
+	return w.watcher.Close()
+}
+
+// refresh reads the manifest again, watching the sources it now references
+func (w *sourceWatcher) refresh() error {
+	manif, err := readManifestYaml(w.manifestPath)
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+	sources, err := collectWatchedSources(w.manifestPath, &manif)
+	if err != nil {
+		return err
+	}
+
+	dirs := sources.dirs()
+	for dir := range w.dirs {
+		if !dirs[dir] {
+			_ = w.watcher.Remove(dir)
+		}
+	}
+	for dir := range dirs {
+		if !w.dirs[dir] {
+			if err := w.watcher.Add(dir); err != nil {
+				return fmt.Errorf("watching %q: %w", dir, err)
+			}
+		}
+	}
+
+	w.sources = sources
+	w.dirs = dirs
+	return nil
+}
+
+// Next blocks until some sources changed, and stayed unchanged for the debounce period since. It returns
+// right away when sources changed during the last build.
+func (w *sourceWatcher) Next(ctx context.Context) error {
+	if w.changedDuringBuild {
+		w.changedDuringBuild = false
+		return nil
+	}
+
+	var settled <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return errors.New("file watcher closed")
+			}
+			if event.Has(fsnotify.Create) && w.sources.sourceDirs[filepath.Dir(event.Name)] {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := w.refresh(); err != nil {
+						zlog.Warn("unable to watch new directory", zap.String("path", event.Name), zap.Error(err))
+					}
+					continue
+				}
+			}
+			if event.Op == fsnotify.Chmod || !w.sources.matches(event.Name) {
+				continue
+			}
+			settled = time.After(w.debounce)
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return errors.New("file watcher closed")
+			}
+			zlog.Warn("file watcher error", zap.Error(err))
+
+		case <-settled:
+			return nil
+		}
+	}
+}
+
+// Build runs the protobuf and binary builders of the manifest, like 'substreams build --no-pack', writing
+// their output to out. The changes they make to their outputs, like generated protobuf bindings, are ignored,
+// while the other sources changed in the meantime are reported by the next call to Next.
+func (w *sourceWatcher) Build(ctx context.Context, out io.Writer) error {
+	defer w.collectBuildChanges()
+
+	manif, err := readManifestYaml(w.manifestPath)
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+	info := &manifestInfo{
+		Path:     w.manifestPath,
+		Manifest: manif,
+		Output:   out,
+	}
+
+	protoBuilder, err := newProtoBuilder(info, w.binaryLabel)
+	if err != nil {
+		return fmt.Errorf("creating proto builder: %w", err)
+	}
+	if err := protoBuilder.Build(ctx); err != nil {
+		return fmt.Errorf("running protogen: %w", err)
+	}
+
+	binaryBuilder, err := newBinaryBuilder(info, w.binaryLabel)
+	if err != nil {
+		return fmt.Errorf("creating binary builder: %w", err)
+	}
+	if err := binaryBuilder.Build(ctx); err != nil {
+		return fmt.Errorf("building binary: %w", err)
+	}
+
+	return nil
+}
+
+// collectBuildChanges reads the changes made during a build until the sources stay unchanged for the
+// debounce period, keeping track of the ones not made to the build outputs, then refreshes the watched sources
+func (w *sourceWatcher) collectBuildChanges() {
+	for quiet := false; !quiet; {
+		select {
+		case event := <-w.watcher.Events:
+			if event.Op == fsnotify.Chmod || !w.sources.matches(event.Name) || w.sources.isBuildOutput(event.Name) {
+				continue
+			}
+			w.changedDuringBuild = true
+		case <-time.After(w.debounce):
+			quiet = true
+		}
+	}
+
+	if err := w.refresh(); err != nil {
+		zlog.Warn("unable to refresh watched sources", zap.Error(err))
+	}
+}
+
+// watchedManifestPath resolves the manifest given to a command in watch mode, which must be a local manifest file
+func watchedManifestPath(input string) (string, error) {
+	manifestPath, err := resolveManifestFile(input)
+	if err != nil {
+		return "", err
+	}
+	if ext := filepath.Ext(manifestPath); ext != ".yaml" && ext != ".yml" {
+		return "", fmt.Errorf("watching sources requires a local manifest file, got %q", manifestPath)
+	}
+	return manifestPath, nil
+}
+
+// packageModuleHashes reads the package at manifestPath and returns the hashes of the given modules, or of all
+// its modules when none are given. The hash of a module changes with its code and with any of its ancestors.
+func packageModuleHashes(manifestPath string, readerOptions []manifest.Option, modules []string) (map[string]string, error) {
+	reader, err := manifest.NewReader(manifestPath, readerOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("manifest reader: %w", err)
+	}
+	pkgBundle, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read manifest %q: %w", manifestPath, err)
+	}
+
+	if len(modules) == 0 {
+		for _, module := range pkgBundle.Package.Modules.Modules {
+			modules = append(modules, module.Name)
+		}
+	}
+
+	hashes := manifest.NewModuleHashes()
+	out := make(map[string]string, len(modules))
+	for _, name := range modules {
+		module, err := pkgBundle.Graph.Module(name)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", name, err)
+		}
+		hash, err := hashes.HashModule(pkgBundle.Package.Modules, module, pkgBundle.Graph)
+		if err != nil {
+			return nil, fmt.Errorf("hashing module %q: %w", name, err)
+		}
+		out[name] = hex.EncodeToString(hash)
+	}
+	return out, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const watchedManifest = `specVersion: v0.1.0
+package:
+  name: test
+  version: v0.1.0
+protobuf:
+  files:
+    - a.proto
+    - b.proto
+  importPaths:
+    - ./proto
+binaries:
+  default:
+    type: wasm/rust-v1
+    file: ./target/wasm32-unknown-unknown/release/test.wasm
+`
+
+func writeWatchedProject(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"substreams.yaml": watchedManifest,
+		"Cargo.toml":      "",
+		"README.md":       "",
+		"proto/a.proto":   "",
+		"b.proto":         "",
+		"src/lib.rs":      "",
+		"src/pb/mod.rs":   "",
+		".git/config":     "",
+		"target/wasm32-unknown-unknown/release/test.wasm": "",
+		"target/release/build.rs":                         "",
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestCollectWatchedSources(t *testing.T) {
+	dir := writeWatchedProject(t)
+	manif, err := readManifestYaml(filepath.Join(dir, "substreams.yaml"))
+	require.NoError(t, err)
+
+	sources, err := collectWatchedSources(filepath.Join(dir, "substreams.yaml"), &manif)
+	require.NoError(t, err)
+
+	for path, expected := range map[string]bool{
+		"substreams.yaml": true,
+		"proto/a.proto":   true,
+		"b.proto":         true,
+		"Cargo.toml":      true,
+		"src/lib.rs":      true,
+		"src/pb/mod.rs":   true,
+		"src/new.rs":      true,
+		"README.md":       false,
+		"proto/b.proto":   false,
+		"src/notes.txt":   false,
+		".git/config":     false,
+		"target/wasm32-unknown-unknown/release/test.wasm": true,
+		"target/release/build.rs":                         false,
+	} {
+		assert.Equal(t, expected, sources.matches(filepath.Join(dir, path)), path)
+	}
+
+	for path, expected := range map[string]bool{
+		"src/pb/mod.rs": true,
+		"target/wasm32-unknown-unknown/release/test.wasm": true,
+		"src/lib.rs":      false,
+		"src/pbx/mod.rs":  false,
+		"substreams.yaml": false,
+	} {
+		assert.Equal(t, expected, sources.isBuildOutput(filepath.Join(dir, path)), path)
+	}
+
+	assert.ElementsMatch(t, []string{
+		dir,
+		filepath.Join(dir, "proto"),
+		filepath.Join(dir, "src"),
+		filepath.Join(dir, "src/pb"),
+		filepath.Join(dir, "target/wasm32-unknown-unknown/release"),
+	}, mapKeys(sources.dirs()))
+}
+
+func TestSourceWatcherNext(t *testing.T) {
+	dir := writeWatchedProject(t)
+	watcher, err := newSourceWatcher(filepath.Join(dir, "substreams.yaml"), "default")
+	require.NoError(t, err)
+	defer watcher.Close()
+	watcher.debounce = 50 * time.Millisecond
+
+	next := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		return watcher.Next(ctx)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed"), 0644))
+	assert.ErrorIs(t, next(), context.DeadlineExceeded, "not a source")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src/lib.rs"), []byte("changed"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "proto/a.proto"), []byte("changed"), 0644))
+	assert.NoError(t, next())
+	assert.ErrorIs(t, next(), context.DeadlineExceeded, "changes are reported once")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src/new"), 0755))
+	assert.ErrorIs(t, next(), context.DeadlineExceeded, "new directories are watched")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src/new/mod.rs"), []byte("new"), 0644))
+	assert.NoError(t, next())
+}
+
+func TestSourceWatcherBuildChanges(t *testing.T) {
+	dir := writeWatchedProject(t)
+	watcher, err := newSourceWatcher(filepath.Join(dir, "substreams.yaml"), "default")
+	require.NoError(t, err)
+	defer watcher.Close()
+	watcher.debounce = 50 * time.Millisecond
+
+	next := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		return watcher.Next(ctx)
+	}
+	build := func(changedPaths ...string) {
+		for _, path := range changedPaths {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte("built"), 0644))
+		}
+		watcher.collectBuildChanges()
+	}
+
+	build("src/pb/mod.rs", "target/wasm32-unknown-unknown/release/test.wasm")
+	assert.ErrorIs(t, next(), context.DeadlineExceeded, "the build outputs are ignored")
+
+	build("src/pb/mod.rs", "src/lib.rs")
+	assert.NoError(t, next(), "sources changed during the build are reported")
+	assert.ErrorIs(t, next(), context.DeadlineExceeded, "changes are reported once")
+}
+
+func mapKeys(m map[string]bool) (out []string) {
+	for k := range m {
+		out = append(out, k)
+	}
+	return
+}
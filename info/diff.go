@@ -0,0 +1,420 @@
+package info
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// PackageDiff describes how a package changed from `Old` to `New`. A modified module
+// gets a new hash, invalidating its cached outputs, either because of its own changes or
+// transitively because one of its ancestors changed.
+type PackageDiff struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	AddedModules    []string        `json:"added_modules,omitempty"`
+	RemovedModules  []string        `json:"removed_modules,omitempty"`
+	ModifiedModules []*ModuleDiff   `json:"modified_modules,omitempty"`
+	ProtoTypes      *ProtoTypesDiff `json:"proto_types,omitempty"`
+	UnchangedCount  int             `json:"unchanged_count"`
+}
+
+type ModuleDiff struct {
+	Name    string         `json:"name"`
+	OldHash string         `json:"old_hash"`
+	NewHash string         `json:"new_hash"`
+	Changes []*FieldChange `json:"changes,omitempty"`
+	// ChangedAncestors lists the ancestors with changes of their own through which this
+	// module's hash changed
+	ChangedAncestors []string `json:"changed_ancestors,omitempty"`
+}
+
+// HashChanged reports whether the module's cache is invalidated by the change.
+func (d *ModuleDiff) HashChanged() bool {
+	return d.OldHash != d.NewHash
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type ProtoTypesDiff struct {
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+}
+
+// HasChanges reports whether anything differs between the two packages.
+func (d *PackageDiff) HasChanges() bool {
+	return len(d.AddedModules) != 0 || len(d.RemovedModules) != 0 || len(d.ModifiedModules) != 0 || d.ProtoTypes != nil
+}
+
+type packageHashes struct {
+	pkg    *pbsubstreams.Package
+	graph  *manifest.ModuleGraph
+	hashes *manifest.ModuleHashes
+}
+
+// Diff compares two packages. When `outputModule` is set, only the modules it depends
+// on (in either package), and the protobuf types they use, are compared.
+func Diff(oldName string, oldPkg *pbsubstreams.Package, oldGraph *manifest.ModuleGraph, newName string, newPkg *pbsubstreams.Package, newGraph *manifest.ModuleGraph, outputModule string) (*PackageDiff, error) {
+	diff := &PackageDiff{Old: oldName, New: newName}
+
+	oldModules, err := diffedModules(oldPkg, oldGraph, outputModule)
+	if err != nil {
+		return nil, fmt.Errorf("old package: %w", err)
+	}
+	newModules, err := diffedModules(newPkg, newGraph, outputModule)
+	if err != nil {
+		return nil, fmt.Errorf("new package: %w", err)
+	}
+	if outputModule != "" && oldModules == nil && newModules == nil {
+		return nil, fmt.Errorf("output module %q not found in either package", outputModule)
+	}
+
+	before := &packageHashes{pkg: oldPkg, graph: oldGraph, hashes: manifest.NewModuleHashes()}
+	after := &packageHashes{pkg: newPkg, graph: newGraph, hashes: manifest.NewModuleHashes()}
+
+	var compared []*ModuleDiff
+	withOwnChanges := make(map[string]bool)
+	for _, mod := range newPkg.Modules.Modules {
+		if !newModules[mod.Name] {
+			continue
+		}
+
+		oldMod := findModule(oldPkg, mod.Name)
+		if oldMod == nil || !oldModules[mod.Name] {
+			diff.AddedModules = append(diff.AddedModules, mod.Name)
+			withOwnChanges[mod.Name] = true
+			continue
+		}
+
+		modDiff, err := diffModule(before, oldMod, after, mod)
+		if err != nil {
+			return nil, err
+		}
+		if len(modDiff.Changes) != 0 {
+			withOwnChanges[mod.Name] = true
+		}
+		compared = append(compared, modDiff)
+	}
+
+	for _, modDiff := range compared {
+		ancestors, err := newGraph.AncestorsOf(modDiff.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ancestor := range ancestors {
+			if withOwnChanges[ancestor.Name] {
+				modDiff.ChangedAncestors = append(modDiff.ChangedAncestors, ancestor.Name)
+			}
+		}
+		sort.Strings(modDiff.ChangedAncestors)
+
+		if len(modDiff.Changes) == 0 && !modDiff.HashChanged() {
+			diff.UnchangedCount++
+			continue
+		}
+		diff.ModifiedModules = append(diff.ModifiedModules, modDiff)
+	}
+
+	for _, mod := range oldPkg.Modules.Modules {
+		if oldModules[mod.Name] && (findModule(newPkg, mod.Name) == nil || !newModules[mod.Name]) {
+			diff.RemovedModules = append(diff.RemovedModules, mod.Name)
+		}
+	}
+	sort.Strings(diff.AddedModules)
+	sort.Strings(diff.RemovedModules)
+
+	var protoTypes map[string]bool
+	if outputModule != "" {
+		protoTypes = usedProtoTypes(oldPkg, oldModules)
+		for name := range usedProtoTypes(newPkg, newModules) {
+			protoTypes[name] = true
+		}
+	}
+	diff.ProtoTypes = diffProtoTypes(oldPkg.ProtoFiles, newPkg.ProtoFiles, protoTypes)
+
+	return diff, nil
+}
+
+// diffedModules returns the set of modules to compare, or nil when `outputModule` is
+// set but not part of the package.
+func diffedModules(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph, outputModule string) (map[string]bool, error) {
+	out := make(map[string]bool)
+	if outputModule == "" {
+		for _, mod := range pkg.Modules.Modules {
+			out[mod.Name] = true
+		}
+		return out, nil
+	}
+
+	if findModule(pkg, outputModule) == nil {
+		return nil, nil
+	}
+	ancestors, err := graph.AncestorsOf(outputModule)
+	if err != nil {
+		return nil, err
+	}
+	out[outputModule] = true
+	for _, ancestor := range ancestors {
+		out[ancestor.Name] = true
+	}
+	return out, nil
+}
+
+func findModule(pkg *pbsubstreams.Package, name string) *pbsubstreams.Module {
+	for _, mod := range pkg.Modules.Modules {
+		if mod.Name == name {
+			return mod
+		}
+	}
+	return nil
+}
+
+func diffModule(before *packageHashes, oldMod *pbsubstreams.Module, after *packageHashes, newMod *pbsubstreams.Module) (*ModuleDiff, error) {
+	oldHash, err := before.hashes.HashModule(before.pkg.Modules, oldMod, before.graph)
+	if err != nil {
+		return nil, fmt.Errorf("hashing old module %q: %w", oldMod.Name, err)
+	}
+	newHash, err := after.hashes.HashModule(after.pkg.Modules, newMod, after.graph)
+	if err != nil {
+		return nil, fmt.Errorf("hashing new module %q: %w", newMod.Name, err)
+	}
+
+	d := &ModuleDiff{
+		Name:    newMod.Name,
+		OldHash: hex.EncodeToString(oldHash),
+		NewHash: hex.EncodeToString(newHash),
+	}
+
+	compare := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			d.Changes = append(d.Changes, &FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	compare("kind", moduleKind(oldMod), moduleKind(newMod))
+	compare("output_type", moduleOutputType(oldMod), moduleOutputType(newMod))
+	compare("update_policy", moduleUpdatePolicy(oldMod), moduleUpdatePolicy(newMod))
+	compare("retain_blocks", fmt.Sprint(oldMod.GetKindStore().GetRetainBlocks()), fmt.Sprint(newMod.GetKindStore().GetRetainBlocks()))
+	compare("indexes", moduleIndexes(oldMod), moduleIndexes(newMod))
+	compare("merge_entrypoint", oldMod.GetKindStore().GetMergeEntrypoint(), newMod.GetKindStore().GetMergeEntrypoint())
+	compare("initial_block", fmt.Sprint(oldMod.InitialBlock), fmt.Sprint(newMod.InitialBlock))
+	compare("end_block", fmt.Sprint(oldMod.EndBlock), fmt.Sprint(newMod.EndBlock))
+	compare("inputs", moduleInputs(oldMod), moduleInputs(newMod))
+	compare("params", moduleParams(oldMod), moduleParams(newMod))
+	compare("block_filter", moduleBlockFilter(oldMod), moduleBlockFilter(newMod))
+
+	oldBinary := before.pkg.Modules.Binaries[oldMod.BinaryIndex]
+	newBinary := after.pkg.Modules.Binaries[newMod.BinaryIndex]
+	compare("binary_type", oldBinary.Type, newBinary.Type)
+	compare("binary_content", binaryDigest(oldBinary), binaryDigest(newBinary))
+	compare("binary_entrypoint", oldMod.BinaryEntrypoint, newMod.BinaryEntrypoint)
+
+	return d, nil
+}
+
+func moduleKind(mod *pbsubstreams.Module) string {
+	switch mod.Kind.(type) {
+	case *pbsubstreams.Module_KindMap_:
+		return "map"
+	case *pbsubstreams.Module_KindStore_:
+		return "store"
+	case *pbsubstreams.Module_KindBlockIndex_:
+		return "index"
+	}
+	return "unknown"
+}
+
+func moduleOutputType(mod *pbsubstreams.Module) string {
+	switch v := mod.Kind.(type) {
+	case *pbsubstreams.Module_KindMap_:
+		return v.KindMap.OutputType
+	case *pbsubstreams.Module_KindStore_:
+		return v.KindStore.ValueType
+	case *pbsubstreams.Module_KindBlockIndex_:
+		return v.KindBlockIndex.OutputType
+	}
+	return ""
+}
+
+func moduleUpdatePolicy(mod *pbsubstreams.Module) string {
+	if store := mod.GetKindStore(); store != nil {
+		return store.UpdatePolicy.Pretty()
+	}
+	return ""
+}
+
+func moduleIndexes(mod *pbsubstreams.Module) string {
+	var indexes []string
+	for _, index := range mod.GetKindStore().GetIndexes() {
+		indexes = append(indexes, storeIndexString(index))
+	}
+	return strings.Join(indexes, ", ")
+}
+
+func moduleInputs(mod *pbsubstreams.Module) string {
+	var inputs []string
+	for _, input := range mod.Inputs {
+		switch v := input.Input.(type) {
+		case *pbsubstreams.Module_Input_Source_:
+			inputs = append(inputs, "source:"+v.Source.Type)
+		case *pbsubstreams.Module_Input_Map_:
+			inputs = append(inputs, "map:"+v.Map.ModuleName)
+		case *pbsubstreams.Module_Input_Store_:
+			inputs = append(inputs, fmt.Sprintf("store:%s (%s)", v.Store.ModuleName, v.Store.Mode.Pretty()))
+		case *pbsubstreams.Module_Input_Params_:
+			inputs = append(inputs, "params")
+		}
+	}
+	return "[" + strings.Join(inputs, ", ") + "]"
+}
+
+func moduleParams(mod *pbsubstreams.Module) string {
+	for _, input := range mod.Inputs {
+		if p := input.GetParams(); p != nil {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+func moduleBlockFilter(mod *pbsubstreams.Module) string {
+	if mod.BlockFilter == nil {
+		return ""
+	}
+	query, err := mod.BlockFilterQueryString()
+	if err != nil {
+		query = err.Error()
+	}
+	return fmt.Sprintf("%s: %s", mod.BlockFilter.Module, query)
+}
+
+func binaryDigest(binary *pbsubstreams.Binary) string {
+	sum := sha256.Sum256(binary.Content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// diffProtoTypes compares the message types of two sets of files, only those in `types`
+// when it is not nil.
+func diffProtoTypes(oldFiles, newFiles []*descriptorpb.FileDescriptorProto, types map[string]bool) *ProtoTypesDiff {
+	oldTypes := protoMessageTypes(oldFiles)
+	newTypes := protoMessageTypes(newFiles)
+	if types != nil {
+		for name := range oldTypes {
+			if !types[name] {
+				delete(oldTypes, name)
+			}
+		}
+		for name := range newTypes {
+			if !types[name] {
+				delete(newTypes, name)
+			}
+		}
+	}
+
+	diff := &ProtoTypesDiff{}
+	for name, newDef := range newTypes {
+		oldDef, found := oldTypes[name]
+		switch {
+		case !found:
+			diff.Added = append(diff.Added, name)
+		case oldDef != newDef:
+			diff.Modified = append(diff.Modified, name)
+		}
+	}
+	for name := range oldTypes {
+		if _, found := newTypes[name]; !found {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Modified) == 0 {
+		return nil
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Modified)
+	return diff
+}
+
+// usedProtoTypes returns the message types used by `modules` as their source inputs,
+// outputs, store values or typed params, along with the types of their fields, recursively.
+func usedProtoTypes(pkg *pbsubstreams.Package, modules map[string]bool) map[string]bool {
+	messages := protoMessages(pkg.ProtoFiles)
+	out := make(map[string]bool)
+
+	var use func(name string)
+	use = func(name string) {
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "proto:"), ".")
+		msg, found := messages[name]
+		if !found || out[name] {
+			return
+		}
+		out[name] = true
+		for _, field := range msg.Field {
+			if field.GetTypeName() != "" {
+				use(field.GetTypeName())
+			}
+		}
+	}
+
+	for _, mod := range pkg.Modules.Modules {
+		if !modules[mod.Name] {
+			continue
+		}
+		use(moduleOutputType(mod))
+		for _, input := range mod.Inputs {
+			use(input.GetSource().GetType())
+			use(input.GetParams().GetType())
+		}
+	}
+
+	return out
+}
+
+// protoMessageTypes returns the serialized definition of every message type, keyed by
+// fully qualified name.
+func protoMessageTypes(files []*descriptorpb.FileDescriptorProto) map[string]string {
+	out := make(map[string]string)
+	for name, msg := range protoMessages(files) {
+		cnt, _ := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		out[name] = string(cnt)
+	}
+	return out
+}
+
+// protoMessages returns every message type, nested ones included, keyed by fully
+// qualified name.
+func protoMessages(files []*descriptorpb.FileDescriptorProto) map[string]*descriptorpb.DescriptorProto {
+	out := make(map[string]*descriptorpb.DescriptorProto)
+
+	var collect func(prefix string, messages []*descriptorpb.DescriptorProto)
+	collect = func(prefix string, messages []*descriptorpb.DescriptorProto) {
+		for _, msg := range messages {
+			name := msg.GetName()
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			out[name] = msg
+			collect(name, msg.NestedType)
+		}
+	}
+
+	for _, file := range files {
+		collect(file.GetPackage(), file.MessageType)
+	}
+
+	return out
+}
@@ -0,0 +1,144 @@
+package info
+
+import (
+	"testing"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestDiff(t *testing.T) {
+	mapModule := func(name string, initialBlock uint64, inputs ...*pbsubstreams.Module_Input) *pbsubstreams.Module {
+		return &pbsubstreams.Module{
+			Name:             name,
+			InitialBlock:     initialBlock,
+			Kind:             &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:test.Output"}},
+			Inputs:           inputs,
+			BinaryEntrypoint: name,
+		}
+	}
+	source := &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.substreams.v1.Clock"}}}
+	mapInput := func(name string) *pbsubstreams.Module_Input {
+		return &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: name}}}
+	}
+	params := func(value string) *pbsubstreams.Module_Input {
+		return &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Params_{Params: &pbsubstreams.Module_Input_Params{Value: value}}}
+	}
+	protoFile := func(fields ...string) *descriptorpb.FileDescriptorProto {
+		msg := &descriptorpb.DescriptorProto{Name: proto.String("Output")}
+		for i, f := range fields {
+			msg.Field = append(msg.Field, &descriptorpb.FieldDescriptorProto{Name: proto.String(f), Number: proto.Int32(int32(i + 1))})
+		}
+		return &descriptorpb.FileDescriptorProto{Name: proto.String("test.proto"), Package: proto.String("test"), MessageType: []*descriptorpb.DescriptorProto{msg}}
+	}
+	newPackage := func(file *descriptorpb.FileDescriptorProto, modules ...*pbsubstreams.Module) (*pbsubstreams.Package, *manifest.ModuleGraph) {
+		pkg := &pbsubstreams.Package{
+			Modules: &pbsubstreams.Modules{
+				Modules:  modules,
+				Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			},
+			ProtoFiles: []*descriptorpb.FileDescriptorProto{file},
+		}
+		graph, err := manifest.NewModuleGraph(modules)
+		require.NoError(t, err)
+		return pkg, graph
+	}
+
+	oldPkg, oldGraph := newPackage(protoFile("a"),
+		mapModule("root", 10, source, params("one")),
+		mapModule("child", 10, mapInput("root")),
+		mapModule("other", 5, source),
+		mapModule("removed", 5, source),
+	)
+	newPkg, newGraph := newPackage(protoFile("a", "b"),
+		mapModule("root", 10, source, params("two")),
+		mapModule("child", 10, mapInput("root")),
+		mapModule("other", 5, source),
+		mapModule("added", 20, mapInput("other")),
+	)
+
+	diff, err := Diff("old", oldPkg, oldGraph, "new", newPkg, newGraph, "")
+	require.NoError(t, err)
+	assert.True(t, diff.HasChanges())
+	assert.Equal(t, []string{"added"}, diff.AddedModules)
+	assert.Equal(t, []string{"removed"}, diff.RemovedModules)
+	assert.Equal(t, 1, diff.UnchangedCount)
+	assert.Equal(t, &ProtoTypesDiff{Modified: []string{"test.Output"}}, diff.ProtoTypes)
+
+	require.Len(t, diff.ModifiedModules, 2)
+	root, child := diff.ModifiedModules[0], diff.ModifiedModules[1]
+	assert.Equal(t, "root", root.Name)
+	assert.True(t, root.HashChanged())
+	assert.Equal(t, []*FieldChange{{Field: "params", Old: "one", New: "two"}}, root.Changes)
+
+	assert.Equal(t, "child", child.Name)
+	assert.True(t, child.HashChanged())
+	assert.Empty(t, child.Changes)
+	assert.Equal(t, []string{"root"}, child.ChangedAncestors)
+
+	diff, err = Diff("old", oldPkg, oldGraph, "new", newPkg, newGraph, "other")
+	require.NoError(t, err)
+	assert.Empty(t, diff.ModifiedModules)
+	assert.Empty(t, diff.AddedModules)
+	assert.Empty(t, diff.RemovedModules)
+	assert.Equal(t, 1, diff.UnchangedCount)
+}
+
+func TestDiff_ProtoTypes(t *testing.T) {
+	source := &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.substreams.v1.Clock"}}}
+	mapModule := func(name, outputType string) *pbsubstreams.Module {
+		return &pbsubstreams.Module{
+			Name:             name,
+			Kind:             &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: outputType}},
+			Inputs:           []*pbsubstreams.Module_Input{source},
+			BinaryEntrypoint: name,
+		}
+	}
+	message := func(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+	}
+	field := func(name, typeName string) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(1), TypeName: proto.String(typeName)}
+	}
+	newPackage := func(nestedField string, modules ...*pbsubstreams.Module) (*pbsubstreams.Package, *manifest.ModuleGraph) {
+		pkg := &pbsubstreams.Package{
+			Modules: &pbsubstreams.Modules{
+				Modules:  modules,
+				Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			},
+			ProtoFiles: []*descriptorpb.FileDescriptorProto{{
+				Name:    proto.String("test.proto"),
+				Package: proto.String("test"),
+				MessageType: []*descriptorpb.DescriptorProto{
+					message("Output", field("nested", ".test.Nested")),
+					message("Nested", &descriptorpb.FieldDescriptorProto{Name: proto.String(nestedField), Number: proto.Int32(1)}),
+					message("Other"),
+				},
+			}},
+		}
+		graph, err := manifest.NewModuleGraph(modules)
+		require.NoError(t, err)
+		return pkg, graph
+	}
+
+	oldPkg, oldGraph := newPackage("a", mapModule("output", "proto:test.Output"), mapModule("other", "proto:test.Other"))
+	newPkg, newGraph := newPackage("b", mapModule("output", "proto:test.Output"), mapModule("other", "proto:test.Other"), mapModule("c", "proto:test.Other"), mapModule("b", "proto:test.Other"))
+
+	diff, err := Diff("old", oldPkg, oldGraph, "new", newPkg, newGraph, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, diff.AddedModules)
+	assert.Equal(t, &ProtoTypesDiff{Modified: []string{"test.Nested"}}, diff.ProtoTypes)
+
+	diff, err = Diff("old", oldPkg, oldGraph, "new", newPkg, newGraph, "output")
+	require.NoError(t, err)
+	assert.Equal(t, &ProtoTypesDiff{Modified: []string{"test.Nested"}}, diff.ProtoTypes)
+
+	diff, err = Diff("old", oldPkg, oldGraph, "new", newPkg, newGraph, "other")
+	require.NoError(t, err)
+	assert.Nil(t, diff.ProtoTypes)
+	assert.False(t, diff.HasChanges())
+}
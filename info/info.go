@@ -1,6 +1,7 @@
 package info
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -15,6 +16,7 @@ type BasicInfo struct {
 	Version                string                             `json:"version"`
 	Documentation          *string                            `json:"documentation,omitempty"`
 	Network                string                             `json:"network,omitempty"`
+	SignedBy               string                             `json:"signed_by,omitempty"` // hex encoded public key of the package's signer
 	Image                  []byte                             `json:"-"`
 	Modules                []ModulesInfo                      `json:"modules"`
 	SinkInfo               *SinkInfo                          `json:"sink_info,omitempty"`
@@ -48,7 +50,8 @@ type SinkInfo struct {
 type ExtendedInfo struct {
 	*BasicInfo
 
-	ExecutionStages [][][]string `json:"execution_stages,omitempty"`
+	ExecutionStages [][][]string               `json:"execution_stages,omitempty"`
+	ResolvedImports []*manifest.ResolvedImport `json:"resolved_imports,omitempty"`
 }
 
 type ProtoFileInfo struct {
@@ -61,22 +64,29 @@ type ProtoFileInfo struct {
 }
 
 type ModulesInfo struct {
-	Name          string                           `json:"name"`
-	Kind          string                           `json:"kind"`
-	Inputs        []ModuleInput                    `json:"inputs"`
-	OutputType    *string                          `json:"output_type,omitempty"`   //for map inputs
-	ValueType     *string                          `json:"value_type,omitempty"`    //for store inputs
-	UpdatePolicy  *string                          `json:"update_policy,omitempty"` //for store inputs
-	BlockFilter   *pbsubstreams.Module_BlockFilter `json:"block_filter,omitempty"`
-	InitialBlock  uint64                           `json:"initial_block"`
-	Documentation *string                          `json:"documentation,omitempty"`
-	Hash          string                           `json:"hash"`
+	Name            string                           `json:"name"`
+	Kind            string                           `json:"kind"`
+	Inputs          []ModuleInput                    `json:"inputs"`
+	OutputType      *string                          `json:"output_type,omitempty"`   //for map inputs
+	ValueType       *string                          `json:"value_type,omitempty"`    //for store inputs
+	UpdatePolicy    *string                          `json:"update_policy,omitempty"` //for store inputs
+	BlockFilter     *pbsubstreams.Module_BlockFilter `json:"block_filter,omitempty"`
+	InitialBlock    uint64                           `json:"initial_block"`
+	EndBlock        *uint64                          `json:"end_block,omitempty"`
+	RetainBlocks    *uint64                          `json:"retain_blocks,omitempty"`    //for store modules
+	Indexes         []string                         `json:"indexes,omitempty"`          //for store modules
+	MergeEntrypoint *string                          `json:"merge_entrypoint,omitempty"` //for store modules
+	Documentation   *string                          `json:"documentation,omitempty"`
+	Hash            string                           `json:"hash"`
 }
 
 type ModuleInput struct {
 	Type string  `json:"type"`
 	Name string  `json:"name"`
 	Mode *string `json:"mode,omitempty"` //for store inputs
+
+	ParamsType  *string `json:"params_type,omitempty"`  //for typed params inputs
+	ParamsShape *string `json:"params_shape,omitempty"` //for typed params inputs: the protobuf message definition or the JSON Schema
 }
 
 func Basic(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph) (*BasicInfo, error) {
@@ -94,6 +104,9 @@ func Basic(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph) (*BasicInfo,
 		Version: version,
 		Image:   pkg.Image,
 	}
+	if pkg.Signature != nil {
+		manifestInfo.SignedBy = hex.EncodeToString(pkg.Signature.PublicKey)
+	}
 
 	if pkg.Networks != nil {
 		manifestInfo.Networks = make(map[string]*manifest.NetworkParams)
@@ -124,6 +137,7 @@ func Basic(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph) (*BasicInfo,
 	modules := make([]ModulesInfo, 0, len(pkg.Modules.Modules))
 
 	hashes := manifest.NewModuleHashes()
+	shapes := &paramsShapes{protoFiles: pkg.ProtoFiles}
 	for ix, mod := range pkg.Modules.Modules {
 		modInfo := ModulesInfo{}
 
@@ -132,6 +146,9 @@ func Basic(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph) (*BasicInfo,
 
 		modInfo.Name = mod.Name
 		modInfo.InitialBlock = mod.InitialBlock
+		if mod.EndBlock != 0 {
+			modInfo.EndBlock = &mod.EndBlock
+		}
 
 		kind := mod.GetKind()
 		switch v := kind.(type) {
@@ -146,6 +163,15 @@ func Basic(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph) (*BasicInfo,
 			modInfo.Kind = "store"
 			modInfo.ValueType = strPtr(v.KindStore.ValueType)
 			modInfo.UpdatePolicy = strPtr(v.KindStore.UpdatePolicy.Pretty())
+			if v.KindStore.RetainBlocks != 0 {
+				modInfo.RetainBlocks = &v.KindStore.RetainBlocks
+			}
+			for _, index := range v.KindStore.Indexes {
+				modInfo.Indexes = append(modInfo.Indexes, storeIndexString(index))
+			}
+			if v.KindStore.MergeEntrypoint != "" {
+				modInfo.MergeEntrypoint = strPtr(v.KindStore.MergeEntrypoint)
+			}
 		default:
 			modInfo.Kind = "unknown"
 		}
@@ -177,6 +203,14 @@ func Basic(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph) (*BasicInfo,
 			case *pbsubstreams.Module_Input_Params_:
 				inputInfo.Type = "params"
 				inputInfo.Name = input.GetParams().Value
+				if v.Params.Type != "" {
+					shape, err := shapes.describe(v.Params)
+					if err != nil {
+						return nil, fmt.Errorf("module %q: %w", mod.Name, err)
+					}
+					inputInfo.ParamsType = strPtr(v.Params.Type)
+					inputInfo.ParamsShape = strPtr(shape)
+				}
 			default:
 				inputInfo.Type = "unknown"
 				inputInfo.Name = "unknown"
@@ -238,7 +272,13 @@ func Extended(manifestPath string, outputModule string, skipValidation bool) (*E
 		return nil, fmt.Errorf("no package found")
 	}
 
-	return ExtendedWithPackage(pkgBundle.Package, pkgBundle.Graph, outputModule)
+	extendedInfo, err := ExtendedWithPackage(pkgBundle.Package, pkgBundle.Graph, outputModule)
+	if err != nil {
+		return nil, err
+	}
+	extendedInfo.ResolvedImports = reader.ResolvedImports()
+
+	return extendedInfo, nil
 }
 
 func ExtendedWithPackage(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph, outputModule string) (*ExtendedInfo, error) {
@@ -249,7 +289,7 @@ func ExtendedWithPackage(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph,
 
 	var stages [][][]string
 	if outputModule != "" {
-		execGraph, err := exec.NewOutputModuleGraph(outputModule, true, pkg.Modules, 0)
+		execGraph, err := exec.NewOutputModuleGraph([]string{outputModule}, true, pkg.Modules, 0)
 		if err != nil {
 			return nil, fmt.Errorf("creating output module graph: %w", err)
 		}
@@ -276,3 +316,14 @@ func ExtendedWithPackage(pkg *pbsubstreams.Package, graph *manifest.ModuleGraph,
 func strPtr(s string) *string {
 	return &s
 }
+
+// storeIndexString describes a store index as `<name> (key segment <n>)` or `<name> (value field <n>)`
+func storeIndexString(index *pbsubstreams.Module_KindStore_Index) string {
+	switch extract := index.Extract.(type) {
+	case *pbsubstreams.Module_KindStore_Index_KeySegment:
+		return fmt.Sprintf("%s (key segment %d)", index.Name, extract.KeySegment)
+	case *pbsubstreams.Module_KindStore_Index_ValueField:
+		return fmt.Sprintf("%s (value field %d)", index.Name, extract.ValueField)
+	}
+	return index.Name
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/desc/protoprint"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
@@ -190,3 +191,38 @@ func extractEnumBlock(protoContent, messageName string) (string, error) {
 
 	return matches[0], nil
 }
+
+// paramsShapes describes the value expected by typed params.
+type paramsShapes struct {
+	protoFiles []*descriptorpb.FileDescriptorProto
+	files      map[string]*desc.FileDescriptor
+}
+
+// describe returns the protobuf definition of the message of `proto:<message type>`
+// params, or the JSON Schema of `json` params.
+func (s *paramsShapes) describe(params *pbsubstreams.Module_Input_Params) (string, error) {
+	if params.Type == "json" {
+		return params.JsonSchema, nil
+	}
+
+	msgType, found := strings.CutPrefix(params.Type, "proto:")
+	if !found {
+		return "", fmt.Errorf("unsupported params type %q", params.Type)
+	}
+
+	if s.files == nil {
+		files, err := desc.CreateFileDescriptors(s.protoFiles)
+		if err != nil {
+			return "", fmt.Errorf("loading protobuf definitions: %w", err)
+		}
+		s.files = files
+	}
+
+	for _, file := range s.files {
+		if msgDesc := file.FindMessage(msgType); msgDesc != nil {
+			printer := &protoprint.Printer{Compact: true}
+			return printer.PrintProtoToString(msgDesc)
+		}
+	}
+	return "", fmt.Errorf("params protobuf message type %q not found in package", msgType)
+}
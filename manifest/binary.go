@@ -1,6 +1,12 @@
 package manifest
 
-import "strings"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
 
 // SplitBinaryType splits a binary type in two components: the type ID and the raw extensions.
 //
@@ -22,3 +28,65 @@ func SplitBinaryType(in string) (typeID string, rawExtensions string) {
 	typeID, rawExtensions, _ = strings.Cut(in, "+")
 	return
 }
+
+// BinaryDeduplication reports the binaries left out of a package because an identical
+// one (same type and content) was already part of it.
+type BinaryDeduplication struct {
+	Count      int
+	SavedBytes uint64
+}
+
+func (d *BinaryDeduplication) add(other BinaryDeduplication) {
+	d.Count += other.Count
+	d.SavedBytes += other.SavedBytes
+}
+
+// binarySet indexes the binaries of a package by type and content hash, so that
+// identical binaries are stored only once.
+type binarySet struct {
+	modules *pbsubstreams.Modules
+	indexes map[string]uint32
+	stats   BinaryDeduplication
+}
+
+func newBinarySet(modules *pbsubstreams.Modules) *binarySet {
+	s := &binarySet{modules: modules, indexes: make(map[string]uint32)}
+	for idx, binary := range modules.Binaries {
+		if key, ok := binaryKey(binary); ok {
+			if _, found := s.indexes[key]; !found {
+				s.indexes[key] = uint32(idx)
+			}
+		}
+	}
+	return s
+}
+
+// add appends `binary` to the modules' binaries unless an identical one is already
+// there, and returns the index of the binary to use.
+func (s *binarySet) add(binary *pbsubstreams.Binary) uint32 {
+	key, ok := binaryKey(binary)
+	if ok {
+		if idx, found := s.indexes[key]; found {
+			s.stats.Count++
+			s.stats.SavedBytes += uint64(len(binary.Content))
+			return idx
+		}
+	}
+
+	s.modules.Binaries = append(s.modules.Binaries, binary)
+	idx := uint32(len(s.modules.Binaries) - 1)
+	if ok {
+		s.indexes[key] = idx
+	}
+	return idx
+}
+
+// binaryKey identifies a binary by its type and content. Binaries without content,
+// which are not loaded when source code validation is skipped, are never deduplicated.
+func binaryKey(binary *pbsubstreams.Binary) (string, bool) {
+	if len(binary.Content) == 0 {
+		return "", false
+	}
+	sum := sha256.Sum256(binary.Content)
+	return binary.Type + "@" + hex.EncodeToString(sum[:]), true
+}
@@ -362,7 +362,8 @@ func (g *ModuleGraph) HasStatefulDependencies(moduleName string) (bool, error) {
 	return false, nil
 }
 
-func (g *ModuleGraph) StoresDownTo(moduleName string) ([]*pbsubstreams.Module, error) {
+// StoresDownTo returns the stores among the given modules and all of their ancestors.
+func (g *ModuleGraph) StoresDownTo(moduleNames ...string) ([]*pbsubstreams.Module, error) {
 	alreadyAdded := map[string]bool{}
 	topologicalIndex := map[string]int{}
 
@@ -376,14 +377,17 @@ func (g *ModuleGraph) StoresDownTo(moduleName string) ([]*pbsubstreams.Module, e
 	}
 
 	var res []*pbsubstreams.Module
-	if _, found := g.moduleIndex[moduleName]; !found {
-		return nil, fmt.Errorf("could not find module %s in graph", moduleName)
-	}
+	for _, moduleName := range moduleNames {
+		if _, found := g.moduleIndex[moduleName]; !found {
+			return nil, fmt.Errorf("could not find module %s in graph", moduleName)
+		}
 
-	_, distances := graph.ShortestPaths(g, g.moduleIndex[moduleName])
+		_, distances := graph.ShortestPaths(g, g.moduleIndex[moduleName])
 
-	for i, d := range distances {
-		if d >= 0 { // connected node or myself
+		for i, d := range distances {
+			if d < 0 { // not connected to this module
+				continue
+			}
 			module := g.indexIndex[i]
 			if module.GetKindStore() == nil {
 				continue
@@ -443,7 +447,8 @@ func (g *ModuleGraph) ParentStoresOf(moduleName string) ([]*pbsubstreams.Modules
 	return nil, nil
 }
 
-func (g *ModuleGraph) ModulesDownTo(moduleName string) ([]*pbsubstreams.Module, error) {
+// ModulesDownTo returns the given modules and all of their ancestors, each module once.
+func (g *ModuleGraph) ModulesDownTo(moduleNames ...string) ([]*pbsubstreams.Module, error) {
 	alreadyAdded := map[string]bool{}
 	topologicalIndex := map[string]int{}
 
@@ -457,14 +462,17 @@ func (g *ModuleGraph) ModulesDownTo(moduleName string) ([]*pbsubstreams.Module,
 	}
 
 	var res []*pbsubstreams.Module
-	if _, found := g.moduleIndex[moduleName]; !found {
-		return nil, fmt.Errorf("could not find module %s in graph", moduleName)
-	}
+	for _, moduleName := range moduleNames {
+		if _, found := g.moduleIndex[moduleName]; !found {
+			return nil, fmt.Errorf("could not find module %s in graph", moduleName)
+		}
 
-	_, distances := graph.ShortestPaths(g, g.moduleIndex[moduleName])
+		_, distances := graph.ShortestPaths(g, g.moduleIndex[moduleName])
 
-	for i, d := range distances {
-		if d >= 0 { // connected node or myself
+		for i, d := range distances {
+			if d < 0 { // not connected to this module
+				continue
+			}
 			module := g.indexIndex[i]
 			if _, ok := alreadyAdded[module.Name]; ok {
 				continue
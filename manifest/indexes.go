@@ -0,0 +1,101 @@
+package manifest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// StoreIndex is a secondary index of a store module, indexing its keys either by one of their
+// segments or by a field of their protobuf value.
+type StoreIndex struct {
+	Name       string  `yaml:"name"`
+	KeySegment *uint32 `yaml:"keySegment,omitempty"`
+	// ValueField is the name, or the number, of a string or bytes field of the store's protobuf value type
+	ValueField string `yaml:"valueField,omitempty"`
+}
+
+// validateStoreIndexes checks the `indexes` of a module, the value fields are checked when
+// resolved against the protobuf definitions.
+func validateStoreIndexes(mod *Module, issues *issueCollector, at func(fields ...interface{}) []interface{}) {
+	if len(mod.Indexes) == 0 {
+		return
+	}
+	if mod.Kind != ModuleKindStore && mod.Kind != "" {
+		issues.add(at("indexes"), "stream %q: 'indexes' is only allowed for kind 'store'", mod.Name)
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, index := range mod.Indexes {
+		if index.Name == "" {
+			issues.add(at("indexes", i, "name"), "stream %q: missing index 'name'", mod.Name)
+		} else if seen[index.Name] {
+			issues.add(at("indexes", i, "name"), "stream %q: duplicate index name %q", mod.Name, index.Name)
+		}
+		seen[index.Name] = true
+
+		if (index.KeySegment == nil) == (index.ValueField == "") {
+			issues.add(at("indexes", i), "stream %q: index %q must define exactly one of 'keySegment' or 'valueField'", mod.Name, index.Name)
+		}
+		if index.ValueField != "" && !strings.HasPrefix(mod.ValueType, "proto:") {
+			issues.add(at("indexes", i, "valueField"), "stream %q: index %q: 'valueField' requires a 'proto:' value type", mod.Name, index.Name)
+		}
+	}
+}
+
+// indexesToProto converts the indexes of the module, value fields given by name are left
+// without an extractor until resolved by resolveStoreIndexes.
+func (m *Module) indexesToProto() (out []*pbsubstreams.Module_KindStore_Index) {
+	for _, index := range m.Indexes {
+		pbIndex := &pbsubstreams.Module_KindStore_Index{Name: index.Name}
+		switch {
+		case index.KeySegment != nil:
+			pbIndex.Extract = &pbsubstreams.Module_KindStore_Index_KeySegment{KeySegment: *index.KeySegment}
+		case index.ValueField != "":
+			if number, err := strconv.ParseUint(index.ValueField, 10, 32); err == nil {
+				pbIndex.Extract = &pbsubstreams.Module_KindStore_Index_ValueField{ValueField: uint32(number)}
+			}
+		}
+		out = append(out, pbIndex)
+	}
+	return
+}
+
+// resolveStoreIndexes sets the field number of the indexes on a value field given by name, from
+// the protobuf definitions of the package.
+func resolveStoreIndexes(pkg *pbsubstreams.Package, manif *Manifest) error {
+	modules := make(map[string]*pbsubstreams.Module, len(pkg.Modules.Modules))
+	for _, mod := range pkg.Modules.Modules {
+		modules[mod.Name] = mod
+	}
+
+	encoder := newParamsEncoder(pkg)
+	for _, mod := range manif.Modules {
+		pbMod := modules[mod.Name]
+		for i, index := range mod.Indexes {
+			pbIndex := pbMod.GetKindStore().GetIndexes()[i]
+			if index.ValueField == "" || pbIndex.Extract != nil {
+				continue
+			}
+
+			msgDesc, err := encoder.messageDescriptor(strings.TrimPrefix(mod.ValueType, "proto:"))
+			if err != nil {
+				return fmt.Errorf("module %q: index %q: %w", mod.Name, index.Name, err)
+			}
+			field := msgDesc.FindFieldByName(index.ValueField)
+			if field == nil {
+				return fmt.Errorf("module %q: index %q: field %q not found in %s", mod.Name, index.Name, index.ValueField, msgDesc.GetFullyQualifiedName())
+			}
+			if field.IsRepeated() || (field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING && field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_BYTES) {
+				return fmt.Errorf("module %q: index %q: field %q must be a singular string or bytes field", mod.Name, index.Name, index.ValueField)
+			}
+			pbIndex.Extract = &pbsubstreams.Module_KindStore_Index_ValueField{ValueField: uint32(field.GetNumber())}
+		}
+	}
+	return nil
+}
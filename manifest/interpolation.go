@@ -0,0 +1,176 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// paramsInterpolator resolves the references found in the params of a manifest:
+//
+//   - `${NAME}` is replaced by the environment variable `NAME`, which must be listed
+//     in the manifest's `paramsEnv` so that secrets don't end up in a packed .spkg by mistake
+//   - `${file:./path}` is replaced by the content of the file, relative to the manifest,
+//     without its trailing newlines
+//   - `${network}` is replaced by the network the package is read for
+//
+// A literal `${` is written `$${`.
+type paramsInterpolator struct {
+	manif      *Manifest
+	network    string
+	allowedEnv map[string]bool
+}
+
+func newParamsInterpolator(manif *Manifest, network string) *paramsInterpolator {
+	if network == "" {
+		network = manif.Network
+	}
+
+	allowedEnv := make(map[string]bool, len(manif.ParamsEnv))
+	for _, name := range manif.ParamsEnv {
+		allowedEnv[name] = true
+	}
+
+	return &paramsInterpolator{manif: manif, network: network, allowedEnv: allowedEnv}
+}
+
+func (i *paramsInterpolator) interpolate(value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var out strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start == -1 {
+			out.WriteString(value)
+			return out.String(), nil
+		}
+
+		if start > 0 && value[start-1] == '$' {
+			out.WriteString(value[:start-1])
+			out.WriteString("${")
+			value = value[start+2:]
+			continue
+		}
+
+		end := strings.IndexByte(value[start:], '}')
+		if end == -1 {
+			return "", fmt.Errorf("unterminated reference %q", value[start:])
+		}
+
+		resolved, err := i.resolve(value[start+2 : start+end])
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(value[:start])
+		out.WriteString(resolved)
+		value = value[start+end+1:]
+	}
+}
+
+func (i *paramsInterpolator) resolve(ref string) (string, error) {
+	if ref == "network" {
+		if i.network == "" {
+			return "", fmt.Errorf("cannot resolve ${network}: no network specified")
+		}
+		return i.network, nil
+	}
+
+	if path, found := strings.CutPrefix(ref, "file:"); found {
+		if path == "" {
+			return "", fmt.Errorf("invalid reference ${file:}: missing file path")
+		}
+		content, err := os.ReadFile(i.manif.resolvePath(path))
+		if err != nil {
+			return "", fmt.Errorf("cannot resolve ${file:%s}: %w", path, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	if !envVarNameRegex.MatchString(ref) {
+		return "", fmt.Errorf("invalid reference ${%s}, expected ${ENV_VAR}, ${file:path} or ${network}", ref)
+	}
+	if !i.allowedEnv[ref] {
+		return "", fmt.Errorf("environment variable %q is not listed in 'paramsEnv'", ref)
+	}
+	value, found := os.LookupEnv(ref)
+	if !found {
+		return "", fmt.Errorf("environment variable %q is not set", ref)
+	}
+	return value, nil
+}
+
+// interpolateParams resolves the references found in the `params`, `networks[*].params`
+// and `overrides` sections of the manifest, in place. In the params of a network,
+// `${network}` is that network's name.
+func (r *manifestConverter) interpolateParams(manif *Manifest) error {
+	interpolator := newParamsInterpolator(manif, r.network)
+	issues := newIssueCollector(manif)
+
+	for _, name := range sortedKeys(manif.Params) {
+		resolved, err := interpolator.interpolate(manif.Params[name])
+		if err != nil {
+			issues.add([]interface{}{"params", name}, "params for module %q: %s", name, err)
+			continue
+		}
+		manif.Params[name] = resolved
+	}
+
+	for _, network := range sortedKeys(manif.Networks) {
+		networkParams := manif.Networks[network]
+		if networkParams == nil {
+			continue
+		}
+		networkInterpolator := newParamsInterpolator(manif, network)
+		for _, name := range sortedKeys(networkParams.Params) {
+			resolved, err := networkInterpolator.interpolate(networkParams.Params[name])
+			if err != nil {
+				issues.add([]interface{}{"networks", network, "params", name}, "network %q: params for module %q: %s", network, name, err)
+				continue
+			}
+			networkParams.Params[name] = resolved
+		}
+	}
+
+	for _, name := range sortedKeys(manif.Overrides) {
+		override := manif.Overrides[name]
+		if override == nil {
+			continue
+		}
+		interpolate := func(interpolator *paramsInterpolator, value *string, path ...interface{}) {
+			if value == nil {
+				return
+			}
+			resolved, err := interpolator.interpolate(*value)
+			if err != nil {
+				issues.add(append([]interface{}{"overrides", name}, path...), "overrides: params for module %q: %s", name, err)
+				return
+			}
+			*value = resolved
+		}
+
+		interpolate(interpolator, override.Params, "params")
+		for _, network := range sortedKeys(override.Networks) {
+			if networkOverride := override.Networks[network]; networkOverride != nil {
+				interpolate(newParamsInterpolator(manif, network), networkOverride.Params, "networks", network, "params")
+			}
+		}
+	}
+
+	return issues.err()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,123 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsInterpolator_Interpolate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "addresses.txt"), []byte("0xaa,0xbb\n"), 0644))
+
+	t.Setenv("SUBSTREAMS_TEST_FACTORY", "0x1234")
+	t.Setenv("SUBSTREAMS_TEST_SECRET", "hunter2")
+
+	manif := &Manifest{
+		Workdir:   dir,
+		Network:   "mainnet",
+		ParamsEnv: []string{"SUBSTREAMS_TEST_FACTORY", "SUBSTREAMS_TEST_UNSET"},
+	}
+
+	tests := []struct {
+		name          string
+		network       string
+		value         string
+		expected      string
+		expectedError string
+	}{
+		{name: "literal", value: "addr=0x1234", expected: "addr=0x1234"},
+		{name: "lone dollar", value: "price>$10", expected: "price>$10"},
+		{name: "env", value: "factory=${SUBSTREAMS_TEST_FACTORY}", expected: "factory=0x1234"},
+		{name: "file", value: "addresses=${file:addresses.txt}", expected: "addresses=0xaa,0xbb"},
+		{name: "network", value: "${network}:${SUBSTREAMS_TEST_FACTORY}", expected: "mainnet:0x1234"},
+		{name: "network override", network: "sepolia", value: "${network}", expected: "sepolia"},
+		{name: "escaped", value: "$${SUBSTREAMS_TEST_SECRET}", expected: "${SUBSTREAMS_TEST_SECRET}"},
+
+		{name: "env not allowed", value: "${SUBSTREAMS_TEST_SECRET}", expectedError: `environment variable "SUBSTREAMS_TEST_SECRET" is not listed in 'paramsEnv'`},
+		{name: "env not set", value: "${SUBSTREAMS_TEST_UNSET}", expectedError: `environment variable "SUBSTREAMS_TEST_UNSET" is not set`},
+		{name: "missing file", value: "${file:missing.txt}", expectedError: "cannot resolve ${file:missing.txt}"},
+		{name: "invalid reference", value: "${not valid}", expectedError: "invalid reference ${not valid}, expected ${ENV_VAR}, ${file:path} or ${network}"},
+		{name: "unterminated", value: "a=${network", expectedError: `unterminated reference "${network"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := newParamsInterpolator(manif, test.network).interpolate(test.value)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, out)
+		})
+	}
+}
+
+func TestReader_InterpolatedParams(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+
+params:
+  map_a: "${SUBSTREAMS_TEST_FACTORY}"
+  map_b: "${SUBSTREAMS_TEST_SECRET}"
+
+networks:
+  mainnet:
+    params:
+      map_a: "${file:missing.txt}"
+`), 0644))
+
+	t.Setenv("SUBSTREAMS_TEST_FACTORY", "0x1234")
+	t.Setenv("SUBSTREAMS_TEST_SECRET", "hunter2")
+
+	reader, err := NewReader(manifestPath, SkipSourceCodeReader())
+	require.NoError(t, err)
+
+	_, err = reader.Read()
+	require.Error(t, err)
+
+	var issues ManifestIssues
+	require.ErrorAs(t, err, &issues)
+	require.Len(t, issues, 3)
+	assert.Equal(t, `:7:10: params for module "map_a": environment variable "SUBSTREAMS_TEST_FACTORY" is not listed in 'paramsEnv'`, issues[0].Error()[len(manifestPath):])
+	assert.Equal(t, `:8:10: params for module "map_b": environment variable "SUBSTREAMS_TEST_SECRET" is not listed in 'paramsEnv'`, issues[1].Error()[len(manifestPath):])
+	assert.Contains(t, issues[2].Error(), `:13:14: network "mainnet": params for module "map_a": cannot resolve ${file:missing.txt}`)
+}
+
+func TestManifestConverter_InterpolateNetworkParams(t *testing.T) {
+	overridden := "${network}"
+	sepoliaOverride := "${network}"
+	manif := &Manifest{
+		Network: "mainnet",
+		Params:  map[string]string{"map_a": "${network}"},
+		Networks: map[string]*NetworkParams{
+			"mainnet": {Params: map[string]string{"map_a": "${network}"}},
+			"sepolia": {Params: map[string]string{"map_a": "${network}"}},
+		},
+		Overrides: map[string]*ModuleOverride{
+			"dep:map_b": {
+				Params:   &overridden,
+				Networks: map[string]*ModuleNetworkOverride{"sepolia": {Params: &sepoliaOverride}},
+			},
+		},
+	}
+
+	converter := newManifestConverter("test", true)
+	converter.network = "mainnet"
+	require.NoError(t, converter.interpolateParams(manif))
+
+	assert.Equal(t, "mainnet", manif.Params["map_a"])
+	assert.Equal(t, "mainnet", manif.Networks["mainnet"].Params["map_a"])
+	assert.Equal(t, "sepolia", manif.Networks["sepolia"].Params["map_a"])
+	assert.Equal(t, "mainnet", *manif.Overrides["dep:map_b"].Params)
+	assert.Equal(t, "sepolia", *manif.Overrides["dep:map_b"].Networks["sepolia"].Params)
+}
@@ -0,0 +1,184 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestIssue is a problem found in a manifest, located at the YAML node causing it
+// when known.
+type ManifestIssue struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i *ManifestIssue) Error() string {
+	var location string
+	switch {
+	case i.Line != 0:
+		location = fmt.Sprintf("%s:%d:%d: ", i.File, i.Line, i.Column)
+	case i.File != "":
+		location = i.File + ": "
+	}
+	if i.Path != "" {
+		return fmt.Sprintf("%s%s: %s", location, i.Path, i.Message)
+	}
+	return location + i.Message
+}
+
+// ManifestIssues is the error returned when a manifest fails validation, it holds
+// every issue found rather than only the first one.
+type ManifestIssues []*ManifestIssue
+
+func (issues ManifestIssues) Error() string {
+	if len(issues) == 1 {
+		return issues[0].Error()
+	}
+
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		lines = append(lines, issue.Error())
+	}
+	return fmt.Sprintf("%d issues found:\n%s", len(issues), strings.Join(lines, "\n"))
+}
+
+// issueCollector accumulates the issues found while validating a decoded manifest,
+// locating them through the YAML nodes it was decoded from, if any.
+type issueCollector struct {
+	file   string
+	root   *yaml.Node
+	issues ManifestIssues
+}
+
+func newIssueCollector(manif *Manifest) *issueCollector {
+	return &issueCollector{file: manif.source, root: manif.node}
+}
+
+// add records an issue located at `path`, a list of mapping keys and sequence indexes
+// from the document root. When the exact node does not exist, the closest parent is used.
+func (c *issueCollector) add(path []interface{}, format string, args ...interface{}) {
+	issue := &ManifestIssue{Message: fmt.Sprintf(format, args...)}
+	if node := nodeAt(c.root, path...); node != nil {
+		issue.File = c.file
+		issue.Line = node.Line
+		issue.Column = node.Column
+	}
+	c.issues = append(c.issues, issue)
+}
+
+func (c *issueCollector) err() error {
+	if len(c.issues) == 0 {
+		return nil
+	}
+	return c.issues
+}
+
+func nodeAt(root *yaml.Node, path ...interface{}) *yaml.Node {
+	if root == nil {
+		return nil
+	}
+
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) != 0 {
+		node = node.Content[0]
+	}
+
+	for _, elem := range path {
+		var next *yaml.Node
+		switch e := elem.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == e {
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && e < len(node.Content) {
+				next = node.Content[e]
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+// decodeManifest parses a YAML manifest read from `file`, validates it against the
+// manifest JSON Schema and decodes it. Schema issues are returned as `ManifestIssues`.
+func decodeManifest(file string, content []byte) (*Manifest, error) {
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(content, root); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest: %w", err)
+	}
+
+	issues, err := validateManifestSchema(file, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) != 0 {
+		return nil, issues
+	}
+
+	manif := &Manifest{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(manif); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal manifest: %w", err)
+	}
+	manif.node = root
+	manif.source = file
+
+	return manif, nil
+}
+
+// LintManifest reads the manifest at `input` (a file, or a directory containing a
+// `substreams.yaml` file) and returns every issue found: JSON Schema violations,
+// semantic errors and, when those pass, errors building the package.
+func LintManifest(input string, opts ...Option) (ManifestIssues, error) {
+	reader, err := NewReader(input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !reader.IsLocalManifest() {
+		return nil, fmt.Errorf("%q is not a local manifest", reader.currentInput)
+	}
+
+	content, err := os.ReadFile(reader.currentInput)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %q: %w", reader.currentInput, err)
+	}
+
+	manif, err := decodeManifest(reader.currentInput, content)
+	if err != nil {
+		if issues, ok := err.(ManifestIssues); ok {
+			return issues, nil
+		}
+		return ManifestIssues{{File: reader.currentInput, Message: err.Error()}}, nil
+	}
+
+	converter := newManifestConverter(reader.currentInput, true)
+	if err := converter.validateManifest(manif); err != nil {
+		if issues, ok := err.(ManifestIssues); ok {
+			return issues, nil
+		}
+		return nil, err
+	}
+
+	if _, err := reader.Read(); err != nil {
+		return ManifestIssues{{File: reader.currentInput, Message: err.Error()}}, nil
+	}
+
+	return nil, nil
+}
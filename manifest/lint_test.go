@@ -0,0 +1,162 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+
+binaries:
+  default:
+    type: wasm/rust-v1
+    file: ./test.wasm
+
+modules:
+  - name: map_block
+    kind: map
+    initialBlock: -1
+    inputs:
+      - source: sf.substreams.v1.test.Block
+    outptu:
+      type: proto:test.Block
+
+  - name: store_block
+    kind: store
+    updatePolicy: sum
+    valueType: int64
+    inputs:
+      - map: map_block
+        mode: deltas
+`), 0644))
+
+	issues, err := LintManifest(manifestPath)
+	require.NoError(t, err)
+
+	var found []string
+	for _, issue := range issues {
+		assert.Equal(t, manifestPath, issue.File)
+		found = append(found, issue.Error()[len(manifestPath):])
+	}
+	assert.Equal(t, []string{
+		`:14:19: modules[0].initialBlock: invalid value -1, must be at least 0`,
+		`:17:5: modules[0].outptu: unknown field "outptu", did you mean "output"?`,
+		`:22:19: modules[1].updatePolicy: invalid value "sum", must be one of: set, set_if_not_exists, set_sum, append, add, min, max, custom`,
+		`:25:9: modules[1].inputs[0]: must have at most 1 field(s)`,
+	}, found)
+}
+
+func TestLintManifest_ConditionalFields(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+
+protobuf:
+  files: []
+  importPath: [./proto]
+
+modules:
+  - name: map_block
+    kind: map
+    updatePolicy: set
+    inputs:
+      - source: sf.substreams.v1.test.Block
+    output:
+      type: proto:test.Block
+
+  - name: store_block
+    kind: store
+    updatePolicy: set
+    valueType: int64
+    indexes:
+      - name: by_owner
+        keySegment: 1
+        valueField: owner
+    inputs:
+      - map: map_block
+`), 0644))
+
+	issues, err := LintManifest(manifestPath)
+	require.NoError(t, err)
+
+	var found []string
+	for _, issue := range issues {
+		found = append(found, issue.Error()[len(manifestPath):])
+	}
+	assert.Equal(t, []string{
+		`:7:10: protobuf.files: must have at least 1 item(s)`,
+		`:8:3: protobuf.importPath: unknown field "importPath", did you mean "importPaths"?`,
+		`:13:5: modules[0].updatePolicy: field "updatePolicy" is not allowed here`,
+		`:24:9: modules[1].indexes[0]: must match only one of the alternatives, matches #1 and #2`,
+	}, found)
+}
+
+func TestLintManifest_SemanticIssues(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+
+modules:
+  - name: map_block
+    kind: map
+    binary: other
+    inputs:
+      - source: sf.substreams.v1.test.Block
+
+  - name: map_block
+    kind: store
+    updatePolicy: append
+    valueType: int64
+    inputs:
+      - source: sf.substreams.v1.test.Block
+
+params:
+  unknown: "value"
+`), 0644))
+
+	issues, err := LintManifest(manifestPath)
+	require.NoError(t, err)
+
+	var found []string
+	for _, issue := range issues {
+		found = append(found, issue.Error()[len(manifestPath):])
+	}
+	assert.Equal(t, []string{
+		`:9:13: module "map_block": binary "other" is not defined in 'binaries'`,
+		`:7:5: stream "map_block": missing 'output.type' for kind 'map'`,
+		`:13:11: module "map_block": duplicate module name`,
+		`:15:19: stream "map_block": invalid 'output.updatePolicy' and 'output.valueType' combination, found "append:int64" use one of: ` + fmt.Sprint(storeCombinations),
+		`:21:12: params: module "unknown" is not defined`,
+	}, found)
+}
+
+func TestReader_ManifestSchemaIssues(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("specVersion: v0.2.0\npackage:\n  name: test\n"), 0644))
+
+	reader, err := newReader(manifestPath, dir)
+	require.NoError(t, err)
+
+	_, err = reader.Read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), manifestPath+`:1:14: specVersion: invalid value "v0.2.0", must be one of: v0.1.0`)
+	assert.Contains(t, err.Error(), manifestPath+`:3:3: package: missing required field "version"`)
+}
@@ -0,0 +1,148 @@
+package manifest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileName is the name of the file, sitting next to the manifest, that pins the
+// content of every package imported by that manifest.
+const LockFileName = "substreams.lock"
+
+const lockVersion = 1
+
+// Lock records the resolved location, content hash and module hashes of every package
+// imported by a manifest, recursively. When a lock file exists next to a manifest, the
+// `Reader` refuses to load an import whose content does not match its locked hash.
+type Lock struct {
+	Version int             `yaml:"version"`
+	Imports []*LockedImport `yaml:"imports,omitempty"`
+}
+
+type LockedImport struct {
+	Name         string            `yaml:"name"`
+	URL          string            `yaml:"url"`
+	Version      string            `yaml:"version,omitempty"`
+	Sha256       string            `yaml:"sha256"`
+	ModuleHashes map[string]string `yaml:"moduleHashes,omitempty"`
+	Imports      []*LockedImport   `yaml:"imports,omitempty"`
+}
+
+func NewLock() *Lock {
+	return &Lock{Version: lockVersion}
+}
+
+// LockFilePath returns the path of the lock file associated with the given manifest path.
+func LockFilePath(manifestPath string) string {
+	return filepath.Join(filepath.Dir(manifestPath), LockFileName)
+}
+
+// ReadLockFile reads the lock file at `path`. It returns a nil lock and no error
+// if the file does not exist.
+func ReadLockFile(path string) (*Lock, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading lock file %q: %w", path, err)
+	}
+
+	lock := &Lock{}
+	decoder := yaml.NewDecoder(bytes.NewReader(cnt))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(lock); err != nil {
+		return nil, fmt.Errorf("decoding lock file %q: %w", path, err)
+	}
+
+	if lock.Version != lockVersion {
+		return nil, fmt.Errorf("lock file %q: unsupported version %d", path, lock.Version)
+	}
+
+	return lock, nil
+}
+
+func (l *Lock) WriteFile(path string) error {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString("# This file is generated by `substreams build`, do not edit it by hand.\n")
+
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(l); err != nil {
+		return fmt.Errorf("encoding lock file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encoding lock file: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing lock file %q: %w", path, err)
+	}
+	return nil
+}
+
+func (l *Lock) find(name string) *LockedImport {
+	for _, imp := range l.Imports {
+		if imp.Name == name {
+			return imp
+		}
+	}
+	return nil
+}
+
+// verifyImport checks that an import resolved from `url` with content hash `sha256Hash`
+// matches what is recorded in the lock. Imports absent from the lock are accepted, they
+// will be recorded the next time the lock file is written.
+func (l *Lock) verifyImport(name, url, sha256Hash string) error {
+	locked := l.find(name)
+	if locked == nil {
+		return nil
+	}
+
+	if locked.URL != url {
+		return fmt.Errorf("import %q: url %q does not match %q recorded in %s, use '--update-lock' to refresh it", name, url, locked.URL, LockFileName)
+	}
+
+	if locked.Sha256 != sha256Hash {
+		return fmt.Errorf("import %q: content of %q changed (sha256 %s, expected %s from %s), use '--update-lock' to refresh it", name, url, sha256Hash, locked.Sha256, LockFileName)
+	}
+
+	return nil
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func newLockedImport(name, url string, reader *Reader, bundle *PackageBundle) (*LockedImport, error) {
+	locked := &LockedImport{
+		Name:   name,
+		URL:    url,
+		Sha256: reader.contentHash,
+	}
+
+	hashes := NewModuleHashes()
+	for _, mod := range bundle.Package.Modules.Modules {
+		hash, err := hashes.HashModule(bundle.Package.Modules, mod, bundle.Graph)
+		if err != nil {
+			return nil, fmt.Errorf("hashing module %q: %w", mod.Name, err)
+		}
+		if locked.ModuleHashes == nil {
+			locked.ModuleHashes = make(map[string]string)
+		}
+		locked.ModuleHashes[mod.Name] = hex.EncodeToString(hash)
+	}
+
+	if reader.lock != nil {
+		locked.Imports = reader.lock.Imports
+	}
+
+	return locked, nil
+}
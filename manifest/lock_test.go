@@ -0,0 +1,99 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock_VerifyImports(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	writeFile("dep.yaml", "specVersion: v0.1.0\npackage:\n  name: dep\n  version: v0.0.0\n")
+	writeFile("substreams.yaml", "specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\nimports:\n  dep: ./dep.yaml\n")
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+
+	read := func(opts ...Option) (*Reader, error) {
+		reader, err := newReader(manifestPath, dir, opts...)
+		require.NoError(t, err)
+		_, err = reader.Read()
+		return reader, err
+	}
+
+	reader, err := read()
+	require.NoError(t, err)
+
+	lock := reader.ResolvedLock()
+	require.NotNil(t, lock)
+	require.Len(t, lock.Imports, 1)
+	assert.Equal(t, "dep", lock.Imports[0].Name)
+	assert.Equal(t, "./dep.yaml", lock.Imports[0].URL)
+	assert.Len(t, lock.Imports[0].Sha256, 64)
+	require.NoError(t, lock.WriteFile(LockFilePath(manifestPath)))
+
+	readBack, err := ReadLockFile(LockFilePath(manifestPath))
+	require.NoError(t, err)
+	assert.Equal(t, lock, readBack)
+
+	_, err = read()
+	require.NoError(t, err)
+
+	writeFile("dep.yaml", "specVersion: v0.1.0\npackage:\n  name: dep\n  version: v0.0.1\n")
+	_, err = read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `import "dep": content of "./dep.yaml" changed`)
+
+	reader, err = read(WithUpdateLock())
+	require.NoError(t, err)
+	assert.NotEqual(t, lock.Imports[0].Sha256, reader.ResolvedLock().Imports[0].Sha256)
+}
+
+func TestReadLockFile_Missing(t *testing.T) {
+	lock, err := ReadLockFile(filepath.Join(t.TempDir(), LockFileName))
+	require.NoError(t, err)
+	assert.Nil(t, lock)
+}
+
+func TestLock_VerifyNestedImports(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	writeFile("dep/inner.yaml", "specVersion: v0.1.0\npackage:\n  name: inner\n  version: v0.0.0\n")
+	writeFile("dep/substreams.yaml", "specVersion: v0.1.0\npackage:\n  name: dep\n  version: v0.0.0\nimports:\n  inner: ./inner.yaml\n")
+	writeFile("substreams.yaml", "specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\nimports:\n  dep: ./dep/substreams.yaml\n")
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+
+	read := func(opts ...Option) (*Reader, error) {
+		reader, err := newReader(manifestPath, dir, opts...)
+		require.NoError(t, err)
+		_, err = reader.Read()
+		return reader, err
+	}
+
+	reader, err := read()
+	require.NoError(t, err)
+
+	lock := reader.ResolvedLock()
+	require.Len(t, lock.Imports, 1)
+	require.Len(t, lock.Imports[0].Imports, 1)
+	assert.Equal(t, "inner", lock.Imports[0].Imports[0].Name)
+	require.NoError(t, lock.WriteFile(LockFilePath(manifestPath)))
+
+	writeFile("dep/inner.yaml", "specVersion: v0.1.0\npackage:\n  name: inner\n  version: v0.0.1\n")
+	_, err = read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `import "inner": content of "./inner.yaml" changed`)
+
+	reader, err = read(WithUpdateLock())
+	require.NoError(t, err)
+	assert.NotEqual(t, lock.Imports[0].Imports[0].Sha256, reader.ResolvedLock().Imports[0].Imports[0].Sha256)
+}
@@ -1,7 +1,6 @@
 package manifest
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"math"
@@ -32,20 +31,27 @@ const (
 // Manifest is a YAML structure used to create a Package and its list
 // of Modules. The notion of a manifest does not live in protobuf definitions.
 type Manifest struct {
-	SpecVersion  string                    `yaml:"specVersion,omitempty"` // check that it equals v0.1.0
-	Package      PackageMeta               `yaml:"package,omitempty"`
-	Protobuf     Protobuf                  `yaml:"protobuf,omitempty"`
-	Imports      mapSlice                  `yaml:"imports,omitempty"`
-	Binaries     map[string]Binary         `yaml:"binaries,omitempty"`
-	Modules      []*Module                 `yaml:"modules,omitempty"`
-	Params       map[string]string         `yaml:"params,omitempty"`
-	BlockFilters map[string]string         `yaml:"blockFilters,omitempty"`
-	Network      string                    `yaml:"network,omitempty"`
-	Networks     map[string]*NetworkParams `yaml:"networks,omitempty"`
-	Sink         *Sink                     `yaml:"sink,omitempty"`
+	SpecVersion  string                     `yaml:"specVersion,omitempty"` // check that it equals v0.1.0
+	Package      PackageMeta                `yaml:"package,omitempty"`
+	Protobuf     Protobuf                   `yaml:"protobuf,omitempty"`
+	Imports      mapSlice                   `yaml:"imports,omitempty"`
+	Binaries     map[string]Binary          `yaml:"binaries,omitempty"`
+	Modules      []*Module                  `yaml:"modules,omitempty"`
+	Params       map[string]string          `yaml:"params,omitempty"`
+	ParamsEnv    []string                   `yaml:"paramsEnv,omitempty"`
+	BlockFilters map[string]string          `yaml:"blockFilters,omitempty"`
+	Network      string                     `yaml:"network,omitempty"`
+	Networks     map[string]*NetworkParams  `yaml:"networks,omitempty"`
+	Overrides    map[string]*ModuleOverride `yaml:"overrides,omitempty"`
+	Sink         *Sink                      `yaml:"sink,omitempty"`
 
 	Graph   *ModuleGraph `yaml:"-"`
 	Workdir string       `yaml:"-"`
+
+	// node and source are the YAML document and the file the manifest was decoded from,
+	// used to locate validation issues
+	node   *yaml.Node
+	source string
 }
 
 type NetworkParams struct {
@@ -53,6 +59,20 @@ type NetworkParams struct {
 	Params        map[string]string `yaml:"params,omitempty" json:"params,omitempty"`
 }
 
+// ModuleOverride changes the values of a module of an imported package, referenced
+// as `importAlias:module` in the `overrides` section.
+type ModuleOverride struct {
+	InitialBlock *uint64                           `yaml:"initialBlock,omitempty"`
+	Params       *string                           `yaml:"params,omitempty"`
+	BlockFilter  *BlockFilter                      `yaml:"blockFilter,omitempty"`
+	Networks     map[string]*ModuleNetworkOverride `yaml:"networks,omitempty"`
+}
+
+type ModuleNetworkOverride struct {
+	InitialBlock *uint64 `yaml:"initialBlock,omitempty"`
+	Params       *string `yaml:"params,omitempty"`
+}
+
 type Sink struct {
 	Type   string      `yaml:"type,omitempty"`
 	Module string      `yaml:"module,omitempty"`
@@ -97,11 +117,15 @@ type Module struct {
 	Doc          string       `yaml:"doc,omitempty"`
 	Kind         string       `yaml:"kind,omitempty"`
 	InitialBlock *uint64      `yaml:"initialBlock,omitempty"`
+	EndBlock     *uint64      `yaml:"endBlock,omitempty"`
 	BlockFilter  *BlockFilter `yaml:"blockFilter,omitempty"`
 
-	UpdatePolicy string `yaml:"updatePolicy,omitempty"`
-	ValueType    string `yaml:"valueType,omitempty"`
-	Binary       string `yaml:"binary,omitempty"`
+	UpdatePolicy    string        `yaml:"updatePolicy,omitempty"`
+	ValueType       string        `yaml:"valueType,omitempty"`
+	RetainBlocks    *uint64       `yaml:"retainBlocks,omitempty"`
+	Indexes         []*StoreIndex `yaml:"indexes,omitempty"`
+	MergeEntrypoint string        `yaml:"mergeEntrypoint,omitempty"`
+	Binary          string        `yaml:"binary,omitempty"`
 
 	Inputs []*Input     `yaml:"inputs,omitempty"`
 	Output StreamOutput `yaml:"output,omitempty"`
@@ -158,9 +182,8 @@ func decodeYamlManifestFromFile(yamlFilePath, workingDir string) (out *Manifest,
 		return nil, fmt.Errorf("reading substreams manifest %q: %w", yamlFilePath, err)
 	}
 
-	decoder := yaml.NewDecoder(bytes.NewReader(cnt))
-	decoder.KnownFields(true)
-	if err := decoder.Decode(&out); err != nil {
+	out, err = decodeManifest(yamlFilePath, cnt)
+	if err != nil {
 		return nil, fmt.Errorf("decoding manifest content: %w", err)
 	}
 
@@ -201,8 +224,8 @@ func (i *Input) parse() error {
 		return nil
 	}
 	if i.IsParams() {
-		if i.Params != "string" {
-			return fmt.Errorf("input 'params': 'string' is the only acceptable value here; specify the parameter's value under the top-level 'params' mapping")
+		if _, _, err := parseParamsType(i.Params); err != nil {
+			return fmt.Errorf("input 'params': %w; specify the parameter's value under the top-level 'params' mapping", err)
 		}
 		return nil
 	}
@@ -226,9 +249,66 @@ func validateModuleWithUse(module *Module) error {
 		return fmt.Errorf("module %q: 'valueType' cannot be set when 'use' is set", module.Name)
 	}
 
+	if module.RetainBlocks != nil {
+		return fmt.Errorf("module %q: 'retainBlocks' cannot be set when 'use' is set", module.Name)
+	}
+
+	if len(module.Indexes) != 0 {
+		return fmt.Errorf("module %q: 'indexes' cannot be set when 'use' is set", module.Name)
+	}
+
+	if module.MergeEntrypoint != "" {
+		return fmt.Errorf("module %q: 'mergeEntrypoint' cannot be set when 'use' is set", module.Name)
+	}
+
 	return nil
 }
 
+// storeCombinations are the valid 'updatePolicy:valueType' pairs of store modules,
+// keep big float to be backward-compatible
+var storeCombinations = []string{
+	"max:bigint",
+	"max:int64",
+	"max:bigdecimal",
+	"max:bigfloat",
+	"max:float64",
+	"min:bigint",
+	"min:int64",
+	"min:bigdecimal",
+	"min:bigfloat",
+	"min:float64",
+	"add:bigint",
+	"add:int64",
+	"add:bigdecimal",
+	"add:bigfloat",
+	"add:float64",
+	"set:bytes",
+	"set:string",
+	"set:proto",
+	"set:bigdecimal",
+	"set:bigfloat",
+	"set:bigint",
+	"set:int64",
+	"set:float64",
+	"set_if_not_exists:bytes",
+	"set_if_not_exists:string",
+	"set_if_not_exists:proto",
+	"set_if_not_exists:bigdecimal",
+	"set_if_not_exists:bigfloat",
+	"set_if_not_exists:bigint",
+	"set_if_not_exists:int64",
+	"set_if_not_exists:float64",
+	"set_sum:bigint",
+	"set_sum:int64",
+	"set_sum:bigdecimal",
+	"set_sum:float64",
+	"append:bytes",
+	"append:string",
+	"custom:bytes",
+	"custom:string",
+	"custom:proto",
+}
+
 func validateStoreBuilder(module *Module) error {
 	if module.UpdatePolicy == "" {
 		return errors.New("missing 'output.updatePolicy' for kind 'store'")
@@ -237,49 +317,9 @@ func validateStoreBuilder(module *Module) error {
 		return errors.New("missing 'output.valueType' for kind 'store'")
 	}
 
-	// keep big float to be backward-compatible
-	combinations := []string{
-		"max:bigint",
-		"max:int64",
-		"max:bigdecimal",
-		"max:bigfloat",
-		"max:float64",
-		"min:bigint",
-		"min:int64",
-		"min:bigdecimal",
-		"min:bigfloat",
-		"min:float64",
-		"add:bigint",
-		"add:int64",
-		"add:bigdecimal",
-		"add:bigfloat",
-		"add:float64",
-		"set:bytes",
-		"set:string",
-		"set:proto",
-		"set:bigdecimal",
-		"set:bigfloat",
-		"set:bigint",
-		"set:int64",
-		"set:float64",
-		"set_if_not_exists:bytes",
-		"set_if_not_exists:string",
-		"set_if_not_exists:proto",
-		"set_if_not_exists:bigdecimal",
-		"set_if_not_exists:bigfloat",
-		"set_if_not_exists:bigint",
-		"set_if_not_exists:int64",
-		"set_if_not_exists:float64",
-		"set_sum:bigint",
-		"set_sum:int64",
-		"set_sum:bigdecimal",
-		"set_sum:float64",
-		"append:bytes",
-		"append:string",
-	}
 	found := false
 	var lastCombination string
-	for _, comb := range combinations {
+	for _, comb := range storeCombinations {
 		valType := module.ValueType
 		if strings.HasPrefix(valType, "proto:") {
 			valType = "proto"
@@ -291,7 +331,18 @@ func validateStoreBuilder(module *Module) error {
 	}
 
 	if !found {
-		return fmt.Errorf("invalid 'output.updatePolicy' and 'output.valueType' combination, found %q use one of: %s", lastCombination, combinations)
+		return fmt.Errorf("invalid 'output.updatePolicy' and 'output.valueType' combination, found %q use one of: %s", lastCombination, storeCombinations)
+	}
+
+	if module.RetainBlocks != nil && *module.RetainBlocks == 0 {
+		return errors.New("'retainBlocks' must be greater than zero, omit it to keep keys indefinitely")
+	}
+
+	if module.UpdatePolicy == UpdatePolicyCustom && module.MergeEntrypoint == "" {
+		return errors.New("missing 'mergeEntrypoint' for update policy 'custom'")
+	}
+	if module.UpdatePolicy != UpdatePolicyCustom && module.MergeEntrypoint != "" {
+		return errors.New("'mergeEntrypoint' is only allowed for update policy 'custom'")
 	}
 
 	return nil
@@ -312,6 +363,9 @@ func (m *Module) ToProtoWASM(codeIndex uint32) (*pbsubstreams.Module, error) {
 	if m.InitialBlock != nil {
 		out.InitialBlock = *m.InitialBlock
 	}
+	if m.EndBlock != nil {
+		out.EndBlock = *m.EndBlock
+	}
 
 	m.setOutputToProto(out)
 	m.setKindToProto(out)
@@ -328,20 +382,23 @@ func (m *Module) ToProtoWASM(codeIndex uint32) (*pbsubstreams.Module, error) {
 
 func (m *Module) setBlockFilterToProto(pbModule *pbsubstreams.Module) {
 	if m.BlockFilter != nil {
-		bf := &pbsubstreams.Module_BlockFilter{
-			Module: m.BlockFilter.Module,
-		}
-		switch {
-		case m.BlockFilter.Query.String != "":
-			bf.Query = &pbsubstreams.Module_BlockFilter_QueryString{
-				QueryString: m.BlockFilter.Query.String,
-			}
-		case m.BlockFilter.Query.Params:
-			bf.Query = &pbsubstreams.Module_BlockFilter_QueryFromParams{}
-		}
+		pbModule.BlockFilter = m.BlockFilter.toProto()
+	}
+}
 
-		pbModule.BlockFilter = bf
+func (bf *BlockFilter) toProto() *pbsubstreams.Module_BlockFilter {
+	out := &pbsubstreams.Module_BlockFilter{
+		Module: bf.Module,
+	}
+	switch {
+	case bf.Query.String != "":
+		out.Query = &pbsubstreams.Module_BlockFilter_QueryString{
+			QueryString: bf.Query.String,
+		}
+	case bf.Query.Params:
+		out.Query = &pbsubstreams.Module_BlockFilter_QueryFromParams{}
 	}
+	return out
 }
 
 func (m *Module) setInputsToProto(pbModule *pbsubstreams.Module) error {
@@ -398,10 +455,16 @@ func (m *Module) setInputsToProto(pbModule *pbsubstreams.Module) error {
 				return fmt.Errorf("input.params must be the first input")
 			}
 
+			paramsType, _, err := parseParamsType(input.Params)
+			if err != nil {
+				return err
+			}
+
 			pbInput := &pbsubstreams.Module_Input{
 				Input: &pbsubstreams.Module_Input_Params_{
 					Params: &pbsubstreams.Module_Input_Params{
 						Value: "",
+						Type:  paramsType,
 					},
 				},
 			}
@@ -434,6 +497,7 @@ const (
 	UpdatePolicyMin            = "min"
 	UpdatePolicyAppend         = "append"
 	UpdatePolicySetSum         = "set_sum"
+	UpdatePolicyCustom         = "custom"
 )
 
 func (m *Module) setKindToProto(pbModule *pbsubstreams.Module) {
@@ -467,14 +531,22 @@ func (m *Module) setKindToProto(pbModule *pbsubstreams.Module) {
 			updatePolicy = pbsubstreams.Module_KindStore_UPDATE_POLICY_APPEND
 		case UpdatePolicySetSum:
 			updatePolicy = pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_SUM
+		case UpdatePolicyCustom:
+			updatePolicy = pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM
 		default:
 			panic(fmt.Sprintf("invalid update policy %s", m.UpdatePolicy))
 		}
+		kindStore := &pbsubstreams.Module_KindStore{
+			UpdatePolicy:    updatePolicy,
+			ValueType:       m.ValueType,
+			MergeEntrypoint: m.MergeEntrypoint,
+		}
+		if m.RetainBlocks != nil {
+			kindStore.RetainBlocks = *m.RetainBlocks
+		}
+		kindStore.Indexes = m.indexesToProto()
 		pbModule.Kind = &pbsubstreams.Module_KindStore_{
-			KindStore: &pbsubstreams.Module_KindStore{
-				UpdatePolicy: updatePolicy,
-				ValueType:    m.ValueType,
-			},
+			KindStore: kindStore,
 		}
 	}
 }
@@ -240,3 +240,36 @@ func TestManifest_ToProto(t *testing.T) {
 //func (x *testSinkConfig) String() string                     { return "testSinkConfig" }
 //func (*testSinkConfig) ProtoMessage()                        {}
 //func (x *testSinkConfig) ProtoReflect() protoreflect.Message { panic("unimplemented") }
+
+func Test_validateStoreBuilder_MergeEntrypoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		module      *Module
+		expectedErr string
+	}{
+		{"custom", &Module{UpdatePolicy: UpdatePolicyCustom, ValueType: "proto:hll.v1.Sketch", MergeEntrypoint: "merge_sketch"}, ""},
+		{"custom without merge entrypoint", &Module{UpdatePolicy: UpdatePolicyCustom, ValueType: "bytes"}, "missing 'mergeEntrypoint' for update policy 'custom'"},
+		{"merge entrypoint on built-in policy", &Module{UpdatePolicy: UpdatePolicySet, ValueType: "bytes", MergeEntrypoint: "merge_sketch"}, "'mergeEntrypoint' is only allowed for update policy 'custom'"},
+		{"custom with numeric value type", &Module{UpdatePolicy: UpdatePolicyCustom, ValueType: "int64", MergeEntrypoint: "merge_sketch"}, "invalid 'output.updatePolicy' and 'output.valueType' combination"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateStoreBuilder(test.module)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestModule_ToProto_MergeEntrypoint(t *testing.T) {
+	module := &Module{Name: "store_sketches", Kind: ModuleKindStore, UpdatePolicy: UpdatePolicyCustom, ValueType: "bytes", MergeEntrypoint: "merge_sketch"}
+	pbModule := &pbsubstreams.Module{}
+	module.setKindToProto(pbModule)
+
+	assert.Equal(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM, pbModule.GetKindStore().UpdatePolicy)
+	assert.Equal(t, "merge_sketch", pbModule.GetKindStore().MergeEntrypoint)
+}
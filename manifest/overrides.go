@@ -0,0 +1,158 @@
+package manifest
+
+import (
+	"context"
+	"strings"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// validateOverrides checks the `overrides` section on its own, before the imports
+// it refers to are loaded.
+func validateOverrides(manif *Manifest, issues *issueCollector) {
+	for _, name := range sortedKeys(manif.Overrides) {
+		override := manif.Overrides[name]
+		at := func(fields ...interface{}) []interface{} {
+			return append([]interface{}{"overrides", name}, fields...)
+		}
+
+		if !strings.Contains(name, PrefixSeparator) {
+			issues.add(at(), "overrides: %q is not an imported module, expected 'importAlias:module'", name)
+			continue
+		}
+		if override == nil {
+			continue
+		}
+
+		if _, found := manif.Params[name]; found && override.Params != nil {
+			issues.add(at("params"), "overrides: module %q: params also defined in 'params'", name)
+		}
+
+		if override.BlockFilter != nil && !override.BlockFilter.IsEmpty() {
+			params := manif.Params[name]
+			if override.Params != nil {
+				params = *override.Params
+			}
+			if err := validateQuery(context.Background(), override.BlockFilter.Query, params); err != nil {
+				issues.add(at("blockFilter", "query"), "overrides: module %q: %s", name, err)
+			}
+		}
+
+		for _, network := range sortedKeys(override.Networks) {
+			networkOverride := override.Networks[network]
+			networkParams := manif.Networks[network]
+			if networkOverride == nil || networkParams == nil {
+				continue
+			}
+			if _, found := networkParams.InitialBlocks[name]; found && networkOverride.InitialBlock != nil {
+				issues.add(at("networks", network, "initialBlock"), "overrides: module %q: initial block for network %q also defined in 'networks'", name, network)
+			}
+			if _, found := networkParams.Params[name]; found && networkOverride.Params != nil {
+				issues.add(at("networks", network, "params"), "overrides: module %q: params for network %q also defined in 'networks'", name, network)
+			}
+		}
+	}
+}
+
+// applyOverrides changes the imported modules of `pkg` as specified by the `overrides`
+// section of the manifest. An overridden value also replaces the module's value coming
+// from the imports in every network: network-specific values, from the `networks` section
+// or the override, are recorded in the package's networks, to be applied when the network
+// is known.
+func (r *manifestConverter) applyOverrides(pkg *pbsubstreams.Package, manif *Manifest) error {
+	if len(manif.Overrides) == 0 {
+		return nil
+	}
+
+	modules := make(map[string]*pbsubstreams.Module, len(pkg.Modules.Modules))
+	for _, mod := range pkg.Modules.Modules {
+		modules[mod.Name] = mod
+	}
+
+	encoder := newParamsEncoder(pkg)
+	issues := newIssueCollector(manif)
+
+	for _, name := range sortedKeys(manif.Overrides) {
+		override := manif.Overrides[name]
+		at := func(fields ...interface{}) []interface{} {
+			return append([]interface{}{"overrides", name}, fields...)
+		}
+
+		mod := modules[name]
+		if mod == nil {
+			issues.add(at(), "overrides: module %q not found in the imported packages", name)
+			continue
+		}
+		if override == nil {
+			continue
+		}
+
+		if override.InitialBlock != nil {
+			mod.InitialBlock = *override.InitialBlock
+			for network, networkParams := range pkg.Networks {
+				if _, found := networkParams.InitialBlocks[name]; found && !manif.definesNetworkInitialBlock(network, name) {
+					networkParams.InitialBlocks[name] = *override.InitialBlock
+				}
+			}
+		}
+
+		if override.Params != nil {
+			if err := setModuleParams(encoder, mod, *override.Params); err != nil {
+				issues.add(at("params"), "overrides: module %q: %s", name, err)
+			}
+			for network, networkParams := range pkg.Networks {
+				if _, found := networkParams.Params[name]; found && !manif.definesNetworkParams(network, name) {
+					networkParams.Params[name] = *override.Params
+				}
+			}
+		}
+
+		if override.BlockFilter != nil {
+			// an empty block filter removes the module's one, see handleEmptyBlockFilter
+			mod.BlockFilter = override.BlockFilter.toProto()
+		}
+
+		for _, network := range sortedKeys(override.Networks) {
+			networkOverride := override.Networks[network]
+			networkParams := pkg.Networks[network]
+			if networkParams == nil {
+				issues.add(at("networks", network), "overrides: module %q: network %q is not defined by the package or its imports", name, network)
+				continue
+			}
+			if networkOverride == nil {
+				continue
+			}
+
+			if networkOverride.InitialBlock != nil {
+				if networkParams.InitialBlocks == nil {
+					networkParams.InitialBlocks = make(map[string]uint64)
+				}
+				networkParams.InitialBlocks[name] = *networkOverride.InitialBlock
+			}
+			if networkOverride.Params != nil {
+				if networkParams.Params == nil {
+					networkParams.Params = make(map[string]string)
+				}
+				networkParams.Params[name] = *networkOverride.Params
+			}
+		}
+	}
+
+	return issues.err()
+}
+
+func (m *Manifest) definesNetworkInitialBlock(network, module string) bool {
+	if networkParams := m.Networks[network]; networkParams != nil {
+		_, found := networkParams.InitialBlocks[module]
+		return found
+	}
+	return false
+}
+
+func (m *Manifest) definesNetworkParams(network, module string) bool {
+	if networkParams := m.Networks[network]; networkParams != nil {
+		_, found := networkParams.Params[module]
+		return found
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const overridesDepManifest = `specVersion: v0.1.0
+package:
+  name: dep
+  version: v0.0.0
+
+binaries:
+  default:
+    type: wasm/rust-v1
+    file: %s
+
+network: mainnet
+
+modules:
+  - name: index_events
+    kind: blockIndex
+    inputs:
+      - source: sf.test.Block
+    output:
+      type: proto:sf.substreams.index.v1.Keys
+
+  - name: map_events
+    kind: map
+    initialBlock: 10
+    inputs:
+      - params: string
+      - source: sf.test.Block
+    output:
+      type: proto:sf.test.Output
+
+params:
+  map_events: "original"
+
+networks:
+  mainnet:
+    initialBlock:
+      map_events: 10
+  sepolia:
+    initialBlock:
+      map_events: 20
+`
+
+func writeOverridesManifests(t *testing.T, overrides string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	dummyWasm, err := filepath.Abs("testdata/binaries/dummy.wasm")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dep.yaml"), []byte(fmt.Sprintf(overridesDepManifest, dummyWasm)), 0644))
+
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+
+imports:
+  dep: ./dep.yaml
+
+network: mainnet
+
+`+overrides), 0644))
+
+	return manifestPath
+}
+
+func TestReader_Overrides(t *testing.T) {
+	manifestPath := writeOverridesManifests(t, `overrides:
+  dep:map_events:
+    initialBlock: 100
+    params: "overridden"
+    blockFilter:
+      module: dep:index_events
+      query:
+        string: "evt:transfer"
+    networks:
+      sepolia:
+        initialBlock: 200
+`)
+
+	read := func(network string) *PackageBundle {
+		reader, err := NewReader(manifestPath, SkipSourceCodeReader(), WithOverrideNetwork(network))
+		require.NoError(t, err)
+		bundle, err := reader.Read()
+		require.NoError(t, err)
+		return bundle
+	}
+
+	bundle := read("mainnet")
+	mod, err := bundle.Graph.Module("dep:map_events")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), mod.InitialBlock, "the override replaces the import's network value")
+	assert.Equal(t, "overridden", mod.Inputs[0].GetParams().Value)
+	require.NotNil(t, mod.BlockFilter)
+	assert.Equal(t, "dep:index_events", mod.BlockFilter.Module)
+	assert.Equal(t, "evt:transfer", mod.BlockFilter.GetQueryString())
+
+	mod, err = read("sepolia").Graph.Module("dep:map_events")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), mod.InitialBlock, "network-specific override")
+
+	reader, err := NewReader(writeOverridesManifests(t, ""), SkipSourceCodeReader())
+	require.NoError(t, err)
+	original, err := reader.Read()
+	require.NoError(t, err)
+
+	hash := func(bundle *PackageBundle) string {
+		mod, err := bundle.Graph.Module("dep:map_events")
+		require.NoError(t, err)
+		h, err := NewModuleHashes().HashModule(bundle.Package.Modules, mod, bundle.Graph)
+		require.NoError(t, err)
+		return string(h)
+	}
+	assert.NotEqual(t, hash(original), hash(bundle))
+}
+
+func TestReader_OverridesErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		overrides      string
+		expectedIssues []string
+	}{
+		{
+			name: "params defined twice",
+			overrides: `params:
+  dep:map_events: "from params"
+
+overrides:
+  dep:map_events:
+    params: "from overrides"
+`,
+			expectedIssues: []string{`:16:13: overrides: module "dep:map_events": params also defined in 'params'`},
+		},
+		{
+			name: "not an imported module",
+			overrides: `overrides:
+  map_local:
+    initialBlock: 1
+`,
+			expectedIssues: []string{`:12:3: overrides.map_local: invalid key "map_local"`},
+		},
+		{
+			name: "unknown module and network",
+			overrides: `overrides:
+  dep:map_unknown:
+    initialBlock: 1
+  dep:map_events:
+    networks:
+      holesky:
+        initialBlock: 1
+`,
+			expectedIssues: []string{
+				`:17:9: overrides: module "dep:map_events": network "holesky" is not defined by the package or its imports`,
+				`:13:5: overrides: module "dep:map_unknown" not found in the imported packages`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifestPath := writeOverridesManifests(t, test.overrides)
+
+			reader, err := NewReader(manifestPath, SkipSourceCodeReader())
+			require.NoError(t, err)
+			_, err = reader.Read()
+
+			var issues ManifestIssues
+			require.ErrorAs(t, err, &issues)
+			require.Len(t, issues, len(test.expectedIssues))
+			for i, expected := range test.expectedIssues {
+				assert.Equal(t, manifestPath+expected, issues[i].Error())
+			}
+		})
+	}
+}
+
+func TestReader_OverridesTypedParams(t *testing.T) {
+	dir := t.TempDir()
+	dummyWasm, err := filepath.Abs("testdata/binaries/dummy.wasm")
+	require.NoError(t, err)
+
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	writeFile("dep.yaml", fmt.Sprintf(`specVersion: v0.1.0
+package:
+  name: dep
+  version: v0.0.0
+
+binaries:
+  default:
+    type: wasm/rust-v1
+    file: %s
+
+modules:
+  - name: map_events
+    kind: map
+    inputs:
+      - params: proto:sf.test.Params
+      - source: sf.test.Block
+    output:
+      type: proto:sf.test.Output
+`, dummyWasm))
+	writeFile("params.proto", `syntax = "proto3";
+package sf.test;
+message Params {
+  string address = 1;
+}
+`)
+	writeFile("substreams.yaml", `specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+
+protobuf:
+  files:
+    - params.proto
+  importPaths:
+    - .
+
+imports:
+  dep: ./dep.yaml
+
+overrides:
+  dep:map_events:
+    params: '{"address": "0x1234"}'
+`)
+
+	reader, err := NewReader(filepath.Join(dir, "substreams.yaml"), SkipSourceCodeReader())
+	require.NoError(t, err)
+	bundle, err := reader.Read()
+	require.NoError(t, err)
+
+	mod, err := bundle.Graph.Module("dep:map_events")
+	require.NoError(t, err)
+	params := mod.Inputs[0].GetParams()
+	assert.Equal(t, `{"address": "0x1234"}`, params.Value)
+	assert.Equal(t, []byte("\n\x060x1234"), params.EncodedValue)
+}
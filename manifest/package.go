@@ -2,10 +2,13 @@ package manifest
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/dynamic"
@@ -19,6 +22,24 @@ type manifestConverter struct {
 
 	sinkConfigDynamicMessage       *dynamic.Message
 	skipSourceCodeImportValidation bool
+
+	// lock, when set, is verified against the content of every import
+	lock *Lock
+	// updateLock is passed to the readers of the imports, so that their own imports
+	// are not verified either
+	updateLock bool
+	// resolvedLock records the imports as they were loaded
+	resolvedLock *Lock
+	// resolver selects the version of versioned imports (`name@^0.2`)
+	resolver *importResolver
+	// network overrides the manifest's network when interpolating `${network}` in params
+	network string
+	// binaryDeduplication accumulates the binaries found identical to another one of the
+	// package, including those deduplicated while reading imports
+	binaryDeduplication BinaryDeduplication
+	// trustedKeys and requireSignature are passed to the readers of the imports
+	trustedKeys      []ed25519.PublicKey
+	requireSignature bool
 }
 
 func newManifestConverter(inputPath string, skipSourceCodeImportValidation bool) *manifestConverter {
@@ -33,6 +54,10 @@ func (r *manifestConverter) Convert(manif *Manifest) (*pbsubstreams.Package, []*
 		return nil, nil, nil, err
 	}
 
+	if err := r.interpolateParams(manif); err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to interpolate params: %w", err)
+	}
+
 	if err := r.validateManifest(manif); err != nil {
 		return nil, nil, nil, fmt.Errorf("unable to load manifest: %w", err)
 	}
@@ -59,76 +84,113 @@ func (r *manifestConverter) expandManifestVariables(manif *Manifest) error {
 }
 
 func (r *manifestConverter) validateManifest(manif *Manifest) error {
+	issues := newIssueCollector(manif)
+
 	if manif.SpecVersion != "v0.1.0" {
-		return fmt.Errorf("invalid 'specVersion', must be v0.1.0")
+		issues.add([]interface{}{"specVersion"}, "invalid 'specVersion', must be v0.1.0")
 	}
 
 	// TODO: put some limits on the NUMBER of modules (max 50 ?)
 	// TODO: put a limit on the SIZE of the WASM payload (max 10MB per binary?)
 
-	for _, s := range manif.Modules {
+	seenModules := make(map[string]bool)
+	for idx, s := range manif.Modules {
+		at := func(fields ...interface{}) []interface{} {
+			return append([]interface{}{"modules", idx}, fields...)
+		}
+
+		if seenModules[s.Name] {
+			issues.add(at("name"), "module %q: duplicate module name", s.Name)
+		}
+		seenModules[s.Name] = true
+
+		if s.Binary != "" {
+			if _, found := manif.Binaries[s.Binary]; !found {
+				issues.add(at("binary"), "module %q: binary %q is not defined in 'binaries'", s.Name, s.Binary)
+			}
+		}
+
 		if s.BlockFilter != nil && !s.BlockFilter.IsEmpty() {
 			ctx := context.Background()
 			if err := validateQuery(ctx, s.BlockFilter.Query, manif.Params[s.Name]); err != nil {
-				return fmt.Errorf("stream %q: %w", s.Name, err)
+				issues.add(at("blockFilter", "query"), "stream %q: %s", s.Name, err)
 			}
 		}
+		if s.RetainBlocks != nil && s.Kind != ModuleKindStore && s.Kind != "" {
+			issues.add(at("retainBlocks"), "stream %q: 'retainBlocks' is only allowed for kind 'store'", s.Name)
+		}
+		if s.MergeEntrypoint != "" && s.Kind != ModuleKindStore && s.Kind != "" {
+			issues.add(at("mergeEntrypoint"), "stream %q: 'mergeEntrypoint' is only allowed for kind 'store'", s.Name)
+		}
+		validateStoreIndexes(s, issues, at)
+
 		// TODO: let's make sure this is also checked when received in Protobuf in a remote request.
 		switch s.Kind {
 		case ModuleKindMap:
 			if s.Output.Type == "" {
-				return fmt.Errorf("stream %q: missing 'output.type' for kind 'map'", s.Name)
+				issues.add(at("output"), "stream %q: missing 'output.type' for kind 'map'", s.Name)
 			}
 			if s.Use != "" {
-				return fmt.Errorf("stream %q: 'use' is not allowed for kind 'map'", s.Name)
+				issues.add(at("use"), "stream %q: 'use' is not allowed for kind 'map'", s.Name)
 			}
 		case ModuleKindStore:
 			if err := validateStoreBuilder(s); err != nil {
-				return fmt.Errorf("stream %q: %w", s.Name, err)
+				issues.add(at("updatePolicy"), "stream %q: %s", s.Name, err)
 			}
 			if s.Use != "" {
-				return fmt.Errorf("stream %q: 'use' is not allowed for kind 'store'", s.Name)
+				issues.add(at("use"), "stream %q: 'use' is not allowed for kind 'store'", s.Name)
 			}
 		case ModuleKindBlockIndex:
 			if s.Inputs == nil {
-				return fmt.Errorf("stream %q: block index module should have inputs", s.Name)
+				issues.add(at(), "stream %q: block index module should have inputs", s.Name)
 			}
 
-			for _, input := range s.Inputs {
+			for inputIdx, input := range s.Inputs {
 				if input.IsParams() {
-					return fmt.Errorf("stream %q: block index module cannot have params input", s.Name)
+					issues.add(at("inputs", inputIdx), "stream %q: block index module cannot have params input", s.Name)
 				}
 			}
 
 			if s.BlockFilter != nil {
-				return fmt.Errorf("stream %q: block index module cannot have block filter", s.Name)
+				issues.add(at("blockFilter"), "stream %q: block index module cannot have block filter", s.Name)
 			}
 
 			if s.Output.Type != "proto:sf.substreams.index.v1.Keys" {
-				return fmt.Errorf("stream %q: block index module must have output type 'proto:sf.substreams.index.v1.Keys'", s.Name)
+				issues.add(at("output", "type"), "stream %q: block index module must have output type 'proto:sf.substreams.index.v1.Keys'", s.Name)
 			}
 
 		case "":
 			if s.Use == "" {
-				return fmt.Errorf("module kind not specified for %q", s.Name)
-			}
-
-			if err := validateModuleWithUse(s); err != nil {
-				return fmt.Errorf("stream %q: %w", s.Name, err)
+				issues.add(at(), "module kind not specified for %q", s.Name)
+			} else if err := validateModuleWithUse(s); err != nil {
+				issues.add(at("use"), "stream %q: %s", s.Name, err)
 			}
 
 		default:
-			return fmt.Errorf("stream %q: invalid kind %q", s.Name, s.Kind)
+			issues.add(at("kind"), "stream %q: invalid kind %q", s.Name, s.Kind)
 		}
 
-		for idx, input := range s.Inputs {
+		for inputIdx, input := range s.Inputs {
 			if err := input.parse(); err != nil {
-				return fmt.Errorf("module %q: invalid input [%d]: %w", s.Name, idx, err)
+				issues.add(at("inputs", inputIdx), "module %q: invalid input [%d]: %s", s.Name, inputIdx, err)
 			}
 		}
 	}
 
-	return nil
+	paramNames := make([]string, 0, len(manif.Params))
+	for name := range manif.Params {
+		paramNames = append(paramNames, name)
+	}
+	sort.Strings(paramNames)
+	for _, name := range paramNames {
+		if !strings.Contains(name, ":") && !seenModules[name] {
+			issues.add([]interface{}{"params", name}, "params: module %q is not defined", name)
+		}
+	}
+
+	validateOverrides(manif, issues)
+
+	return issues.err()
 }
 
 func validateQuery(ctx context.Context, query BlockFilterQuery, param string) error {
@@ -212,9 +274,18 @@ func checkUseInputs(moduleWithUse, usedModule *pbsubstreams.Module, manifestModu
 			}
 
 		case input.GetParams() != nil:
-			if usedModuleInput.GetParams() == nil {
+			usedParams := usedModuleInput.GetParams()
+			if usedParams == nil {
 				return fmt.Errorf("module %q: input %q is not a params type", manifestModuleWithUse.Name, input.String())
 			}
+			// the params are decoded by the used module's code, so they keep its type
+			params := input.GetParams()
+			if params.Type == "" {
+				params.Type = usedParams.Type
+				params.JsonSchema = usedParams.JsonSchema
+			} else if params.Type != usedParams.Type {
+				return fmt.Errorf("module %q: params type %q differs from the type %q of the used module %q", manifestModuleWithUse.Name, params.Type, usedParams.Type, manifestModuleWithUse.Use)
+			}
 
 		case input.GetStore() != nil:
 			if usedModuleInput.GetStore() == nil {
@@ -254,7 +325,7 @@ func (r *manifestConverter) manifestToPkg(manif *Manifest) (*pbsubstreams.Packag
 		return nil, nil, nil, fmt.Errorf("failed to convert manifest to pkg: %w", err)
 	}
 
-	if err := loadImports(pkg, manif); err != nil {
+	if err := r.loadImports(pkg, manif); err != nil {
 		return nil, nil, nil, fmt.Errorf("error loading imports: %w", err)
 	}
 
@@ -273,6 +344,11 @@ func (r *manifestConverter) manifestToPkg(manif *Manifest) (*pbsubstreams.Packag
 
 	protoFiles = append(protoFiles, fromLocalFiles...)
 
+	// overrides are applied once the local protobuf files are loaded, as typed params may use them
+	if err := r.applyOverrides(pkg, manif); err != nil {
+		return nil, nil, nil, fmt.Errorf("applying overrides: %w", err)
+	}
+
 	if manif.Package.Image != "" {
 		if err := loadImage(pkg, manif); err != nil {
 			return nil, nil, nil, fmt.Errorf("loading image: %w", err)
@@ -287,6 +363,10 @@ func (r *manifestConverter) manifestToPkg(manif *Manifest) (*pbsubstreams.Packag
 		return nil, nil, nil, fmt.Errorf("handling use modules: %w", err)
 	}
 
+	if err := resolveStoreIndexes(pkg, manif); err != nil {
+		return nil, nil, nil, fmt.Errorf("resolving store indexes: %w", err)
+	}
+
 	if err := handleParams(pkg, manif); err != nil {
 		return nil, nil, nil, fmt.Errorf("handling params: %w", err)
 	}
@@ -306,19 +386,14 @@ func handleEmptyBlockFilter(pkg *pbsubstreams.Package, manif *Manifest) {
 }
 
 func handleParams(pkg *pbsubstreams.Package, manif *Manifest) error {
+	encoder := newParamsEncoder(pkg)
 	for modName, paramValue := range manif.Params {
 		var modFound bool
 		for _, mod := range pkg.Modules.Modules {
 			if mod.Name == modName {
-				if len(mod.Inputs) == 0 {
-					return fmt.Errorf("params value defined for module %q but module has no inputs defined, add 'params: string' to 'inputs' for module", modName)
+				if err := setModuleParams(encoder, mod, paramValue); err != nil {
+					return fmt.Errorf("params value defined for module %q: %w", modName, err)
 				}
-
-				p := mod.Inputs[0].GetParams()
-				if p == nil {
-					return fmt.Errorf("params value defined for module %q: module %q does not have 'params' as its first input type", modName, modName)
-				}
-				p.Value = paramValue
 				modFound = true
 			}
 		}
@@ -329,6 +404,21 @@ func handleParams(pkg *pbsubstreams.Package, manif *Manifest) error {
 	return nil
 }
 
+// setModuleParams sets the value of the params input of `mod`, encoding it as
+// declared by its type.
+func setModuleParams(encoder *paramsEncoder, mod *pbsubstreams.Module, value string) error {
+	if len(mod.Inputs) == 0 {
+		return fmt.Errorf("module has no inputs defined, add 'params: string' to 'inputs' for module")
+	}
+
+	p := mod.Inputs[0].GetParams()
+	if p == nil {
+		return fmt.Errorf("module %q does not have 'params' as its first input type", mod.Name)
+	}
+	p.Value = value
+	return encoder.encode(p)
+}
+
 func (m *Manifest) readFileFromName(filename string) ([]byte, error) {
 	fileNameFound, err := searchExistingCaseInsensitiveFileName(m.Workdir, filename)
 	if err != nil {
@@ -403,7 +493,8 @@ func (r *manifestConverter) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package
 			pkg.Networks[k] = params
 		}
 	}
-	moduleCodeIndexes := map[string]int{}
+	moduleCodeIndexes := map[string]uint32{}
+	binaries := newBinarySet(pkg.Modules)
 
 	for _, mod := range m.Modules {
 		pkg.ModuleMeta = append(pkg.ModuleMeta, &pbsubstreams.ModuleMetadata{
@@ -438,8 +529,6 @@ func (r *manifestConverter) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package
 
 		switch wasmCodeTypeID {
 		case "wasm/rust-v1", "wasip1/tinygo-v1":
-			// OPTIM(abourget): also check if it's not already in
-			// `Binaries`, by comparing its, length + hash or value.
 			codeIndex, found := moduleCodeIndexes[binaryDef.File]
 			if !found {
 				codePath := m.resolvePath(binaryDef.File)
@@ -450,14 +539,16 @@ func (r *manifestConverter) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package
 						return nil, fmt.Errorf("failed to read source code %q: %w", codePath, err)
 					}
 				}
-				pkg.Modules.Binaries = append(pkg.Modules.Binaries, &pbsubstreams.Binary{Type: binaryDef.Type, Content: byteCode})
-				codeIndex = len(pkg.Modules.Binaries) - 1
+				codeIndex = binaries.add(&pbsubstreams.Binary{Type: binaryDef.Type, Content: byteCode})
 				moduleCodeIndexes[binaryDef.File] = codeIndex
 			}
-			pbmod, err = mod.ToProtoWASM(uint32(codeIndex))
+			pbmod, err = mod.ToProtoWASM(codeIndex)
 			if err != nil {
 				return nil, err
 			}
+			if err := m.loadParamsJSONSchema(mod, pbmod); err != nil {
+				return nil, err
+			}
 
 		default:
 			return nil, fmt.Errorf("module %q: invalid code type %q", mod.Name, binaryDef.Type)
@@ -465,6 +556,7 @@ func (r *manifestConverter) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package
 
 		pkg.Modules.Modules = append(pkg.Modules.Modules, pbmod)
 	}
+	r.binaryDeduplication.add(binaries.stats)
 
 	return
 }
@@ -0,0 +1,229 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/streamingfast/dstore"
+	"golang.org/x/mod/semver"
+)
+
+// PackageIndexURL is the default location of the index used to resolve versioned
+// imports (`name@^0.2`), it can be a local directory or any dstore URL.
+var PackageIndexURL string
+
+var versionedImportRegex = regexp.MustCompile(`^([A-Za-z0-9_-]+)@(\S.*)$`)
+
+// parseVersionedImport splits a registry-style import reference `name@range`. It
+// returns false when `in` is a regular path or URL.
+func parseVersionedImport(in string) (name string, constraint string, ok bool) {
+	if hasRemotePrefix(in) || strings.ContainsAny(in, `/\`) {
+		return "", "", false
+	}
+
+	parts := versionedImportRegex.FindStringSubmatch(in)
+	if parts == nil {
+		return "", "", false
+	}
+	return parts[1], strings.TrimSpace(parts[2]), true
+}
+
+// PackageIndex lists the available versions of packages and locates them.
+type PackageIndex interface {
+	// Versions returns the versions of `name` available in the index, in canonical `vX.Y.Z` form.
+	Versions(ctx context.Context, name string) ([]string, error)
+	// PackageURL returns the location of version `version` of package `name`, readable by a `Reader`.
+	PackageURL(name, version string) string
+}
+
+// NewPackageIndex returns an index backed by the store at `indexURL`, in which packages
+// are stored flat under the name produced by `substreams pack`: `<name>-<version>.spkg`.
+func NewPackageIndex(indexURL string) (PackageIndex, error) {
+	store, err := dstore.NewStore(indexURL, "", "", false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create store from %q: %w", indexURL, err)
+	}
+	return &storePackageIndex{store: store}, nil
+}
+
+type storePackageIndex struct {
+	store dstore.Store
+}
+
+func packageIndexFilePrefix(name string) string {
+	return strings.Replace(name, "_", "-", -1) + "-"
+}
+
+func (i *storePackageIndex) Versions(ctx context.Context, name string) ([]string, error) {
+	prefix := packageIndexFilePrefix(name)
+
+	var versions []string
+	err := i.store.Walk(ctx, prefix+"v", func(filename string) error {
+		version, found := strings.CutSuffix(strings.TrimPrefix(filename, prefix), ".spkg")
+		if found && fullVersionRegex.MatchString(version) {
+			versions = append(versions, version)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing versions of %q: %w", name, err)
+	}
+
+	sort.Slice(versions, func(a, b int) bool { return semver.Compare(versions[a], versions[b]) < 0 })
+	return versions, nil
+}
+
+func (i *storePackageIndex) PackageURL(name, version string) string {
+	filename := packageIndexFilePrefix(name) + version + ".spkg"
+	if local, ok := i.store.(*dstore.LocalStore); ok {
+		return local.ObjectPath(filename)
+	}
+	return i.store.ObjectURL(filename)
+}
+
+// ResolvedImport is the outcome of resolving the versioned imports of a given package
+// across a manifest and all its transitive imports.
+type ResolvedImport struct {
+	Package     string              `json:"package"`
+	Version     string              `json:"version"`
+	URL         string              `json:"url"`
+	Constraints []*ImportConstraint `json:"constraints"`
+}
+
+type ImportConstraint struct {
+	Constraint string `json:"constraint"`
+	RequiredBy string `json:"required_by"`
+}
+
+// errRestartResolution is returned when a constraint met late in the import tree rules
+// out a version already selected, while another version satisfies all the constraints
+// seen so far. Resolution then restarts with this knowledge from the top-level manifest.
+var errRestartResolution = errors.New("import resolution must restart")
+
+const maxResolutionAttempts = 10
+
+type importResolver struct {
+	indexURL string
+	index    PackageIndex
+
+	resolved map[string]*ResolvedImport
+	// seen accumulates, across resolution attempts, the constraints met for each package
+	seen     map[string][]*versionConstraint
+	versions map[string][]string
+}
+
+func newImportResolver(indexURL string, index PackageIndex) *importResolver {
+	return &importResolver{
+		indexURL: indexURL,
+		index:    index,
+		seen:     make(map[string][]*versionConstraint),
+		versions: make(map[string][]string),
+	}
+}
+
+func (r *importResolver) reset() {
+	r.resolved = make(map[string]*ResolvedImport)
+}
+
+func (r *importResolver) availableVersions(name string) ([]string, error) {
+	if versions, found := r.versions[name]; found {
+		return versions, nil
+	}
+
+	if r.index == nil {
+		if r.indexURL == "" {
+			return nil, fmt.Errorf("no package index configured, use '--package-index' to specify one")
+		}
+		index, err := NewPackageIndex(r.indexURL)
+		if err != nil {
+			return nil, err
+		}
+		r.index = index
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteFetchTimeout)
+	defer cancel()
+	versions, err := r.index.Versions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	r.versions[name] = versions
+	return versions, nil
+}
+
+// resolve selects the version of package `name` satisfying `rawConstraint`, required
+// by `requiredBy`, and consistent with the selections already made. `preferred`, when
+// set (from the lock file), is chosen over the highest match if it satisfies the constraints.
+func (r *importResolver) resolve(name, rawConstraint, requiredBy, preferred string) (*ResolvedImport, error) {
+	constraint, err := parseVersionConstraint(rawConstraint)
+	if err != nil {
+		return nil, fmt.Errorf("package %q: %w", name, err)
+	}
+	r.seen[name] = append(r.seen[name], constraint)
+	requirement := &ImportConstraint{Constraint: rawConstraint, RequiredBy: requiredBy}
+
+	versions, err := r.availableVersions(name)
+	if err != nil {
+		return nil, fmt.Errorf("package %q: %w", name, err)
+	}
+
+	if resolved, found := r.resolved[name]; found {
+		resolved.Constraints = append(resolved.Constraints, requirement)
+		if constraint.matches(resolved.Version) {
+			return resolved, nil
+		}
+
+		var all []*versionConstraint
+		for _, c := range resolved.Constraints {
+			parsed, _ := parseVersionConstraint(c.Constraint)
+			all = append(all, parsed)
+		}
+		if highestMatching(versions, all...) == "" {
+			var details []string
+			for _, c := range resolved.Constraints {
+				details = append(details, fmt.Sprintf("%q required by %s", c.Constraint, c.RequiredBy))
+			}
+			return nil, fmt.Errorf("package %q: conflicting version constraints, no version satisfies all of %s", name, strings.Join(details, ", "))
+		}
+		return nil, errRestartResolution
+	}
+
+	version := ""
+	if preferred != "" && constraint.matches(preferred) && highestMatching([]string{preferred}, r.seen[name]...) != "" && slices.Contains(versions, preferred) {
+		version = preferred
+	}
+	if version == "" {
+		// favor a version compatible with constraints met during previous attempts
+		version = highestMatching(versions, r.seen[name]...)
+	}
+	if version == "" {
+		version = highestMatching(versions, constraint)
+	}
+	if version == "" {
+		return nil, fmt.Errorf("package %q: no version matching %q in index (available: %s)", name, rawConstraint, strings.Join(versions, ", "))
+	}
+
+	resolved := &ResolvedImport{
+		Package:     name,
+		Version:     version,
+		URL:         r.index.PackageURL(name, version),
+		Constraints: []*ImportConstraint{requirement},
+	}
+	r.resolved[name] = resolved
+	return resolved, nil
+}
+
+// Resolved returns the resolutions, sorted by package name.
+func (r *importResolver) Resolved() []*ResolvedImport {
+	out := make([]*ResolvedImport, 0, len(r.resolved))
+	for _, res := range r.resolved {
+		out = append(out, res)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Package < out[j].Package })
+	return out
+}
@@ -0,0 +1,88 @@
+package manifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseVersionedImport(t *testing.T) {
+	name, constraint, ok := parseVersionedImport("uniswap-v3@^0.2")
+	require.True(t, ok)
+	assert.Equal(t, "uniswap-v3", name)
+	assert.Equal(t, "^0.2", constraint)
+
+	for _, in := range []string{"./uniswap-v3-v0.2.0.spkg", "https://example.com/a@b.spkg", "dir/pkg@^0.2", "uniswap.spkg"} {
+		_, _, ok := parseVersionedImport(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestReader_VersionedImports(t *testing.T) {
+	indexDir := t.TempDir()
+	for _, version := range []string{"v0.1.0", "v0.2.1", "v0.2.4", "v0.3.0", "v0.3.1-rc1"} {
+		pkg := &pbsubstreams.Package{
+			Version:     1,
+			PackageMeta: []*pbsubstreams.PackageMetadata{{Name: "dep", Version: version}},
+			Modules:     &pbsubstreams.Modules{},
+		}
+		cnt, err := proto.Marshal(pkg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(indexDir, "dep-"+version+".spkg"), cnt, 0644))
+	}
+	index, err := NewPackageIndex(indexDir)
+	require.NoError(t, err)
+
+	versions, err := index.Versions(context.Background(), "dep")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v0.1.0", "v0.2.1", "v0.2.4", "v0.3.0", "v0.3.1-rc1"}, versions)
+
+	read := func(t *testing.T, topConstraint, midConstraint string) ([]*ResolvedImport, error) {
+		dir := t.TempDir()
+		imports := "  dep: dep@" + topConstraint + "\n"
+		if midConstraint != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "mid.yaml"), []byte("specVersion: v0.1.0\npackage:\n  name: mid\n  version: v0.0.0\nimports:\n  dep: dep@"+midConstraint+"\n"), 0644))
+			imports += "  mid: ./mid.yaml\n"
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "substreams.yaml"), []byte("specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\nimports:\n"+imports), 0644))
+
+		reader, err := newReader(filepath.Join(dir, "substreams.yaml"), dir, WithPackageIndex(index))
+		require.NoError(t, err)
+		_, err = reader.Read()
+		return reader.ResolvedImports(), err
+	}
+
+	t.Run("highest matching", func(t *testing.T) {
+		resolved, err := read(t, "^0.2", "")
+		require.NoError(t, err)
+		require.Len(t, resolved, 1)
+		assert.Equal(t, "v0.2.4", resolved[0].Version)
+		assert.Equal(t, filepath.Join(indexDir, "dep-v0.2.4.spkg"), resolved[0].URL)
+	})
+
+	t.Run("narrowed by transitive import", func(t *testing.T) {
+		resolved, err := read(t, "^0.2", "<0.2.3")
+		require.NoError(t, err)
+		require.Len(t, resolved, 1)
+		assert.Equal(t, "v0.2.1", resolved[0].Version)
+		assert.Len(t, resolved[0].Constraints, 2)
+	})
+
+	t.Run("conflicting ranges", func(t *testing.T) {
+		_, err := read(t, "^0.2", "^0.3")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `package "dep": conflicting version constraints`)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := read(t, "^1", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no version matching "^1"`)
+	})
+}
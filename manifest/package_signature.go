@@ -0,0 +1,201 @@
+package manifest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// TrustedKeys are the public keys accepted as signers of packages by the readers
+// not configured with `WithSignatureVerification`.
+var TrustedKeys []ed25519.PublicKey
+
+// RequireSignature makes the readers not configured with `WithSignatureVerification`
+// refuse packages that are not signed by one of the `TrustedKeys`.
+var RequireSignature bool
+
+const packageSignatureFieldNumber protowire.Number = 15
+
+// SignPackage serializes the package with an ed25519 signature of its content. The
+// signature covers the serialized package without its `signature` field, which is
+// appended last so that verification can strip it from the bytes as they were signed.
+func SignPackage(pkg *pbsubstreams.Package, key ed25519.PrivateKey) ([]byte, error) {
+	unsigned := proto.Clone(pkg).(*pbsubstreams.Package)
+	unsigned.Signature = nil
+
+	cnt, err := proto.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling package: %w", err)
+	}
+
+	signature, err := proto.Marshal(&pbsubstreams.PackageSignature{
+		PublicKey: key.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(key, cnt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling signature: %w", err)
+	}
+
+	cnt = protowire.AppendTag(cnt, packageSignatureFieldNumber, protowire.BytesType)
+	return protowire.AppendBytes(cnt, signature), nil
+}
+
+// verifyPackageSignature checks the signature of a serialized package. A signature that
+// does not match the content is always refused. When `required` is set, the package must
+// also be signed by one of the `trustedKeys`.
+func verifyPackageSignature(data []byte, signature *pbsubstreams.PackageSignature, trustedKeys []ed25519.PublicKey, required bool) error {
+	if signature == nil {
+		if required {
+			return fmt.Errorf("package is not signed")
+		}
+		return nil
+	}
+
+	if len(signature.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid package signature: public key is %d bytes, expected %d", len(signature.PublicKey), ed25519.PublicKeySize)
+	}
+
+	unsigned, err := stripPackageSignature(data)
+	if err != nil {
+		return fmt.Errorf("invalid package signature: %w", err)
+	}
+
+	publicKey := ed25519.PublicKey(signature.PublicKey)
+	if !ed25519.Verify(publicKey, unsigned, signature.Signature) {
+		return fmt.Errorf("invalid package signature: content does not match the signature of key %s", hex.EncodeToString(publicKey))
+	}
+
+	for _, trusted := range trustedKeys {
+		if trusted.Equal(publicKey) {
+			return nil
+		}
+	}
+
+	if required {
+		return fmt.Errorf("package is signed by key %s which is not trusted", hex.EncodeToString(publicKey))
+	}
+
+	zlog.Debug("package signed by an untrusted key", zap.String("public_key", hex.EncodeToString(publicKey)))
+	return nil
+}
+
+// stripPackageSignature returns the serialized package without its `signature` field,
+// as it was when signed.
+func stripPackageSignature(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	for rest := data; len(rest) > 0; {
+		num, typ, n := protowire.ConsumeTag(rest)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		m := protowire.ConsumeFieldValue(num, typ, rest[n:])
+		if m < 0 {
+			return nil, protowire.ParseError(m)
+		}
+
+		if num != packageSignatureFieldNumber {
+			out = append(out, rest[:n+m]...)
+		}
+		rest = rest[n+m:]
+	}
+
+	return out, nil
+}
+
+// ParseSigningKey decodes an ed25519 private key, either PEM encoded (PKCS #8, as
+// generated by `openssl genpkey -algorithm ed25519`) or as the hex encoding of its
+// 32 bytes seed or 64 bytes private key.
+func ParseSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PEM private key: %w", err)
+		}
+		privateKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("private key is a %T, expected an ed25519 key", key)
+		}
+		return privateKey, nil
+	}
+
+	raw, err := hex.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("private key is neither PEM nor hex encoded: %w", err)
+	}
+
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, fmt.Errorf("private key is %d bytes, expected %d or %d", len(raw), ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
+}
+
+// ParsePublicKey decodes an ed25519 public key, either PEM encoded (PKIX, as generated
+// by `openssl pkey -pubout`) or as the hex encoding of its 32 bytes.
+func ParsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PEM public key: %w", err)
+		}
+		publicKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key is a %T, expected an ed25519 key", key)
+		}
+		return publicKey, nil
+	}
+
+	raw, err := hex.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("public key is neither PEM nor hex encoded: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key is %d bytes, expected %d", len(raw), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// LoadTrustedKeys reads the public keys given either inline, hex encoded, or as the
+// path to a file containing one.
+func LoadTrustedKeys(values []string) ([]ed25519.PublicKey, error) {
+	var out []ed25519.PublicKey
+	for _, value := range values {
+		data := []byte(value)
+		if !isHexPublicKey(value) {
+			cnt, err := os.ReadFile(value)
+			if err != nil {
+				return nil, fmt.Errorf("reading trusted key %q: %w", value, err)
+			}
+			data = cnt
+		}
+
+		key, err := ParsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("trusted key %q: %w", value, err)
+		}
+		out = append(out, key)
+	}
+	return out, nil
+}
+
+func isHexPublicKey(value string) bool {
+	value = strings.TrimSpace(value)
+	if len(value) != 2*ed25519.PublicKeySize {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
@@ -232,6 +232,21 @@ func TestValidateManifest(t *testing.T) {
 			},
 			expectedError: "stream \"basic_index\": block index module cannot have block filter",
 		},
+		{
+			name: "all issues reported",
+			manifest: &Manifest{
+				SpecVersion: "v0.1.0",
+				Modules: []*Module{
+					{Name: "basic_index", Kind: "blockIndex", Inputs: []*Input{{Params: "string"}}, Output: StreamOutput{"proto:sf.substreams.test"}},
+					{Name: "basic_index", Kind: "map", Inputs: []*Input{{Source: "sf.substreams.v1.Clock"}}},
+				},
+			},
+			expectedError: "4 issues found:\n" +
+				"stream \"basic_index\": block index module cannot have params input\n" +
+				"stream \"basic_index\": block index module must have output type 'proto:sf.substreams.index.v1.Keys'\n" +
+				"module \"basic_index\": duplicate module name\n" +
+				"stream \"basic_index\": missing 'output.type' for kind 'map'",
+		},
 	}
 
 	manifestConv := newManifestConverter("test", true)
@@ -373,6 +388,28 @@ func TestValidateModules(t *testing.T) {
 			},
 			expectedError: "checking block filter for module \"test_module\": block filter module \"map_module\" not of 'block_index' kind",
 		},
+		{
+			name: "end block not after initial block",
+			modules: &pbsubstreams.Modules{
+				Modules: []*pbsubstreams.Module{
+					{
+						Name:             "test_module",
+						BinaryEntrypoint: "test_module",
+						InitialBlock:     uint64(62),
+						EndBlock:         uint64(62),
+						Kind: &pbsubstreams.Module_KindMap_{
+							KindMap: &pbsubstreams.Module_KindMap{
+								OutputType: "sf.database.v1.changes",
+							},
+						},
+						Inputs: []*pbsubstreams.Module_Input{
+							{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.database.v1.changes"}}},
+						},
+					},
+				},
+			},
+			expectedError: "module \"test_module\": end block 62 must be greater than its initial block 62",
+		},
 	}
 
 	for _, c := range cases {
@@ -1,10 +1,15 @@
 package manifest
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
 	"github.com/schollz/closestmatch"
+	"gopkg.in/yaml.v3"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
@@ -21,7 +26,10 @@ func ParseParams(paramsString []string) (map[string]string, error) {
 	return params, nil
 }
 
+// ApplyParams sets the value of the params of modules, keyed by module name. Values of
+// typed params are validated and encoded.
 func ApplyParams(params map[string]string, pkg *pbsubstreams.Package) error {
+	encoder := newParamsEncoder(pkg)
 	for k, v := range params {
 		var found bool
 		var closest []string
@@ -36,6 +44,9 @@ func ApplyParams(params map[string]string, pkg *pbsubstreams.Package) error {
 					return fmt.Errorf("param for module %q: first module input is not 'params'", mod.Name)
 				}
 				p.Value = v
+				if err := encoder.encode(p); err != nil {
+					return fmt.Errorf("param for module %q: %w", mod.Name, err)
+				}
 				found = true
 			}
 		}
@@ -46,3 +57,139 @@ func ApplyParams(params map[string]string, pkg *pbsubstreams.Package) error {
 	}
 	return nil
 }
+
+// parseParamsType parses the type of a `params` module input, as declared in a manifest:
+// `string`, `proto:<message type>` or `json:<schema file>`. It returns the type stored
+// in the package and, for `json`, the path of the JSON Schema file.
+func parseParamsType(in string) (paramsType string, schemaFile string, err error) {
+	switch {
+	case in == "string":
+		return "", "", nil
+	case strings.HasPrefix(in, "proto:") && len(in) > len("proto:"):
+		return in, "", nil
+	case strings.HasPrefix(in, "json:") && len(in) > len("json:"):
+		return "json", strings.TrimPrefix(in, "json:"), nil
+	}
+	return "", "", fmt.Errorf("invalid value %q, must be 'string', 'proto:<message type>' or 'json:<schema file>'", in)
+}
+
+// loadParamsJSONSchema embeds in `pbmod` the JSON Schema declared by the `params: json:<schema file>`
+// input of `mod`, if any.
+func (m *Manifest) loadParamsJSONSchema(mod *Module, pbmod *pbsubstreams.Module) error {
+	for idx, input := range mod.Inputs {
+		if !input.IsParams() {
+			continue
+		}
+		_, schemaFile, err := parseParamsType(input.Params)
+		if err != nil || schemaFile == "" {
+			return err
+		}
+
+		path := m.resolvePath(schemaFile)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("module %q: reading params JSON Schema: %w", mod.Name, err)
+		}
+		if _, err := parseJSONSchema(content); err != nil {
+			return fmt.Errorf("module %q: invalid params JSON Schema %q: %w", mod.Name, path, err)
+		}
+		pbmod.Inputs[idx].GetParams().JsonSchema = string(content)
+	}
+	return nil
+}
+
+// paramsEncoder validates the values of typed params and encodes them in the form they
+// are passed to the module.
+type paramsEncoder struct {
+	pkg   *pbsubstreams.Package
+	files []*desc.FileDescriptor
+}
+
+func newParamsEncoder(pkg *pbsubstreams.Package) *paramsEncoder {
+	return &paramsEncoder{pkg: pkg}
+}
+
+func (e *paramsEncoder) encode(params *pbsubstreams.Module_Input_Params) error {
+	params.EncodedValue = nil
+
+	switch {
+	case params.Type == "" || params.Type == "string":
+		return nil
+
+	case params.Type == "json":
+		return validateJSONParams(params.Value, params.JsonSchema)
+
+	case strings.HasPrefix(params.Type, "proto:"):
+		msgDesc, err := e.messageDescriptor(strings.TrimPrefix(params.Type, "proto:"))
+		if err != nil {
+			return err
+		}
+
+		msg := dynamic.NewMessage(msgDesc)
+		if params.Value != "" {
+			if err := msg.UnmarshalJSON([]byte(params.Value)); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", msgDesc.GetFullyQualifiedName(), err)
+			}
+		}
+		encoded, err := msg.MarshalDeterministic()
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", msgDesc.GetFullyQualifiedName(), err)
+		}
+		params.EncodedValue = encoded
+		return nil
+	}
+
+	return fmt.Errorf("unsupported params type %q", params.Type)
+}
+
+func (e *paramsEncoder) messageDescriptor(msgType string) (*desc.MessageDescriptor, error) {
+	if e.files == nil {
+		files, err := desc.CreateFileDescriptors(e.pkg.ProtoFiles)
+		if err != nil {
+			return nil, fmt.Errorf("loading protobuf definitions: %w", err)
+		}
+		for _, file := range files {
+			e.files = append(e.files, file)
+		}
+	}
+
+	for _, file := range e.files {
+		if msgDesc := file.FindMessage(msgType); msgDesc != nil {
+			return msgDesc, nil
+		}
+	}
+	return nil, fmt.Errorf("protobuf message type %q not found in package", msgType)
+}
+
+// validateJSONParams checks that `value` is a JSON document matching `schema`.
+func validateJSONParams(value string, schema string) error {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return fmt.Errorf("invalid JSON value: %w", err)
+	}
+
+	compiled, err := parseJSONSchema([]byte(schema))
+	if err != nil {
+		return fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(value), root); err != nil {
+		return fmt.Errorf("invalid JSON value: %w", err)
+	}
+
+	issues := validateAgainstSchema(compiled, "", root)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, issue := range issues {
+		if issue.Path == "" {
+			messages = append(messages, issue.Message)
+			continue
+		}
+		messages = append(messages, issue.Path+": "+issue.Message)
+	}
+	return fmt.Errorf("value does not match JSON Schema: %s", strings.Join(messages, "; "))
+}
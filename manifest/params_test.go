@@ -0,0 +1,159 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/jhump/protoreflect/desc/protoparse"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestParseParamsType(t *testing.T) {
+	tests := []struct {
+		in                 string
+		expectedType       string
+		expectedSchemaFile string
+		expectedErr        bool
+	}{
+		{in: "string"},
+		{in: "proto:sf.test.Params", expectedType: "proto:sf.test.Params"},
+		{in: "json:./params.schema.json", expectedType: "json", expectedSchemaFile: "./params.schema.json"},
+		{in: "proto:", expectedErr: true},
+		{in: "json:", expectedErr: true},
+		{in: "int", expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			paramsType, schemaFile, err := parseParamsType(test.in)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedType, paramsType)
+			assert.Equal(t, test.expectedSchemaFile, schemaFile)
+		})
+	}
+}
+
+func TestApplyParams_Typed(t *testing.T) {
+	parser := protoparse.Parser{
+		Accessor: protoparse.FileContentsFromMap(map[string]string{
+			"params.proto": `syntax = "proto3";
+package sf.test;
+message Params {
+  string address = 1;
+}`,
+		}),
+	}
+	files, err := parser.ParseFiles("params.proto")
+	require.NoError(t, err)
+
+	paramsModule := func(name string, params *pbsubstreams.Module_Input_Params) *pbsubstreams.Module {
+		return &pbsubstreams.Module{
+			Name:   name,
+			Inputs: []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Params_{Params: params}}},
+		}
+	}
+
+	newPkg := func() *pbsubstreams.Package {
+		return &pbsubstreams.Package{
+			ProtoFiles: []*descriptorpb.FileDescriptorProto{files[0].AsFileDescriptorProto()},
+			Modules: &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{
+				paramsModule("map_string", &pbsubstreams.Module_Input_Params{}),
+				paramsModule("map_proto", &pbsubstreams.Module_Input_Params{Type: "proto:sf.test.Params"}),
+				paramsModule("map_json", &pbsubstreams.Module_Input_Params{
+					Type:       "json",
+					JsonSchema: `{"type": "object", "properties": {"min": {"type": "integer"}}, "required": ["min"]}`,
+				}),
+			}},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		pkg := newPkg()
+		require.NoError(t, ApplyParams(map[string]string{
+			"map_string": "anything",
+			"map_proto":  `{"address": "0x1234"}`,
+			"map_json":   `{"min": 10}`,
+		}, pkg))
+
+		mods := pkg.Modules.Modules
+		assert.Equal(t, []byte("anything"), mods[0].Inputs[0].GetParams().ModuleValue())
+
+		expected, err := proto.Marshal(wrapperspb.String("0x1234"))
+		require.NoError(t, err)
+		assert.Equal(t, expected, mods[1].Inputs[0].GetParams().EncodedValue, "field 1 of Params is encoded like the value of a StringValue")
+		assert.Equal(t, `{"address": "0x1234"}`, mods[1].Inputs[0].GetParams().Value)
+
+		assert.Equal(t, []byte(`{"min": 10}`), mods[2].Inputs[0].GetParams().ModuleValue())
+	})
+
+	tests := []struct {
+		name          string
+		params        map[string]string
+		expectedError string
+	}{
+		{
+			name:          "unknown proto field",
+			params:        map[string]string{"map_proto": `{"addr": "0x1234"}`},
+			expectedError: `param for module "map_proto": invalid value for sf.test.Params`,
+		},
+		{
+			name:          "not JSON",
+			params:        map[string]string{"map_json": `min=10`},
+			expectedError: `param for module "map_json": invalid JSON value`,
+		},
+		{
+			name:          "JSON not matching the schema",
+			params:        map[string]string{"map_json": `{"min": "ten"}`},
+			expectedError: `param for module "map_json": value does not match JSON Schema: min: expected integer, got string`,
+		},
+		{
+			name:          "JSON missing required field",
+			params:        map[string]string{"map_json": `{}`},
+			expectedError: `param for module "map_json": value does not match JSON Schema: missing required field "min"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ApplyParams(test.params, newPkg())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
+
+func TestModuleHash_TypedParams(t *testing.T) {
+	newModules := func(params *pbsubstreams.Module_Input_Params) *pbsubstreams.Modules {
+		return &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			Modules: []*pbsubstreams.Module{{
+				Name:   "map_a",
+				Kind:   &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}},
+				Inputs: []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Params_{Params: params}}},
+			}},
+		}
+	}
+
+	hash := func(modules *pbsubstreams.Modules) string {
+		graph, err := NewModuleGraph(modules.Modules)
+		require.NoError(t, err)
+		h, err := NewModuleHashes().HashModule(modules, modules.Modules[0], graph)
+		require.NoError(t, err)
+		return string(h)
+	}
+
+	untyped := hash(newModules(&pbsubstreams.Module_Input_Params{Value: "a"}))
+	typed := hash(newModules(&pbsubstreams.Module_Input_Params{Value: "a", Type: "proto:sf.test.Params", EncodedValue: []byte{0x0a, 0x01, 0x61}}))
+	reencoded := hash(newModules(&pbsubstreams.Module_Input_Params{Value: "a", Type: "proto:sf.test.Params", EncodedValue: []byte{0x0a, 0x01, 0x62}}))
+
+	assert.NotEqual(t, untyped, typed)
+	assert.NotEqual(t, typed, reencoded)
+}
@@ -3,6 +3,8 @@ package manifest
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,7 +27,6 @@ import (
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
-	yaml3 "gopkg.in/yaml.v3"
 )
 
 var remoteFetchTimeout = 5 * time.Minute
@@ -56,6 +57,19 @@ type Reader struct {
 
 	pkg *pbsubstreams.Package
 
+	// contentHash is the sha256 of the package content, used to pin imports in the lock file
+	contentHash string
+	// lock is the lock resolved while loading the imports of a local manifest
+	lock *Lock
+	// importedLock, set on the readers of imports, holds the nested imports recorded in
+	// the lock file of the importing manifest, verified instead of the import's own lock file
+	importedLock *Lock
+	// binaryDeduplication reports the binaries deduplicated while building the package
+	binaryDeduplication BinaryDeduplication
+	// importResolver is shared by the readers of a manifest and all its transitive imports
+	importResolver     *importResolver
+	ownsImportResolver bool
+
 	// cached values
 	protoDefinitions         []*desc.FileDescriptor
 	sinkConfigDynamicMessage *dynamic.Message
@@ -67,8 +81,12 @@ type Reader struct {
 	skipModuleOutputTypeValidation bool
 	skipPackageValidation          bool
 	overrideNetwork                string
-	overrideOutputModule           string
+	overrideOutputModules          []string
 	params                         map[string]string
+	updateLock                     bool
+	packageIndex                   PackageIndex
+	trustedKeys                    []ed25519.PublicKey
+	requireSignature               bool
 }
 
 func NewReader(input string, opts ...Option) (*Reader, error) {
@@ -91,8 +109,10 @@ func MustNewReader(input string, opts ...Option) *Reader {
 
 func newReader(input, workingDir string, opts ...Option) (*Reader, error) {
 	r := &Reader{
-		originalInput: input,
-		workingDir:    workingDir,
+		originalInput:    input,
+		workingDir:       workingDir,
+		trustedKeys:      TrustedKeys,
+		requireSignature: RequireSignature,
 	}
 
 	if err := r.resolveInputPath(); err != nil {
@@ -136,7 +156,7 @@ func (r *Reader) Read() (*PackageBundle, error) {
 	}
 
 	if pkg.Networks != nil {
-		importIncludedModules, err := dependentImportedModules(graph, r.overrideOutputModule)
+		importIncludedModules, err := dependentImportedModules(graph, r.overrideOutputModules)
 		if err != nil {
 			return nil, err
 		}
@@ -420,12 +440,9 @@ func (r *Reader) getPkg() (*pbsubstreams.Package, *Manifest, error) {
 	}
 
 	if strings.HasSuffix(r.currentInput, ".yaml") || strings.HasSuffix(r.currentInput, ".yml") {
-		manif := &Manifest{}
-		decoder := yaml3.NewDecoder(bytes.NewReader(r.currentData))
-		decoder.KnownFields(true)
-
-		if err := decoder.Decode(&manif); err != nil {
-			return nil, nil, fmt.Errorf("unable to unmarshal manifest: %w", err)
+		manif, err := decodeManifest(r.currentInput, r.currentData)
+		if err != nil {
+			return nil, nil, err
 		}
 
 		pkg, err := r.newPkgFromManifest(manif)
@@ -433,6 +450,14 @@ func (r *Reader) getPkg() (*pbsubstreams.Package, *Manifest, error) {
 			return nil, nil, fmt.Errorf("unable to convert manifest to package: %w", err)
 		}
 
+		// the manifest alone does not capture the content of its binaries and protobuf files,
+		// so we hash the package it produced instead
+		cnt, err := proto.MarshalOptions{Deterministic: true}.Marshal(pkg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to marshal package: %w", err)
+		}
+		r.contentHash = sha256Hex(cnt)
+
 		return pkg, manif, nil
 	}
 
@@ -442,15 +467,22 @@ func (r *Reader) getPkg() (*pbsubstreams.Package, *Manifest, error) {
 		return nil, nil, fmt.Errorf("unable to unmarshal package: %w", err)
 	}
 
+	if err := verifyPackageSignature(r.currentData, pkg.Signature, r.trustedKeys, r.requireSignature); err != nil {
+		return nil, nil, fmt.Errorf("package %q: %w", r.currentInput, err)
+	}
+	r.contentHash = sha256Hex(r.currentData)
+
 	return pkg, nil, nil
 }
 
-func dependentImportedModules(graph *ModuleGraph, outputModule string) (map[string]bool, error) {
+func dependentImportedModules(graph *ModuleGraph, outputModules []string) (map[string]bool, error) {
 	out := make(map[string]bool)
 
 	outputModulesToCheck := make(map[string]bool)
-	if outputModule != "" {
-		outputModulesToCheck[outputModule] = true
+	if len(outputModules) != 0 {
+		for _, outputModule := range outputModules {
+			outputModulesToCheck[outputModule] = true
+		}
 	} else {
 		for _, mod := range graph.Modules() {
 			if !strings.Contains(mod, ":") {
@@ -547,11 +579,30 @@ func validatePackage(pkg *pbsubstreams.Package, skipModuleOutputTypeValidation b
 
 func (r *Reader) newPkgFromManifest(manif *Manifest) (*pbsubstreams.Package, error) {
 	converter := newManifestConverter(r.currentInput, r.skipSourceCodeImportValidation)
+	converter.resolver = r.importResolver
+	converter.trustedKeys = r.trustedKeys
+	converter.requireSignature = r.requireSignature
+	converter.network = r.overrideNetwork
+	converter.updateLock = r.updateLock
+	if !r.updateLock {
+		if r.importedLock != nil {
+			converter.lock = r.importedLock
+		} else if !r.IsRemotePackage(r.currentInput) {
+			lock, err := ReadLockFile(LockFilePath(r.currentInput))
+			if err != nil {
+				return nil, err
+			}
+			converter.lock = lock
+		}
+	}
+
 	pkg, descriptors, dynMessage, err := converter.Convert(manif)
 	if err != nil {
 		return nil, err
 	}
 	r.sinkConfigDynamicMessage = dynMessage
+	r.lock = converter.resolvedLock
+	r.binaryDeduplication = converter.binaryDeduplication
 
 	if r.collectProtoDefinitionsFunc != nil {
 		r.collectProtoDefinitionsFunc(descriptors)
@@ -570,13 +621,49 @@ func (r *Reader) resolvePkg() (*pbsubstreams.Package, *Manifest, error) {
 		return nil, nil, err
 	}
 
-	pkg, manif, err := r.getPkg()
-	if err != nil {
-		return nil, nil, fmt.Errorf("unable to get package: %w", err)
+	if r.importResolver == nil {
+		r.importResolver = newImportResolver(PackageIndexURL, r.packageIndex)
+		r.ownsImportResolver = true
+	}
+
+	for attempt := 1; ; attempt++ {
+		if r.ownsImportResolver {
+			r.importResolver.reset()
+		}
+
+		pkg, manif, err := r.getPkg()
+		if err != nil {
+			if r.ownsImportResolver && errors.Is(err, errRestartResolution) && attempt < maxResolutionAttempts {
+				continue
+			}
+			return nil, nil, fmt.Errorf("unable to get package: %w", err)
+		}
+
+		r.pkg = pkg
+		return pkg, manif, nil
+	}
+}
+
+// ResolvedImports returns how the versioned imports (`name@^0.2`) of the manifest and
+// all its transitive imports were resolved, once it has been read.
+func (r *Reader) ResolvedImports() []*ResolvedImport {
+	if !r.ownsImportResolver {
+		return nil
 	}
+	return r.importResolver.Resolved()
+}
+
+// BinaryDeduplication reports the binaries that were identical to another binary of the
+// package, including the ones of its imports, and were therefore stored only once.
+func (r *Reader) BinaryDeduplication() BinaryDeduplication {
+	return r.binaryDeduplication
+}
 
-	r.pkg = pkg
-	return pkg, manif, nil
+// ResolvedLock returns the lock computed from the imports of a manifest once it has
+// been read, to be written to the manifest's lock file. It is nil when the input is
+// not a manifest.
+func (r *Reader) ResolvedLock() *Lock {
+	return r.lock
 }
 
 // IsRemotePackage determines if reader's input to read the manifest is a remote file accessible over
@@ -737,6 +824,10 @@ func ValidateModules(mods *pbsubstreams.Modules) error {
 			return fmt.Errorf("limit of 30 inputs for a given module (%q) reached", mod.Name)
 		}
 
+		if mod.EndBlock != 0 && mod.EndBlock <= mod.InitialBlock {
+			return fmt.Errorf("module %q: end block %d must be greater than its initial block %d", mod.Name, mod.EndBlock, mod.InitialBlock)
+		}
+
 		err := checkValidBlockFilter(mod, mapModules)
 		if err != nil {
 			return fmt.Errorf("checking block filter for module %q: %w", mod.Name, err)
@@ -764,8 +855,9 @@ func LoadManifestFile(inputPath, workingDir string) (*Manifest, error) {
 
 	m.Workdir = path.Dir(absoluteManifestPath)
 
+	issues := newIssueCollector(m)
 	if m.SpecVersion != "v0.1.0" {
-		return nil, fmt.Errorf("invalid 'specVersion', must be v0.1.0")
+		issues.add([]interface{}{"specVersion"}, "invalid 'specVersion', must be v0.1.0")
 	}
 
 	// Allow environment variables in `imports` element
@@ -781,40 +873,69 @@ func LoadManifestFile(inputPath, workingDir string) (*Manifest, error) {
 	// TODO: put some limits on the NUMBER of modules (max 50 ?)
 	// TODO: put a limit on the SIZE of the WASM payload (max 10MB per binary?)
 
-	for _, s := range m.Modules {
+	for modIdx, s := range m.Modules {
 		// TODO: let's make sure this is also checked when received in Protobuf in a remote request.
 
 		switch s.Kind {
 		case ModuleKindMap:
 			if s.Output.Type == "" {
-				return nil, fmt.Errorf("stream %q: missing 'output.type' for kind 'map'", s.Name)
+				issues.add([]interface{}{"modules", modIdx, "output"}, "stream %q: missing 'output.type' for kind 'map'", s.Name)
 			}
 		case ModuleKindStore:
 			if err := validateStoreBuilder(s); err != nil {
-				return nil, fmt.Errorf("stream %q: %w", s.Name, err)
+				issues.add([]interface{}{"modules", modIdx, "updatePolicy"}, "stream %q: %s", s.Name, err)
 			}
 
 		default:
-			return nil, fmt.Errorf("stream %q: invalid kind %q", s.Name, s.Kind)
+			issues.add([]interface{}{"modules", modIdx, "kind"}, "stream %q: invalid kind %q", s.Name, s.Kind)
 		}
 		for idx, input := range s.Inputs {
 			if err := input.parse(); err != nil {
-				return nil, fmt.Errorf("module %q: invalid input [%d]: %w", s.Name, idx, err)
+				issues.add([]interface{}{"modules", modIdx, "inputs", idx}, "module %q: invalid input [%d]: %s", s.Name, idx, err)
 			}
 		}
 	}
 
+	if err := issues.err(); err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
 
 // loop through the Manifest, and get the `imports` statements,
 // pull the Package files from Disk, and merge them into this one
-func loadImports(pkg *pbsubstreams.Package, manif *Manifest) error {
+func (r *manifestConverter) loadImports(pkg *pbsubstreams.Package, manif *Manifest) error {
+	r.resolvedLock = NewLock()
+
 	for _, kv := range manif.Imports {
 		importName := kv[0]
 		importPath := manif.resolvePath(kv[1])
 
-		subpkgReader, err := NewReader(importPath)
+		subpkgReaderOptions := []Option{withImportResolver(r.resolver), WithSignatureVerification(r.trustedKeys, r.requireSignature)}
+		if r.updateLock {
+			subpkgReaderOptions = append(subpkgReaderOptions, WithUpdateLock())
+		}
+
+		var lockedVersion string
+		if r.lock != nil {
+			if locked := r.lock.find(importName); locked != nil {
+				lockedVersion = locked.Version
+				subpkgReaderOptions = append(subpkgReaderOptions, withImportedLock(&Lock{Version: lockVersion, Imports: locked.Imports}))
+			}
+		}
+
+		var resolvedVersion string
+		if name, constraint, ok := parseVersionedImport(kv[1]); ok {
+			resolved, err := r.resolver.resolve(name, constraint, r.inputPath, lockedVersion)
+			if err != nil {
+				return fmt.Errorf("importing %q: %w", kv[1], err)
+			}
+			importPath = resolved.URL
+			resolvedVersion = resolved.Version
+		}
+
+		subpkgReader, err := NewReader(importPath, subpkgReaderOptions...)
 		if err != nil {
 			return fmt.Errorf("importing %q: %w", importPath, err)
 		}
@@ -828,9 +949,23 @@ func loadImports(pkg *pbsubstreams.Package, manif *Manifest) error {
 			return fmt.Errorf("importing %q: no package found", importPath)
 		}
 
+		if r.lock != nil {
+			if err := r.lock.verifyImport(importName, kv[1], subpkgReader.contentHash); err != nil {
+				return err
+			}
+		}
+
+		locked, err := newLockedImport(importName, kv[1], subpkgReader, pkgBundle)
+		if err != nil {
+			return fmt.Errorf("importing %q: %w", importPath, err)
+		}
+		locked.Version = resolvedVersion
+		r.resolvedLock.Imports = append(r.resolvedLock.Imports, locked)
+
 		subpkg := pkgBundle.Package
 		prefixModules(subpkg.Modules.Modules, importName)
-		reindexAndMergePackage(subpkg, pkg)
+		r.binaryDeduplication.add(subpkgReader.BinaryDeduplication())
+		r.binaryDeduplication.add(reindexAndMergePackage(subpkg, pkg))
 		mergeProtoFiles(subpkg, pkg)
 		mergeNetworks(subpkg, pkg, importName)
 	}
@@ -896,22 +1031,30 @@ func prefixModules(mods []*pbsubstreams.Module, prefix string) {
 	}
 }
 
-// mergeAndReindexPackages consumes the `src` Package into `dest`, and
-// modifies `src`.
-func reindexAndMergePackage(src, dest *pbsubstreams.Package) {
+// reindexAndMergePackage consumes the `src` Package into `dest`, and
+// modifies `src`. Binaries of `src` identical to one already in `dest` (or
+// repeated within `src`) are not copied, the modules using them are pointed
+// to the existing one instead.
+func reindexAndMergePackage(src, dest *pbsubstreams.Package) BinaryDeduplication {
 	newBasePackageIndex := len(dest.PackageMeta)
-	newBaseBinariesIndex := len(dest.Modules.Binaries)
+
+	binaries := newBinarySet(dest.Modules)
+	binaryIndexes := make([]uint32, len(src.Modules.Binaries))
+	for idx, binary := range src.Modules.Binaries {
+		binaryIndexes[idx] = binaries.add(binary)
+	}
 
 	for _, modMeta := range src.ModuleMeta {
 		modMeta.PackageIndex += uint64(newBasePackageIndex)
 	}
 	for _, mod := range src.Modules.Modules {
-		mod.BinaryIndex += uint32(newBaseBinariesIndex)
+		mod.BinaryIndex = binaryIndexes[mod.BinaryIndex]
 	}
 	dest.Modules.Modules = append(dest.Modules.Modules, src.Modules.Modules...)
-	dest.Modules.Binaries = append(dest.Modules.Binaries, src.Modules.Binaries...)
 	dest.ModuleMeta = append(dest.ModuleMeta, src.ModuleMeta...)
 	dest.PackageMeta = append(dest.PackageMeta, src.PackageMeta...)
+
+	return binaries.stats
 }
 
 func mergeProtoFiles(src, dest *pbsubstreams.Package) {
@@ -1,6 +1,10 @@
 package manifest
 
-import "github.com/jhump/protoreflect/desc"
+import (
+	"crypto/ed25519"
+
+	"github.com/jhump/protoreflect/desc"
+)
 
 type Option func(r *Reader) *Reader
 
@@ -34,7 +38,19 @@ func WithOverrideNetwork(network string) Option {
 
 func WithOverrideOutputModule(outputModule string) Option {
 	return func(r *Reader) *Reader {
-		r.overrideOutputModule = outputModule
+		r.overrideOutputModules = nil
+		if outputModule != "" {
+			r.overrideOutputModules = []string{outputModule}
+		}
+		return r
+	}
+}
+
+// WithOverrideOutputModules is like WithOverrideOutputModule, for the requests streaming
+// the outputs of multiple modules.
+func WithOverrideOutputModules(outputModules ...string) Option {
+	return func(r *Reader) *Reader {
+		r.overrideOutputModules = outputModules
 		return r
 	}
 }
@@ -46,6 +62,51 @@ func WithParams(params map[string]string) Option {
 	}
 }
 
+// WithUpdateLock disables the verification of imports against the manifest's lock file,
+// so that the lock returned by `Reader.ResolvedLock` can be used to refresh it.
+func WithUpdateLock() Option {
+	return func(r *Reader) *Reader {
+		r.updateLock = true
+		return r
+	}
+}
+
+// WithPackageIndex sets the index used to resolve versioned imports (`name@^0.2`),
+// instead of the one found at `PackageIndexURL`.
+func WithPackageIndex(index PackageIndex) Option {
+	return func(r *Reader) *Reader {
+		r.packageIndex = index
+		return r
+	}
+}
+
+// WithSignatureVerification sets the public keys trusted to sign packages, and whether
+// packages not signed by one of them are refused, instead of `TrustedKeys` and
+// `RequireSignature`. It applies to the imports of the manifest as well.
+func WithSignatureVerification(trustedKeys []ed25519.PublicKey, required bool) Option {
+	return func(r *Reader) *Reader {
+		r.trustedKeys = trustedKeys
+		r.requireSignature = required
+		return r
+	}
+}
+
+func withImportResolver(resolver *importResolver) Option {
+	return func(r *Reader) *Reader {
+		r.importResolver = resolver
+		return r
+	}
+}
+
+// withImportedLock sets the lock verified against the imports of an imported package, as
+// recorded in the lock file of the manifest importing it.
+func withImportedLock(lock *Lock) Option {
+	return func(r *Reader) *Reader {
+		r.importedLock = lock
+		return r
+	}
+}
+
 func WithCollectProtoDefinitions(f func(protoDefinitions []*desc.FileDescriptor)) Option {
 	return func(r *Reader) *Reader {
 		r.collectProtoDefinitionsFunc = f
@@ -613,8 +613,8 @@ func Test_dependentImportedModules(t *testing.T) {
 	}
 
 	type args struct {
-		graph        *ModuleGraph
-		outputModule string
+		graph         *ModuleGraph
+		outputModules []string
 	}
 
 	tests := []struct {
@@ -626,16 +626,16 @@ func Test_dependentImportedModules(t *testing.T) {
 		{
 			name: "independant",
 			args: args{
-				graph:        mustNewModuleGraph(testModules),
-				outputModule: "mod_independant",
+				graph:         mustNewModuleGraph(testModules),
+				outputModules: []string{"mod_independant"},
 			},
 			want: map[string]bool{},
 		},
 		{
 			name: "dep_on_map",
 			args: args{
-				graph:        mustNewModuleGraph(testModules),
-				outputModule: "mod_dep_on_mapmod",
+				graph:         mustNewModuleGraph(testModules),
+				outputModules: []string{"mod_dep_on_mapmod"},
 			},
 			want: map[string]bool{
 				"lib:mapmod": true,
@@ -644,18 +644,28 @@ func Test_dependentImportedModules(t *testing.T) {
 		{
 			name: "dep_on_two",
 			args: args{
-				graph:        mustNewModuleGraph(testModules),
-				outputModule: "mod_dep_on_two_mods",
+				graph:         mustNewModuleGraph(testModules),
+				outputModules: []string{"mod_dep_on_two_mods"},
 			},
 			want: map[string]bool{
 				"lib:mapmod":   true,
 				"lib:storemod": true,
 			},
 		},
+		{
+			name: "multiple",
+			args: args{
+				graph:         mustNewModuleGraph(testModules),
+				outputModules: []string{"mod_independant", "mod_dep_on_mapmod"},
+			},
+			want: map[string]bool{
+				"lib:mapmod": true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := dependentImportedModules(tt.args.graph, tt.args.outputModule)
+			got, err := dependentImportedModules(tt.args.graph, tt.args.outputModules)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("dependentImportedModules() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -672,3 +682,75 @@ func mustNewModuleGraph(modules []*pbsubstreams.Module) *ModuleGraph {
 	}
 	return g
 }
+
+func TestReindexAndMergePackage(t *testing.T) {
+	binary := func(typ, content string) *pbsubstreams.Binary {
+		return &pbsubstreams.Binary{Type: typ, Content: []byte(content)}
+	}
+
+	dest := &pbsubstreams.Package{
+		PackageMeta: []*pbsubstreams.PackageMetadata{{Name: "main"}},
+		ModuleMeta:  []*pbsubstreams.ModuleMetadata{{PackageIndex: 0}},
+		Modules: &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{binary("wasm/rust-v1", "shared")},
+			Modules:  []*pbsubstreams.Module{{Name: "main_mod", BinaryIndex: 0}},
+		},
+	}
+	src := &pbsubstreams.Package{
+		PackageMeta: []*pbsubstreams.PackageMetadata{{Name: "lib"}},
+		ModuleMeta:  []*pbsubstreams.ModuleMetadata{{PackageIndex: 0}, {PackageIndex: 0}, {PackageIndex: 0}, {PackageIndex: 0}},
+		Modules: &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{
+				binary("wasm/rust-v1", "own"),
+				binary("wasm/rust-v1", "shared"),
+				binary("wasm/rust-v1+wasm-bindgen-shims", "shared"),
+				binary("wasm/rust-v1", "own"),
+			},
+			Modules: []*pbsubstreams.Module{
+				{Name: "lib:a", BinaryIndex: 0},
+				{Name: "lib:b", BinaryIndex: 1},
+				{Name: "lib:c", BinaryIndex: 2},
+				{Name: "lib:d", BinaryIndex: 3},
+			},
+		},
+	}
+
+	stats := reindexAndMergePackage(src, dest)
+
+	assert.Equal(t, BinaryDeduplication{Count: 2, SavedBytes: uint64(len("shared") + len("own"))}, stats)
+	require.Len(t, dest.Modules.Binaries, 3)
+	assert.Equal(t, "shared", string(dest.Modules.Binaries[0].Content))
+	assert.Equal(t, "own", string(dest.Modules.Binaries[1].Content))
+	assert.Equal(t, "wasm/rust-v1+wasm-bindgen-shims", dest.Modules.Binaries[2].Type)
+
+	var indexes []uint32
+	for _, mod := range dest.Modules.Modules {
+		indexes = append(indexes, mod.BinaryIndex)
+	}
+	assert.Equal(t, []uint32{0, 1, 0, 2, 1}, indexes)
+
+	for _, meta := range dest.ModuleMeta[1:] {
+		assert.Equal(t, uint64(1), meta.PackageIndex)
+	}
+}
+
+func TestReindexAndMergePackage_EmptyBinariesKept(t *testing.T) {
+	dest := &pbsubstreams.Package{
+		Modules: &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1"}},
+			Modules:  []*pbsubstreams.Module{{Name: "main_mod", BinaryIndex: 0}},
+		},
+	}
+	src := &pbsubstreams.Package{
+		Modules: &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1"}},
+			Modules:  []*pbsubstreams.Module{{Name: "lib:a", BinaryIndex: 0}},
+		},
+	}
+
+	stats := reindexAndMergePackage(src, dest)
+
+	assert.Equal(t, BinaryDeduplication{}, stats)
+	require.Len(t, dest.Modules.Binaries, 2)
+	assert.Equal(t, uint32(1), dest.Modules.Modules[1].BinaryIndex)
+}
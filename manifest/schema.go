@@ -0,0 +1,515 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"github.com/streamingfast/substreams/schemas"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonSchema is a compiled JSON Schema, along with the document it was compiled from
+// to look up the properties it declares when suggesting fixes.
+type jsonSchema struct {
+	compiled *jsonschema.Schema
+	doc      interface{}
+}
+
+const jsonSchemaURL = "urn:substreams:schema.json"
+
+var loadManifestSchema = sync.OnceValues(func() (*jsonSchema, error) {
+	schema, err := parseJSONSchema(schemas.ManifestSchema)
+	if err != nil {
+		return nil, fmt.Errorf("decoding manifest schema: %w", err)
+	}
+	return schema, nil
+})
+
+// parseJSONSchema compiles `content`, a JSON Schema which may only reference itself.
+func parseJSONSchema(content []byte) (*jsonSchema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	// no loader, so that a schema cannot reach the filesystem or the network through `$ref`
+	compiler.UseLoader(jsonschema.SchemeURLLoader{})
+	if err := compiler.AddResource(jsonSchemaURL, doc); err != nil {
+		return nil, err
+	}
+
+	compiled, err := compiler.Compile(jsonSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonSchema{compiled: compiled, doc: doc}, nil
+}
+
+// validateManifestSchema validates the YAML document `root`, read from `file`, against
+// the manifest JSON Schema and returns every issue found.
+func validateManifestSchema(file string, root *yaml.Node) (ManifestIssues, error) {
+	schema, err := loadManifestSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	return validateAgainstSchema(schema, file, root), nil
+}
+
+// validateAgainstSchema validates the YAML (or JSON) document `root`, read from `file`,
+// against `schema` and returns every issue found, ordered by position.
+func validateAgainstSchema(schema *jsonSchema, file string, root *yaml.Node) ManifestIssues {
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil
+		}
+		root = root.Content[0]
+	}
+
+	err := schema.compiled.Validate(yamlToJSONValue(root))
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		if err != nil {
+			return ManifestIssues{{File: file, Message: err.Error()}}
+		}
+		return nil
+	}
+
+	r := &schemaIssueReporter{schema: schema, file: file, root: root, seen: map[string]bool{}}
+	r.report(validationErr, nil)
+	r.addMisplaced()
+
+	sort.SliceStable(r.issues, func(i, j int) bool {
+		if r.issues[i].Line != r.issues[j].Line {
+			return r.issues[i].Line < r.issues[j].Line
+		}
+		return r.issues[i].Column < r.issues[j].Column
+	})
+	return r.issues
+}
+
+// yamlToJSONValue converts `node` to the value `encoding/json` would decode from its JSON form.
+func yamlToJSONValue(node *yaml.Node) interface{} {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlToJSONValue(node.Alias)
+
+	case yaml.MappingNode:
+		out := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			out[node.Content[i].Value] = yamlToJSONValue(node.Content[i+1])
+		}
+		return out
+
+	case yaml.SequenceNode:
+		out := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			out = append(out, yamlToJSONValue(item))
+		}
+		return out
+	}
+
+	switch node.Tag {
+	case "!!null":
+		return nil
+	case "!!bool":
+		var value bool
+		if node.Decode(&value) == nil {
+			return value
+		}
+	case "!!int":
+		var value int64
+		if node.Decode(&value) == nil {
+			return value
+		}
+	case "!!float":
+		var value float64
+		if node.Decode(&value) == nil && value-value == 0 {
+			return value
+		}
+	}
+	return node.Value
+}
+
+type schemaIssueReporter struct {
+	schema *jsonSchema
+	file   string
+	root   *yaml.Node
+	seen   map[string]bool
+	issues ManifestIssues
+
+	// misplaced holds the locations of the fields rejected although declared by the schema
+	misplaced [][]string
+}
+
+var schemaMessagePrinter = message.NewPrinter(language.English)
+
+// report records the issues of the leaves of `err`, the errors of its branches only
+// grouping them. `parentLocation` is the instance location of the error holding `err`.
+func (r *schemaIssueReporter) report(err *jsonschema.ValidationError, parentLocation []string) {
+	switch k := err.ErrorKind.(type) {
+	case *kind.PropertyNames:
+		// the error is not located in the document, only its closest parent is known
+		r.addAtKey(r.findKey(parentLocation, k.Property), "invalid key %q", k.Property)
+		return
+
+	case *kind.AdditionalProperties:
+		for _, property := range k.Properties {
+			r.addUnknownField(append(append([]string{}, err.InstanceLocation...), property), err.SchemaURL)
+		}
+		return
+	}
+
+	if len(err.Causes) != 0 {
+		for _, cause := range err.Causes {
+			r.report(cause, err.InstanceLocation)
+		}
+		return
+	}
+
+	node := r.nodeAt(err.InstanceLocation)
+	switch k := err.ErrorKind.(type) {
+	case *kind.Type:
+		if yamlAcceptsType(node, k.Want) {
+			return
+		}
+		r.add(err.InstanceLocation, "expected %s, got %s", strings.Join(k.Want, " or "), k.Got)
+
+	case *kind.Enum:
+		var options []string
+		for _, option := range k.Want {
+			options = append(options, fmt.Sprint(option))
+		}
+		r.add(err.InstanceLocation, "invalid value %q, must be one of: %s", node.Value, strings.Join(options, ", "))
+
+	case *kind.Const:
+		r.add(err.InstanceLocation, "invalid value %q, must be %q", node.Value, fmt.Sprint(k.Want))
+
+	case *kind.Pattern:
+		r.add(err.InstanceLocation, "invalid value %q, must match %q", k.Got, k.Want)
+
+	case *kind.Minimum:
+		r.add(err.InstanceLocation, "invalid value %s, must be at least %s", node.Value, k.Want.RatString())
+
+	case *kind.Required:
+		for _, missing := range k.Missing {
+			r.add(err.InstanceLocation, "missing required field %q", missing)
+		}
+
+	case *kind.MinProperties:
+		r.add(err.InstanceLocation, "must have at least %d field(s)", k.Want)
+
+	case *kind.MaxProperties:
+		r.add(err.InstanceLocation, "must have at most %d field(s)", k.Want)
+
+	case *kind.MinItems:
+		r.add(err.InstanceLocation, "must have at least %d item(s)", k.Want)
+
+	case *kind.OneOf:
+		if len(k.Subschemas) == 0 {
+			r.add(err.InstanceLocation, "must match one of the alternatives")
+			return
+		}
+		r.add(err.InstanceLocation, "must match only one of the alternatives, matches #%d and #%d", k.Subschemas[0]+1, k.Subschemas[1]+1)
+
+	case *kind.FalseSchema:
+		if r.rejectsProperty(err) {
+			r.addUnknownField(err.InstanceLocation, err.SchemaURL)
+			return
+		}
+		r.add(err.InstanceLocation, "not allowed here")
+
+	default:
+		r.add(err.InstanceLocation, "%s", err.ErrorKind.LocalizedString(schemaMessagePrinter))
+	}
+}
+
+// rejectsProperty reports if `err` is the `false` schema of an `additionalProperties` or
+// `unevaluatedProperties` keyword rejecting a field of a mapping.
+func (r *schemaIssueReporter) rejectsProperty(err *jsonschema.ValidationError) bool {
+	if !strings.HasSuffix(err.SchemaURL, "/additionalProperties") && !strings.HasSuffix(err.SchemaURL, "/unevaluatedProperties") {
+		return false
+	}
+	if len(err.InstanceLocation) == 0 {
+		return false
+	}
+	return r.nodeAt(err.InstanceLocation[:len(err.InstanceLocation)-1]).Kind == yaml.MappingNode
+}
+
+// addUnknownField records the field at `location` as rejected by the schema of its mapping,
+// `schemaURL` being the location of that schema or of its rejecting keyword.
+func (r *schemaIssueReporter) addUnknownField(location []string, schemaURL string) {
+	if strings.HasSuffix(schemaURL, "Properties") {
+		schemaURL = schemaURL[:strings.LastIndex(schemaURL, "/")]
+	}
+
+	property := location[len(location)-1]
+	suggestion := closestProperty(property, r.schema.declaredProperties(schemaURL))
+	switch suggestion {
+	case "":
+		r.addAtKey(location, "unknown field %q", property)
+	case property:
+		// declared by a conditional subschema which does not apply, or which failed
+		r.misplaced = append(r.misplaced, location)
+	default:
+		r.addAtKey(location, "unknown field %q, did you mean %q?", property, suggestion)
+	}
+}
+
+// addMisplaced records the fields declared by a conditional subschema which were rejected,
+// unless their mapping has other issues: a failing subschema does not evaluate any field,
+// so its fields are rejected as well and reporting them would only be noise.
+func (r *schemaIssueReporter) addMisplaced() {
+	for _, location := range r.misplaced {
+		_, _, parentPath := locateSchemaInstance(r.root, location[:len(location)-1])
+
+		hasIssues := false
+		for _, issue := range r.issues {
+			if parentPath == "" || issue.Path == parentPath || strings.HasPrefix(issue.Path, parentPath+".") || strings.HasPrefix(issue.Path, parentPath+"[") {
+				hasIssues = true
+				break
+			}
+		}
+		if !hasIssues {
+			r.addAtKey(location, "field %q is not allowed here", location[len(location)-1])
+		}
+	}
+}
+
+func (r *schemaIssueReporter) add(location []string, format string, args ...interface{}) {
+	_, node, path := locateSchemaInstance(r.root, location)
+	r.record(node, path, fmt.Sprintf(format, args...))
+}
+
+// addAtKey records an issue located at the key of the mapping field at `location`.
+func (r *schemaIssueReporter) addAtKey(location []string, format string, args ...interface{}) {
+	key, node, path := locateSchemaInstance(r.root, location)
+	if key != nil {
+		node = key
+	}
+	r.record(node, path, fmt.Sprintf(format, args...))
+}
+
+func (r *schemaIssueReporter) record(node *yaml.Node, path string, message string) {
+	issue := &ManifestIssue{
+		File:    r.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Path:    path,
+		Message: message,
+	}
+
+	// the same issue is found again when several subschemas apply to a value
+	if id := issue.Error(); !r.seen[id] {
+		r.seen[id] = true
+		r.issues = append(r.issues, issue)
+	}
+}
+
+// findKey returns the location of the shallowest mapping field named `key` under `location`.
+func (r *schemaIssueReporter) findKey(location []string, key string) []string {
+	type candidate struct {
+		node     *yaml.Node
+		location []string
+	}
+
+	queue := []candidate{{r.nodeAt(location), location}}
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		node := current.node
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		for i := 0; i < len(node.Content); i++ {
+			token := strconv.Itoa(i)
+			if node.Kind == yaml.MappingNode {
+				if i%2 == 0 {
+					continue
+				}
+				token = node.Content[i-1].Value
+				if token == key {
+					return append(append([]string{}, current.location...), token)
+				}
+			}
+			queue = append(queue, candidate{node.Content[i], append(append([]string{}, current.location...), token)})
+		}
+	}
+	return location
+}
+
+func (r *schemaIssueReporter) nodeAt(location []string) *yaml.Node {
+	_, node, _ := locateSchemaInstance(r.root, location)
+	return node
+}
+
+// locateSchemaInstance returns the node at `location`, a JSON pointer split in its tokens,
+// its key when it is the value of a mapping field and its path in the `modules[0].kind` form.
+// When the exact node does not exist, the closest parent is returned.
+func locateSchemaInstance(root *yaml.Node, location []string) (key *yaml.Node, node *yaml.Node, path string) {
+	node = root
+	for _, token := range location {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		found := false
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content) && !found; i += 2 {
+				if node.Content[i].Value == token {
+					key, node, found = node.Content[i], node.Content[i+1], true
+					path = joinSchemaPath(path, token)
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && i < len(node.Content) {
+				key, node, found = nil, node.Content[i], true
+				path = fmt.Sprintf("%s[%d]", path, i)
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return key, node, path
+}
+
+func joinSchemaPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// yamlAcceptsType reports if the manifest decoder accepts `node` where one of `types` is
+// expected, when the JSON Schema does not.
+func yamlAcceptsType(node *yaml.Node, types []string) bool {
+	// like the manifest decoder, an empty value stands for the zero value of any type
+	if node.Tag == "!!null" {
+		return true
+	}
+
+	// like the manifest decoder, accept any scalar where a string is expected
+	for _, expected := range types {
+		if expected == "string" && node.Kind == yaml.ScalarNode {
+			return true
+		}
+	}
+	return false
+}
+
+// declaredProperties returns the properties declared by the subschema at `schemaURL`,
+// an absolute location within the schema, along with those of its conditional subschemas.
+func (s *jsonSchema) declaredProperties(schemaURL string) []string {
+	_, fragment, _ := strings.Cut(schemaURL, "#")
+
+	var properties []string
+	var collect func(value interface{}, depth int)
+	collect = func(value interface{}, depth int) {
+		object, ok := value.(map[string]interface{})
+		if !ok || depth > 8 {
+			return
+		}
+
+		if declared, ok := object["properties"].(map[string]interface{}); ok {
+			for name := range declared {
+				properties = append(properties, name)
+			}
+		}
+		if ref, ok := object["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			collect(s.resolvePointer(ref[1:]), depth+1)
+		}
+		for _, keyword := range []string{"then", "else"} {
+			collect(object[keyword], depth+1)
+		}
+		for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+			subschemas, _ := object[keyword].([]interface{})
+			for _, subschema := range subschemas {
+				collect(subschema, depth+1)
+			}
+		}
+	}
+	collect(s.resolvePointer(fragment), 0)
+
+	return properties
+}
+
+// resolvePointer returns the value at `pointer`, a URL-encoded JSON pointer, in the schema document.
+func (s *jsonSchema) resolvePointer(pointer string) interface{} {
+	value := s.doc
+	if pointer == "" {
+		return value
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i >= len(v) {
+				return nil
+			}
+			value = v[i]
+		default:
+			return nil
+		}
+	}
+	return value
+}
+
+// closestProperty returns the known property closest to `name`, when it is likely a typo.
+func closestProperty(name string, properties []string) string {
+	candidates := append([]string{}, properties...)
+	sort.Strings(candidates)
+
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if d := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
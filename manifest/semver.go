@@ -0,0 +1,153 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+var fullVersionRegex = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)(-[0-9A-Za-z.-]+)?$`)
+var partialVersionRegex = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(-[0-9A-Za-z.-]+)?$`)
+
+// versionConstraint is a semver range, as found in versioned imports (`name@^0.2`).
+// Supported forms are exact versions (`0.2.1`, `=0.2.1`), caret (`^0.2`) and
+// tilde (`~0.2.1`) ranges, comparisons (`>=0.2.0`, `<0.4`), partial versions
+// (`0.2` matching any `0.2.x`) and `*`. Space or comma separated terms must all
+// match. Pre-release versions are only matched by an exact constraint.
+type versionConstraint struct {
+	raw    string
+	bounds []versionBound
+	exact  bool
+}
+
+type versionBound struct {
+	op      string
+	version string
+}
+
+func parseVersionConstraint(in string) (*versionConstraint, error) {
+	c := &versionConstraint{raw: in}
+
+	terms := strings.FieldsFunc(in, func(r rune) bool { return r == ' ' || r == ',' })
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+
+	for _, term := range terms {
+		if term == "*" || term == "x" {
+			continue
+		}
+
+		op := ""
+		for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+			if strings.HasPrefix(term, candidate) {
+				op = candidate
+				term = strings.TrimPrefix(term, candidate)
+				break
+			}
+		}
+
+		parts := partialVersionRegex.FindStringSubmatch(term)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid version %q in constraint %q", term, in)
+		}
+		major, _ := strconv.Atoi(parts[1])
+		minor, _ := strconv.Atoi(parts[2])
+		patch, _ := strconv.Atoi(parts[3])
+		hasMinor, hasPatch, prerelease := parts[2] != "", parts[3] != "", parts[4]
+		if prerelease != "" && !hasPatch {
+			return nil, fmt.Errorf("invalid version %q in constraint %q: pre-release requires a full version", term, in)
+		}
+
+		lower := fmt.Sprintf("v%d.%d.%d%s", major, minor, patch, prerelease)
+		var upper string
+		switch {
+		case !hasMinor:
+			upper = fmt.Sprintf("v%d.0.0", major+1)
+		case op == "^" && major == 0 && (minor != 0 || !hasPatch):
+			upper = fmt.Sprintf("v0.%d.0", minor+1)
+		case op == "^" && major == 0:
+			upper = fmt.Sprintf("v0.0.%d", patch+1)
+		case op == "^":
+			upper = fmt.Sprintf("v%d.0.0", major+1)
+		case !hasPatch || op == "~":
+			upper = fmt.Sprintf("v%d.%d.0", major, minor+1)
+		}
+
+		switch op {
+		case "^", "~":
+			c.bounds = append(c.bounds, versionBound{">=", lower}, versionBound{"<", upper})
+		case "", "=":
+			if hasPatch {
+				c.bounds = append(c.bounds, versionBound{"=", lower})
+				c.exact = true
+			} else {
+				c.bounds = append(c.bounds, versionBound{">=", lower}, versionBound{"<", upper})
+			}
+		case ">", "<=":
+			// `>0.2` excludes and `<=0.2` includes every `0.2.x`
+			if !hasPatch {
+				c.bounds = append(c.bounds, versionBound{map[string]string{">": ">=", "<=": "<"}[op], upper})
+			} else {
+				c.bounds = append(c.bounds, versionBound{op, lower})
+			}
+		default:
+			c.bounds = append(c.bounds, versionBound{op, lower})
+		}
+	}
+
+	return c, nil
+}
+
+func (c *versionConstraint) String() string {
+	return c.raw
+}
+
+// matches reports whether `version`, in canonical `vX.Y.Z[-pre]` form, satisfies the constraint.
+func (c *versionConstraint) matches(version string) bool {
+	if semver.Prerelease(version) != "" && !c.exact {
+		return false
+	}
+
+	for _, b := range c.bounds {
+		cmp := semver.Compare(version, b.version)
+		var ok bool
+		switch b.op {
+		case "=":
+			ok = cmp == 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// highestMatching returns the highest version satisfying every constraint, or an empty string.
+func highestMatching(versions []string, constraints ...*versionConstraint) string {
+	var best string
+	for _, v := range versions {
+		matchesAll := true
+		for _, c := range constraints {
+			if !c.matches(v) {
+				matchesAll = false
+				break
+			}
+		}
+		if matchesAll && (best == "" || semver.Compare(v, best) > 0) {
+			best = v
+		}
+	}
+	return best
+}
@@ -0,0 +1,49 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionConstraint_Matches(t *testing.T) {
+	tests := []struct {
+		constraint  string
+		matching    []string
+		notMatching []string
+	}{
+		{"^0.2", []string{"v0.2.0", "v0.2.9"}, []string{"v0.1.9", "v0.3.0", "v0.2.1-rc1"}},
+		{"^0.2.3", []string{"v0.2.3", "v0.2.9"}, []string{"v0.2.2", "v0.3.0"}},
+		{"^0.0.3", []string{"v0.0.3"}, []string{"v0.0.4"}},
+		{"^1.2", []string{"v1.2.0", "v1.9.0"}, []string{"v1.1.0", "v2.0.0"}},
+		{"~1.2.3", []string{"v1.2.3", "v1.2.9"}, []string{"v1.3.0"}},
+		{"0.2", []string{"v0.2.0", "v0.2.5"}, []string{"v0.3.0"}},
+		{"v0.2.1", []string{"v0.2.1"}, []string{"v0.2.2"}},
+		{"=0.2.1-rc1", []string{"v0.2.1-rc1"}, []string{"v0.2.1"}},
+		{">=0.2.0 <0.4", []string{"v0.2.0", "v0.3.9"}, []string{"v0.1.0", "v0.4.0"}},
+		{">0.2", []string{"v0.3.0"}, []string{"v0.2.9"}},
+		{"<=0.2", []string{"v0.2.9"}, []string{"v0.3.0"}},
+		{"*", []string{"v0.0.1", "v3.0.0"}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraint, func(t *testing.T) {
+			c, err := parseVersionConstraint(test.constraint)
+			require.NoError(t, err)
+			for _, v := range test.matching {
+				assert.True(t, c.matches(v), "expected %s to match", v)
+			}
+			for _, v := range test.notMatching {
+				assert.False(t, c.matches(v), "expected %s not to match", v)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraint_Invalid(t *testing.T) {
+	for _, in := range []string{"", "^abc", "0.2-rc1", ">=0.2.x"} {
+		_, err := parseVersionConstraint(in)
+		assert.Error(t, err, in)
+	}
+}
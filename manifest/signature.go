@@ -132,6 +132,42 @@ func (m *ModuleHashes) hashModule(modules *pbsubstreams.Modules, module *pbsubst
 	buf.WriteString("entrypoint")
 	buf.WriteString(module.BinaryEntrypoint)
 
+	// the optional fields below are only written when set, so that the hash of modules
+	// not using them stays the same as before they were introduced
+	if module.EndBlock != 0 {
+		endBlockBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint64(endBlockBytes, module.EndBlock)
+		buf.WriteString("end_block")
+		buf.Write(endBlockBytes)
+	}
+
+	if retainBlocks := module.GetKindStore().GetRetainBlocks(); retainBlocks != 0 {
+		retainBlocksBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint64(retainBlocksBytes, retainBlocks)
+		buf.WriteString("retain_blocks")
+		buf.Write(retainBlocksBytes)
+	}
+
+	for _, index := range module.GetKindStore().GetIndexes() {
+		buf.WriteString("index")
+		buf.WriteString(index.Name)
+		extractBytes := make([]byte, 4)
+		switch extract := index.Extract.(type) {
+		case *pbsubstreams.Module_KindStore_Index_KeySegment:
+			buf.WriteString("key_segment")
+			binary.LittleEndian.PutUint32(extractBytes, extract.KeySegment)
+		case *pbsubstreams.Module_KindStore_Index_ValueField:
+			buf.WriteString("value_field")
+			binary.LittleEndian.PutUint32(extractBytes, extract.ValueField)
+		}
+		buf.Write(extractBytes)
+	}
+
+	if mergeEntrypoint := module.GetKindStore().GetMergeEntrypoint(); mergeEntrypoint != "" {
+		buf.WriteString("merge_entrypoint")
+		buf.WriteString(mergeEntrypoint)
+	}
+
 	h := sha1.New()
 	h.Write(buf.Bytes())
 
@@ -168,7 +204,12 @@ func inputValue(input *pbsubstreams.Module_Input) (string, error) {
 	case *pbsubstreams.Module_Input_Source_:
 		return input.GetSource().Type, nil
 	case *pbsubstreams.Module_Input_Params_:
-		return input.GetParams().Value, nil
+		params := input.GetParams()
+		if params.Type == "" {
+			return params.Value, nil
+		}
+		// typed params are hashed as they are passed to the module
+		return params.Type + "!" + string(params.ModuleValue()), nil
 	case *pbsubstreams.Module_Input_Store_:
 		return "", nil // this is accounted for in the `AncestorOf()` tree
 	case *pbsubstreams.Module_Input_Map_:
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -114,3 +115,91 @@ func Test_HashModule(t *testing.T) {
 		})
 	}
 }
+
+func Test_HashModule_EndBlock(t *testing.T) {
+	hash := func(endBlock uint64) string {
+		modules := &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			Modules: []*pbsubstreams.Module{{
+				Name:     "store_a",
+				Kind:     &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{}},
+				EndBlock: endBlock,
+			}},
+		}
+		graph, err := NewModuleGraph(modules.Modules)
+		require.NoError(t, err)
+		h, err := NewModuleHashes().HashModule(modules, modules.Modules[0], graph)
+		require.NoError(t, err)
+		return hex.EncodeToString(h)
+	}
+
+	assert.Equal(t, "eee1a5aa2b90f707ef47df50bbf7a5a44f6a8b76", hash(0), "hash of modules without end block is unchanged")
+	assert.NotEqual(t, hash(0), hash(100))
+	assert.NotEqual(t, hash(100), hash(200))
+}
+
+func Test_HashModule_RetainBlocks(t *testing.T) {
+	hash := func(retainBlocks uint64) string {
+		modules := &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			Modules: []*pbsubstreams.Module{{
+				Name: "store_a",
+				Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{RetainBlocks: retainBlocks}},
+			}},
+		}
+		graph, err := NewModuleGraph(modules.Modules)
+		require.NoError(t, err)
+		h, err := NewModuleHashes().HashModule(modules, modules.Modules[0], graph)
+		require.NoError(t, err)
+		return hex.EncodeToString(h)
+	}
+
+	assert.Equal(t, "eee1a5aa2b90f707ef47df50bbf7a5a44f6a8b76", hash(0), "hash of stores without retain blocks is unchanged")
+	assert.NotEqual(t, hash(0), hash(1000))
+	assert.NotEqual(t, hash(1000), hash(2000))
+}
+
+func Test_HashModule_Indexes(t *testing.T) {
+	hash := func(indexes ...*pbsubstreams.Module_KindStore_Index) string {
+		modules := &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			Modules: []*pbsubstreams.Module{{
+				Name: "store_a",
+				Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{Indexes: indexes}},
+			}},
+		}
+		graph, err := NewModuleGraph(modules.Modules)
+		require.NoError(t, err)
+		h, err := NewModuleHashes().HashModule(modules, modules.Modules[0], graph)
+		require.NoError(t, err)
+		return hex.EncodeToString(h)
+	}
+
+	bySegment := &pbsubstreams.Module_KindStore_Index{Name: "owner", Extract: &pbsubstreams.Module_KindStore_Index_KeySegment{KeySegment: 1}}
+	byField := &pbsubstreams.Module_KindStore_Index{Name: "owner", Extract: &pbsubstreams.Module_KindStore_Index_ValueField{ValueField: 1}}
+
+	assert.Equal(t, "eee1a5aa2b90f707ef47df50bbf7a5a44f6a8b76", hash(), "hash of stores without indexes is unchanged")
+	assert.NotEqual(t, hash(), hash(bySegment))
+	assert.NotEqual(t, hash(bySegment), hash(byField))
+}
+
+func Test_HashModule_MergeEntrypoint(t *testing.T) {
+	hash := func(mergeEntrypoint string) string {
+		modules := &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			Modules: []*pbsubstreams.Module{{
+				Name: "store_a",
+				Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{MergeEntrypoint: mergeEntrypoint}},
+			}},
+		}
+		graph, err := NewModuleGraph(modules.Modules)
+		require.NoError(t, err)
+		h, err := NewModuleHashes().HashModule(modules, modules.Modules[0], graph)
+		require.NoError(t, err)
+		return hex.EncodeToString(h)
+	}
+
+	assert.Equal(t, "eee1a5aa2b90f707ef47df50bbf7a5a44f6a8b76", hash(""), "hash of stores without merge entrypoint is unchanged")
+	assert.NotEqual(t, hash(""), hash("merge_a"))
+	assert.NotEqual(t, hash("merge_a"), hash("merge_b"))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,31 +31,38 @@ func init() {
 	disablePreloadExecFiles = e == "" || e == "0" || e == "false"
 }
 
+// Walker streams out the cached outputs of the requested modules, segment by segment.
+// With multiple modules, their file walkers move in lockstep and the outputs of each
+// block are sent together.
 type Walker struct {
 	ctx context.Context
 	*block.Range
-	fileWalker *execout.FileWalker
-	streamOut  *response.Stream
-	module     *pbsubstreams.Module
-	logger     *zap.Logger
-	working    bool
+	fileWalkers []*execout.FileWalker // one per module
+	streamOut   *response.Stream
+	modules     []*pbsubstreams.Module
+	logger      *zap.Logger
+	working     bool
 }
 
 func NewWalker(
 	ctx context.Context,
-	module *pbsubstreams.Module,
-	fileWalker *execout.FileWalker,
+	modules []*pbsubstreams.Module,
+	fileWalkers []*execout.FileWalker,
 	walkRange *block.Range,
 	stream *response.Stream,
 ) *Walker {
+	if len(modules) == 0 || len(modules) != len(fileWalkers) {
+		panic("logic error: execout walker needs one file walker per module")
+	}
+
 	logger := reqctx.Logger(ctx)
 	return &Walker{
-		ctx:        ctx,
-		module:     module,
-		fileWalker: fileWalker,
-		Range:      walkRange,
-		streamOut:  stream,
-		logger:     logger,
+		ctx:         ctx,
+		modules:     modules,
+		fileWalkers: fileWalkers,
+		Range:       walkRange,
+		streamOut:   stream,
+		logger:      logger,
 	}
 }
 
@@ -71,23 +79,30 @@ func (r *Walker) IsWorking() bool {
 }
 
 func (r *Walker) CmdDownloadCurrentSegment(waitBefore time.Duration) loop.Cmd {
-	file := r.fileWalker.File()
-	if !disablePreloadExecFiles {
-		r.fileWalker.PreloadNext(r.ctx)
+	files := make([]*execout.File, len(r.fileWalkers))
+	for i, fileWalker := range r.fileWalkers {
+		files[i] = fileWalker.File()
+		if !disablePreloadExecFiles {
+			fileWalker.PreloadNext(r.ctx)
+		}
 	}
 
 	return func() loop.Msg {
 		time.Sleep(waitBefore)
 
-		err := file.Load(r.ctx)
-		if errors.Is(err, dstore.ErrNotFound) {
-			return MsgFileNotPresent{NextWait: computeNewWait(waitBefore, r.fileWalker.IsLocal)}
-		}
-		if err != nil {
-			return loop.NewQuitMsg(fmt.Errorf("loading %s cache %q: %w", file.ModuleName, file.Filename(), err))
+		moduleItems := make([][]*pboutput.Item, len(files))
+		for i, file := range files {
+			err := file.Load(r.ctx)
+			if errors.Is(err, dstore.ErrNotFound) {
+				return MsgFileNotPresent{NextWait: computeNewWait(waitBefore, r.fileWalkers[i].IsLocal)}
+			}
+			if err != nil {
+				return loop.NewQuitMsg(fmt.Errorf("loading %s cache %q: %w", file.ModuleName, file.Filename(), err))
+			}
+			moduleItems[i] = file.SortedItems()
 		}
 
-		if err := r.sendItems(file.SortedItems()); err != nil {
+		if err := r.sendItems(moduleItems); err != nil {
 			return loop.NewQuitMsg(err)
 		}
 		return MsgFileDownloaded{}
@@ -108,19 +123,18 @@ func computeNewWait(previousWait time.Duration, storeIsLocal bool) time.Duration
 	return newWait
 }
 
-func (r *Walker) sendItems(sortedItems []*pboutput.Item) error {
-	for _, item := range sortedItems {
-		if item == nil {
-			continue // why would that happen?!
-		}
-		if item.BlockNum < r.StartBlock {
+// sendItems sends the items of each module, sorted by block, grouped by block
+func (r *Walker) sendItems(moduleItems [][]*pboutput.Item) error {
+	for _, items := range groupItemsByBlock(moduleItems) {
+		blockNum := firstItem(items).BlockNum
+		if blockNum < r.StartBlock {
 			continue
 		}
-		if item.BlockNum >= r.ExclusiveEndBlock {
+		if blockNum >= r.ExclusiveEndBlock {
 			return nil
 		}
 
-		blockScopedData, err := toBlockScopedData(r.module, item)
+		blockScopedData, err := toBlockScopedData(r.modules, items)
 		if err != nil {
 			return fmt.Errorf("converting to block scoped data: %w", err)
 		}
@@ -140,20 +154,69 @@ func (r *Walker) sendItems(sortedItems []*pboutput.Item) error {
 	return nil
 }
 
+// groupItemsByBlock returns, for each block having an item in any of the modules, the
+// item of each module at that block (nil for the modules without one), ordered by block.
+func groupItemsByBlock(moduleItems [][]*pboutput.Item) (out [][]*pboutput.Item) {
+	byBlock := map[uint64][]*pboutput.Item{}
+	for i, items := range moduleItems {
+		for _, item := range items {
+			if item == nil {
+				continue // why would that happen?!
+			}
+			blockItems, found := byBlock[item.BlockNum]
+			if !found {
+				blockItems = make([]*pboutput.Item, len(moduleItems))
+				byBlock[item.BlockNum] = blockItems
+				out = append(out, blockItems)
+			}
+			blockItems[i] = item
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return firstItem(out[i]).BlockNum < firstItem(out[j]).BlockNum
+	})
+	return out
+}
+
+func firstItem(items []*pboutput.Item) *pboutput.Item {
+	for _, item := range items {
+		if item != nil {
+			return item
+		}
+	}
+	return nil
+}
+
+// Progress returns the progress of the file walker lagging behind the others.
 func (r *Walker) Progress() (first, current, last int) {
-	return r.fileWalker.Progress()
+	first, current, last = r.fileWalkers[0].Progress()
+	for _, fileWalker := range r.fileWalkers[1:] {
+		f, c, l := fileWalker.Progress()
+		first, current, last = min(first, f), min(current, c), max(last, l)
+	}
+	return
 }
 
 func (r *Walker) NextSegment() {
-	r.fileWalker.Next()
+	for _, fileWalker := range r.fileWalkers {
+		fileWalker.Next()
+	}
 }
 
 func (r *Walker) IsCompleted() bool {
-	return r.fileWalker.IsDone()
+	for _, fileWalker := range r.fileWalkers {
+		if !fileWalker.IsDone() {
+			return false
+		}
+	}
+	return true
 }
 
-func toBlockScopedData(module *pbsubstreams.Module, cacheItem *pboutput.Item) (*pbsubstreamsrpc.BlockScopedData, error) {
-	clock := toClock(cacheItem)
+// toBlockScopedData builds the block's data from the cache items of each module at that
+// block, filling `Outputs` when there is more than one module.
+func toBlockScopedData(modules []*pbsubstreams.Module, cacheItems []*pboutput.Item) (*pbsubstreamsrpc.BlockScopedData, error) {
+	clock := toClock(firstItem(cacheItems))
 	blockRef := bstream.NewBlockRef(clock.Id, clock.Number)
 	cursor := bstream.Cursor{
 		Step:      bstream.StepNewIrreversible,
@@ -167,21 +230,35 @@ func toBlockScopedData(module *pbsubstreams.Module, cacheItem *pboutput.Item) (*
 		FinalBlockHeight: blockRef.Num(),
 	}
 
-	m, err := toModuleOutput(module, cacheItem)
-	if err != nil {
-		return nil, fmt.Errorf("module output: %w", err)
+	for i, module := range modules {
+		m, err := toModuleOutput(module, cacheItems[i])
+		if err != nil {
+			return nil, fmt.Errorf("module output: %w", err)
+		}
+		if i == 0 {
+			out.Output = m
+		}
+		if len(modules) > 1 {
+			out.Outputs = append(out.Outputs, m)
+		}
 	}
-	out.Output = m
 
 	return out, nil
 }
 
+// toModuleOutput returns the output of `module` held by `cacheItem`, empty when the module
+// has no item at that block.
 func toModuleOutput(module *pbsubstreams.Module, cacheItem *pboutput.Item) (*pbsubstreamsrpc.MapModuleOutput, error) {
 	outputType := strings.TrimPrefix(module.Output.Type, "proto:")
 
+	var payload []byte
+	if cacheItem != nil {
+		payload = cacheItem.Payload
+	}
+
 	return &pbsubstreamsrpc.MapModuleOutput{
 		Name:      module.Name,
-		MapOutput: &anypb.Any{TypeUrl: "type.googleapis.com/" + outputType, Value: cacheItem.Payload},
+		MapOutput: &anypb.Any{TypeUrl: "type.googleapis.com/" + outputType, Value: payload},
 	}, nil
 }
 
@@ -0,0 +1,40 @@
+package execout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams/block"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/execout"
+)
+
+func TestWalker_ProgressAccountsForAllFileWalkers(t *testing.T) {
+	segmenter := block.NewSegmenter(10, 0, 30)
+	var modules []*pbsubstreams.Module
+	var fileWalkers []*execout.FileWalker
+	for _, name := range []string{"map_a", "map_b"} {
+		config, err := execout.NewConfig(name, 0, pbsubstreams.ModuleKindMap, name, dstore.NewMockStore(nil), zap.NewNop())
+		require.NoError(t, err)
+		modules = append(modules, &pbsubstreams.Module{Name: name})
+		fileWalkers = append(fileWalkers, execout.NewFileWalker(config, segmenter, zap.NewNop()))
+	}
+	walker := NewWalker(context.Background(), modules, fileWalkers, block.NewRange(0, 30), nil)
+
+	// the second file walker lags one segment behind the first one
+	fileWalkers[0].Next()
+	first, current, last := walker.Progress()
+	assert.Equal(t, []int{0, 0, 2}, []int{first, current, last})
+
+	walker.NextSegment()
+	walker.NextSegment()
+	assert.False(t, walker.IsCompleted())
+
+	walker.NextSegment()
+	assert.True(t, walker.IsCompleted())
+}
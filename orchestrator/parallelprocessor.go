@@ -13,6 +13,7 @@ import (
 	"github.com/streamingfast/substreams/orchestrator/scheduler"
 	"github.com/streamingfast/substreams/orchestrator/stage"
 	"github.com/streamingfast/substreams/orchestrator/work"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/pipeline/exec"
 	"github.com/streamingfast/substreams/storage/execout"
 	"github.com/streamingfast/substreams/storage/store"
@@ -65,22 +66,30 @@ func BuildParallelProcessor(
 	// for whatever reason,
 
 	if reqPlan.ReadExecOut != nil {
-		// note: since we are *NOT* in a sub-request and are setting up output module is a map
-		requestedModule := execGraph.OutputModule()
-		if requestedModule.GetKindStore() != nil {
-			panic("logic error: should not get a store as outputModule on tier 1")
-		}
+		var requestedModules []*pbsubstreams.Module
+		var walkers []*execout.FileWalker
+		for _, requestedModule := range execGraph.OutputModules() {
+			// note: since we are *NOT* in a sub-request and are setting up output module is a map
+			if requestedModule.GetKindStore() != nil {
+				panic("logic error: should not get a store as outputModule on tier 1")
+			}
+
+			// no ReadExecOut if output type is an index
+			if requestedModule.GetKindMap() == nil {
+				continue
+			}
 
-		// no ReadExecOut if output type is an index
-		if requestedModule.GetKindMap() != nil {
 			initialBlock := execGraph.ModulesInitBlocks()[requestedModule.Name]
 			execOutSegmenter := reqPlan.ReadOutSegmenter(initialBlock)
-			walker := execoutStorage.NewFileWalker(requestedModule.Name, execOutSegmenter)
+			requestedModules = append(requestedModules, requestedModule)
+			walkers = append(walkers, execoutStorage.NewFileWalker(requestedModule.Name, execOutSegmenter))
+		}
 
+		if len(requestedModules) != 0 {
 			sched.ExecOutWalker = orchestratorExecout.NewWalker(
 				ctx,
-				requestedModule,
-				walker,
+				requestedModules,
+				walkers,
 				reqPlan.ReadExecOut,
 				stream,
 			)
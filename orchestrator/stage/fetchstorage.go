@@ -24,24 +24,21 @@ func (s *Stages) FetchStoresState(
 
 	upToBlock := segmenter.ExclusiveEndBlock()
 
-	var mapperName string
-	var mapperFiles execout.FileInfos
+	// the mapper stage holds a single module, or one per output module when multiple are requested
+	var mapperFiles map[string]execout.FileInfos
 	if lastStage := s.stages[len(s.stages)-1]; lastStage.kind == KindMap {
-		if len(lastStage.storeModuleStates) != 1 {
-			panic("assertion: mapper stage should contain a single module")
-		}
-
-		mapperName = lastStage.storeModuleStates[0].name
-		conf := execoutConfigs.ConfigMap[mapperName]
-
 		if upToBlock != 0 {
 			from := segmenter.InitialBlock()
 			if upToBlock != from {
-				files, err := conf.ListSnapshotFiles(ctx, bstream.NewInclusiveRange(from, upToBlock))
-				if err != nil {
-					return fmt.Errorf("fetching mapper storage state: %w", err)
+				mapperFiles = make(map[string]execout.FileInfos)
+				for _, mod := range lastStage.storeModuleStates {
+					conf := execoutConfigs.ConfigMap[mod.name]
+					files, err := conf.ListSnapshotFiles(ctx, bstream.NewInclusiveRange(from, upToBlock))
+					if err != nil {
+						return fmt.Errorf("fetching mapper storage state: %w", err)
+					}
+					mapperFiles[mod.name] = files
 				}
-				mapperFiles = files
 			}
 		}
 	}
@@ -73,15 +70,17 @@ func (s *Stages) FetchStoresState(
 			if stageIdx != len(s.stages)-1 {
 				panic("assertion: mapper stage is not the last stage")
 			}
-			for _, outputFile := range mapperFiles {
-				segmentIdx := s.mapSegmenter.IndexForEndBlock(outputFile.BlockRange.ExclusiveEndBlock)
-				rng := s.mapSegmenter.Range(segmentIdx)
-				if rng == nil || rng.ExclusiveEndBlock != outputFile.BlockRange.ExclusiveEndBlock {
-					continue
-				}
-				unit := Unit{Stage: stageIdx, Segment: segmentIdx}
-				if allDone := markFound(completes, unit, mapperName, moduleCount(unit)); allDone {
-					s.markSegmentCompleted(unit)
+			for mapperName, files := range mapperFiles {
+				for _, outputFile := range files {
+					segmentIdx := s.mapSegmenter.IndexForEndBlock(outputFile.BlockRange.ExclusiveEndBlock)
+					rng := s.mapSegmenter.Range(segmentIdx)
+					if rng == nil || rng.ExclusiveEndBlock != outputFile.BlockRange.ExclusiveEndBlock {
+						continue
+					}
+					unit := Unit{Stage: stageIdx, Segment: segmentIdx}
+					if allDone := markFound(completes, unit, mapperName, moduleCount(unit)); allDone {
+						s.markSegmentCompleted(unit)
+					}
 				}
 			}
 
@@ -21,7 +21,10 @@ import (
 // perhaps used to _produce_ that prior FullKV), or load it from storage.
 // This allows for both initialization of the store, and skipping of FullKV if we
 // happen to have some that were deleted.
-func (s *Stages) multiSquash(stage *Stage, mergeUnit Unit) error {
+//
+// When `frozen` is set, no job produced a PartialKV for the mergeUnit because the stage's
+// stores had all ended: the FullKV of the previous segment is carried over as is.
+func (s *Stages) multiSquash(stage *Stage, mergeUnit Unit, frozen bool) error {
 	if stage.kind != KindStore {
 		panic("multiSquash called on non-store stage")
 	}
@@ -37,7 +40,7 @@ func (s *Stages) multiSquash(stage *Stage, mergeUnit Unit) error {
 			stats := reqctx.ReqStats(s.ctx)
 			stats.RecordModuleMerging(modState.name)
 			defer stats.RecordModuleMergeComplete(modState.name)
-			err := s.singleSquash(stage, modState, mergeUnit)
+			err := s.singleSquash(stage, modState, mergeUnit, frozen)
 			if err != nil {
 				return fmt.Errorf("squash stage %d module %q: %w", stage.idx, modState.name, err)
 			}
@@ -97,7 +100,7 @@ func getPartialOrFullKV(ctx context.Context, modState *StoreModuleState, rng *bl
 
 // singleSquash gets the current fullKV and merges the next partialKV into it.
 // If there is an existing fullKV at the destination (next segment), it will be loaded instead (whichever file is seen first)
-func (s *Stages) singleSquash(stage *Stage, modState *StoreModuleState, mergeUnit Unit) error {
+func (s *Stages) singleSquash(stage *Stage, modState *StoreModuleState, mergeUnit Unit, frozen bool) error {
 	metrics := mergeMetrics{}
 	metrics.start = time.Now()
 	metrics.stage = stage.idx
@@ -115,6 +118,15 @@ func (s *Stages) singleSquash(stage *Stage, modState *StoreModuleState, mergeUni
 		return fmt.Errorf("getting store: %w", err)
 	}
 
+	if frozen {
+		modState.lastBlockInStore = rng.ExclusiveEndBlock
+		if err := s.saveFullKV(stage, fullKV, rng, segmentEndsOnInterval, &metrics); err != nil {
+			return err
+		}
+		s.logger.Info("squashing time metrics (skipped, store ended)", metrics.logFields()...)
+		return nil
+	}
+
 	// Load
 	metrics.loadStart = time.Now()
 	partialKV, partialFile, newFullKV, err := getPartialOrFullKV(s.ctx, modState, rng)
@@ -147,21 +159,33 @@ func (s *Stages) singleSquash(stage *Stage, modState *StoreModuleState, mergeUni
 		return partialKV.DeleteStore(s.ctx, partialFile)
 	})
 
-	// Flush full store
-	if segmentEndsOnInterval {
-		metrics.saveStart = time.Now()
-		_, writer, err := fullKV.Save(rng.ExclusiveEndBlock)
-		if err != nil {
-			return fmt.Errorf("save full store: %w", err)
-		}
-		metrics.saveEnd = time.Now()
-
-		stage.asyncWork.Go(func() error {
-			return writer.Write(context.Background()) // always write files here even if the request was cancelled.
-		})
+	if err := s.saveFullKV(stage, fullKV, rng, segmentEndsOnInterval, &metrics); err != nil {
+		return err
 	}
 
 	s.logger.Info("squashing time metrics", metrics.logFields()...)
 
 	return nil
 }
+
+// saveFullKV flushes the full store when the segment ends on an interval, after
+// dropping the keys past its retain blocks.
+func (s *Stages) saveFullKV(stage *Stage, fullKV *store.FullKV, rng *block.Range, segmentEndsOnInterval bool, metrics *mergeMetrics) error {
+	if !segmentEndsOnInterval {
+		return nil
+	}
+
+	fullKV.Retain(rng.ExclusiveEndBlock)
+
+	metrics.saveStart = time.Now()
+	_, writer, err := fullKV.Save(rng.ExclusiveEndBlock)
+	if err != nil {
+		return fmt.Errorf("save full store: %w", err)
+	}
+	metrics.saveEnd = time.Now()
+
+	stage.asyncWork.Go(func() error {
+		return writer.Write(context.Background()) // always write files here even if the request was cancelled.
+	})
+	return nil
+}
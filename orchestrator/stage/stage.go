@@ -16,6 +16,10 @@ type Stage struct {
 
 	storeModuleStates []*StoreModuleState
 
+	// endBlock is set when every store of the stage has an end block, to the
+	// latest of them: from that block on, the stores don't change anymore.
+	endBlock uint64
+
 	// allExecutedModules is all the store+mapper executed specifically for this stage
 	allExecutedModules []string
 
@@ -49,6 +53,11 @@ func (s *Stage) nextUnit() Unit {
 	}
 }
 
+// frozenAt reports whether none of the stage's stores run from `blockNum` on.
+func (s *Stage) frozenAt(blockNum uint64) bool {
+	return s.endBlock != 0 && blockNum >= s.endBlock
+}
+
 type Kind int
 
 const (
@@ -71,6 +71,7 @@ func NewStages(
 
 	stagedModules := execGraph.StagedUsedModules()
 	modulesInitBlocks := execGraph.ModulesInitBlocks()
+	modulesEndBlocks := execGraph.ModulesEndBlocks()
 	out = &Stages{
 		ctx:                 ctx,
 		logger:              reqctx.Logger(ctx),
@@ -120,6 +121,9 @@ func NewStages(
 
 		stageSegmenter := segmenter.WithInitialBlock(stageLowestInitBlock)
 		stage := NewStage(idx, kind, stageSegmenter, moduleStates, allModules)
+		if kind == KindStore {
+			stage.endBlock = stageEndBlock(layer, modulesEndBlocks)
+		}
 		out.stages = append(out.stages, stage)
 	}
 
@@ -128,6 +132,19 @@ func NewStages(
 	return out
 }
 
+// stageEndBlock returns the block from which none of the modules of the layer
+// run anymore, or 0 if one of them runs indefinitely.
+func stageEndBlock(layer exec.LayerModules, modulesEndBlocks map[string]uint64) (out uint64) {
+	for _, mod := range layer {
+		endBlock, found := modulesEndBlocks[mod.Name]
+		if !found {
+			return 0
+		}
+		out = max(out, endBlock)
+	}
+	return out
+}
+
 func layerKind(layer exec.LayerModules) Kind {
 	if layer.IsStoreLayer() {
 		return KindStore
@@ -267,7 +284,12 @@ func (s *Stages) CmdTryMerge(stageIdx int) loop.Cmd {
 		return CmdMergeNotReady(mergeUnit, "this stage is done")
 	}
 
-	if s.getState(mergeUnit) != UnitPartialPresent {
+	// No job is scheduled for the units of a stage whose stores all ended,
+	// their full stores are carried over from the previous unit instead.
+	state := s.getState(mergeUnit)
+	frozen := state == UnitPending && stage.frozenAt(stage.segmenter.Range(mergeUnit.Segment).StartBlock)
+
+	if state != UnitPartialPresent && !frozen {
 		return CmdMergeNotReady(mergeUnit, "next unit's partial isn't present")
 	}
 
@@ -275,10 +297,13 @@ func (s *Stages) CmdTryMerge(stageIdx int) loop.Cmd {
 		return CmdMergeNotReady(mergeUnit, "previous unit not complete")
 	}
 
+	if frozen {
+		s.MarkSegmentPartialPresent(mergeUnit)
+	}
 	s.MarkSegmentMerging(mergeUnit)
 
 	return func() loop.Msg {
-		if err := s.multiSquash(stage, mergeUnit); err != nil {
+		if err := s.multiSquash(stage, mergeUnit, frozen); err != nil {
 			return MsgMergeFailed{Unit: mergeUnit, Error: err}
 		}
 		return MsgMergeFinished{Unit: mergeUnit}
@@ -401,6 +426,10 @@ func (s *Stages) NextJob() (Unit, *block.Range) {
 				s.markSegmentCompleted(unit)
 				continue
 			}
+			if stage.frozenAt(r.StartBlock) {
+				// the merger carries the stores over, see CmdTryMerge
+				continue
+			}
 
 			if someShadowed && stageIdx == lastStage {
 				for i := 0; i < len(s.stages); i++ {
@@ -336,6 +336,41 @@ func TestShadowStartAfter2(t *testing.T) {
 	assert.Equal(t, unit(4, 0), nextJob(t, stages))
 }
 
+func TestStages_FrozenStoreStage(t *testing.T) {
+	reqPlan, err := plan.BuildTier1RequestPlan(true, 10, 5, 5, 5, 50, 50, true)
+	assert.NoError(t, err)
+
+	stages := NewStages(
+		context.Background(),
+		exec.TestGraphStagedModules(5, 5, 5, 5, 5),
+		reqPlan,
+		nil,
+	)
+	stages.stages[0].endBlock = 20 // the first stage's store ended at block 20
+
+	for {
+		u, r := stages.NextJob()
+		if r == nil {
+			break
+		}
+		if u.Stage == 0 {
+			assert.Less(t, r.StartBlock, uint64(20), "no job scheduled on the first stage after its end block, got %s", r)
+		}
+	}
+
+	for _, u := range []Unit{unit(0, 0), unit(1, 0)} {
+		stages.forceTransition(u.Segment, u.Stage, UnitMerging)
+		stages.MergeCompleted(u)
+	}
+	assert.Equal(t, UnitPending, stages.getState(unit(2, 0)))
+
+	assert.NotNil(t, stages.CmdTryMerge(0))
+	assert.Equal(t, UnitMerging, stages.getState(unit(2, 0)), "frozen unit is carried over by the merger")
+
+	stages.forceTransition(0, 1, UnitPending)
+	assert.Equal(t, "next unit's partial isn't present", stages.CmdTryMerge(1)().(MsgMergeNotReady).Reason, "units of stages that didn't end still need a job")
+}
+
 func segmentStateEquals(t *testing.T, s *Stages, segments string) {
 	t.Helper()
 
@@ -94,9 +94,15 @@ func NewRequest(ctx context.Context, req *reqctx.RequestDetails, stageIndex int,
 
 	segment := startBlock / tier2ReqParams.StateBundleSize
 
+	var outputModules []string
+	if len(req.OutputModules) > 1 {
+		outputModules = req.OutputModules
+	}
+
 	return &pbssinternal.ProcessRangeRequest{
 		Modules:              req.Modules,
 		OutputModule:         req.OutputModule,
+		OutputModules:        outputModules,
 		Stage:                uint32(stageIndex),
 		MeteringConfig:       tier2ReqParams.MeteringConfig,
 		FirstStreamableBlock: tier2ReqParams.FirstStreamableBlock,
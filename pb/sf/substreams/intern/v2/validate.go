@@ -29,18 +29,27 @@ func (r *ProcessRangeRequest) Validate() error {
 	}
 
 	seenStores := map[string]bool{}
-	outputModuleFound := false
+	seenModules := map[string]bool{}
 	for _, mod := range r.Modules.Modules {
 		if _, ok := mod.Kind.(*pbsubstreams.Module_KindStore_); ok {
 			seenStores[mod.Name] = true
 		}
-		if mod.Name == r.OutputModule { // internal request can have store or module output
-			outputModuleFound = true
-		}
+		seenModules[mod.Name] = true
 	}
-	if !outputModuleFound {
-		return fmt.Errorf("output module %q not found in modules", r.OutputModule)
+	for _, outputModule := range r.OutputModuleNames() { // internal request can have store or module output
+		if !seenModules[outputModule] {
+			return fmt.Errorf("output module %q not found in modules", outputModule)
+		}
 	}
 
 	return nil
 }
+
+// OutputModuleNames returns all the output modules of the tier1 request, the
+// `output_module` being the first one.
+func (r *ProcessRangeRequest) OutputModuleNames() []string {
+	if len(r.OutputModules) != 0 {
+		return r.OutputModules
+	}
+	return []string{r.OutputModule}
+}
@@ -53,7 +53,9 @@ func (s *StoreModuleOutput) ToAny() *AnyModuleOutput {
 }
 
 func (bd *BlockScopedData) AllModuleOutputs() (out []*AnyModuleOutput) {
-	out = append(out, bd.Output.ToAny())
+	for _, mapOut := range bd.RequestedOutputs() {
+		out = append(out, mapOut.ToAny())
+	}
 	for _, mapOut := range bd.DebugMapOutputs {
 		out = append(out, mapOut.ToAny())
 	}
@@ -63,6 +65,27 @@ func (bd *BlockScopedData) AllModuleOutputs() (out []*AnyModuleOutput) {
 	return
 }
 
+// RequestedOutputs returns the outputs of all the requested output modules, `outputs`
+// when multiple modules were requested or else the single `output`.
+func (bd *BlockScopedData) RequestedOutputs() []*MapModuleOutput {
+	if len(bd.Outputs) != 0 {
+		return bd.Outputs
+	}
+	return []*MapModuleOutput{bd.Output}
+}
+
+// OutputModuleNames returns the modules whose outputs are requested, `output_modules`
+// when set or else the single `output_module`.
+func (req *Request) OutputModuleNames() []string {
+	if len(req.OutputModules) != 0 {
+		return req.OutputModules
+	}
+	if req.OutputModule == "" {
+		return nil
+	}
+	return []string{req.OutputModule}
+}
+
 func (req *Request) Validate() error {
 	seenStores := map[string]bool{}
 
@@ -70,28 +93,47 @@ func (req *Request) Validate() error {
 		return fmt.Errorf("no modules found in request")
 	}
 
-	if req.OutputModule == "" {
+	outputModules := req.OutputModuleNames()
+	if len(outputModules) == 0 {
 		return fmt.Errorf("no output module defined in request")
 	}
+	if req.OutputModule != "" && req.OutputModule != outputModules[0] {
+		return fmt.Errorf("output module %q must be the first of the output modules", req.OutputModule)
+	}
 
 	if req.DebugInitialStoreSnapshotForModules != nil && req.ProductionMode {
 		return fmt.Errorf("cannot set 'debug-modules-initial-snapshot' in 'production-mode'")
 	}
 
-	outputModuleFound := false
+	if req.OutputFilter != nil && req.OutputFilter.Expression == "" {
+		return fmt.Errorf("output filter expression is empty")
+	}
+
+	modules := map[string]*pbsubstreams.Module{}
 	for _, mod := range req.Modules.Modules {
 		if _, ok := mod.Kind.(*pbsubstreams.Module_KindStore_); ok {
 			seenStores[mod.Name] = true
 		}
-		if mod.Name == req.OutputModule {
-			if _, ok := mod.Kind.(*pbsubstreams.Module_KindStore_); ok {
-				return fmt.Errorf("output module must be of kind 'map'")
-			}
-			outputModuleFound = true
-		}
+		modules[mod.Name] = mod
 	}
-	if !outputModuleFound {
-		return fmt.Errorf("output module %q not found in modules", req.OutputModule)
+
+	seenOutputs := map[string]bool{}
+	for _, outputModule := range outputModules {
+		if seenOutputs[outputModule] {
+			return fmt.Errorf("output module %q requested more than once", outputModule)
+		}
+		seenOutputs[outputModule] = true
+
+		mod, found := modules[outputModule]
+		if !found {
+			return fmt.Errorf("output module %q not found in modules", outputModule)
+		}
+		if _, ok := mod.Kind.(*pbsubstreams.Module_KindStore_); ok {
+			return fmt.Errorf("output module must be of kind 'map'")
+		}
+		if len(outputModules) > 1 && mod.GetKindMap() == nil {
+			return fmt.Errorf("output module %q must be of kind 'map' when requesting multiple output modules", outputModule)
+		}
 	}
 
 	for _, storeSnapshot := range req.DebugInitialStoreSnapshotForModules {
@@ -19,6 +19,13 @@ func Test_ValidateRequest(t *testing.T) {
 		{"negative start block num", TestNewRequest(-1, withTestOutputModule("output_mod_1"), withTestMapModule("output_mod_1")), nil},
 		{"no modules found in request", &Request{StartBlockNum: 1}, fmt.Errorf("no modules found in request")},
 		{"store output module is accepted for sub-request", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestStoreModule("output_mod_1")), fmt.Errorf("output module must be of kind 'map'")},
+		{"multiple output modules", TestNewRequest(1, withTestOutputModules("output_mod_1", "output_mod_2"), withTestMapModule("output_mod_1"), withTestMapModule("output_mod_2")), nil},
+		{"multiple output modules with matching output module", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestOutputModules("output_mod_1", "output_mod_2"), withTestMapModule("output_mod_1"), withTestMapModule("output_mod_2")), nil},
+		{"multiple output modules with other output module", TestNewRequest(1, withTestOutputModule("output_mod_2"), withTestOutputModules("output_mod_1", "output_mod_2"), withTestMapModule("output_mod_1"), withTestMapModule("output_mod_2")), fmt.Errorf("output module \"output_mod_2\" must be the first of the output modules")},
+		{"multiple output modules with duplicate", TestNewRequest(1, withTestOutputModules("output_mod_1", "output_mod_1"), withTestMapModule("output_mod_1")), fmt.Errorf("output module \"output_mod_1\" requested more than once")},
+		{"multiple output modules with store", TestNewRequest(1, withTestOutputModules("output_mod_1", "store_mod"), withTestMapModule("output_mod_1"), withTestStoreModule("store_mod")), fmt.Errorf("output module must be of kind 'map'")},
+		{"output filter", TestNewRequest(1, withTestOutputModule("output_mod"), withTestMapModule("output_mod"), withTestOutputFilter("transfers[].to == a")), nil},
+		{"empty output filter", TestNewRequest(1, withTestOutputModule("output_mod"), withTestMapModule("output_mod"), withTestOutputFilter("")), fmt.Errorf("output filter expression is empty")},
 		{"production mode should fail with debug flag", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestMapModule("output_mod_1"), withProductionMode(), withDebugSnapshotsModule("output_mod_1")), fmt.Errorf("cannot set 'debug-modules-initial-snapshot' in 'production-mode'")},
 	}
 
@@ -36,6 +36,20 @@ func withTestOutputModule(module string) testNewRequestOption {
 	}
 }
 
+func withTestOutputModules(modules ...string) testNewRequestOption {
+	return func(req *Request) *Request {
+		req.OutputModules = modules
+		return req
+	}
+}
+
+func withTestOutputFilter(expression string) testNewRequestOption {
+	return func(req *Request) *Request {
+		req.OutputFilter = &OutputFilter{Expression: expression}
+		return req
+	}
+}
+
 func withTestStoreModule(name string) testNewRequestOption {
 	return func(req *Request) *Request {
 		req.Modules.Modules = append(req.Modules.Modules, TestNewStoreModule(name))
@@ -32,6 +32,15 @@ func (m *Module) BlockFilterQueryString() (string, error) {
 	}
 }
 
+// ModuleValue returns the params as they are passed to the module: the encoded value
+// when there is one, the raw value otherwise.
+func (x *Module_Input_Params) ModuleValue() []byte {
+	if len(x.GetEncodedValue()) != 0 {
+		return x.EncodedValue
+	}
+	return []byte(x.GetValue())
+}
+
 func (x *Module) ModuleKind() ModuleKind {
 	switch x.Kind.(type) {
 	case *Module_KindMap_:
@@ -19,6 +19,7 @@ type BaseExecutor struct {
 
 	moduleName    string
 	initialBlock  uint64
+	endBlock      uint64 // exclusive, 0 when the module runs indefinitely
 	wasmModule    wasm.Module
 	wasmArguments []wasm.Argument
 	entrypoint    string
@@ -34,10 +35,11 @@ type BaseExecutor struct {
 	executionStack []string
 }
 
-func NewBaseExecutor(ctx context.Context, moduleName string, initialBlock uint64, wasmModule wasm.Module, cacheEnabled bool, wasmArguments []wasm.Argument, blockIndex *index.BlockIndex, entrypoint string, tracer ttrace.Tracer) *BaseExecutor {
+func NewBaseExecutor(ctx context.Context, moduleName string, initialBlock uint64, endBlock uint64, wasmModule wasm.Module, cacheEnabled bool, wasmArguments []wasm.Argument, blockIndex *index.BlockIndex, entrypoint string, tracer ttrace.Tracer) *BaseExecutor {
 	return &BaseExecutor{
 		ctx:                  ctx,
 		initialBlock:         initialBlock,
+		endBlock:             endBlock,
 		blockIndex:           blockIndex,
 		moduleName:           moduleName,
 		wasmModule:           wasmModule,
@@ -157,6 +159,9 @@ func (e *BaseExecutor) BlockIndex() *index.BlockIndex {
 }
 
 func (e *BaseExecutor) RunsOnBlock(blockNum uint64) bool {
+	if e.endBlock != 0 && blockNum >= e.endBlock {
+		return false
+	}
 	return blockNum >= e.initialBlock
 }
 
@@ -221,3 +221,14 @@ func (m mockOutputGetter) Get(name string) ([]byte, bool, error) {
 	}
 	return nil, false, execout.ErrNotFound
 }
+
+func TestBaseExecutor_RunsOnBlock(t *testing.T) {
+	executor := &BaseExecutor{initialBlock: 10, endBlock: 20}
+	assert.False(t, executor.RunsOnBlock(9))
+	assert.True(t, executor.RunsOnBlock(10))
+	assert.True(t, executor.RunsOnBlock(19))
+	assert.False(t, executor.RunsOnBlock(20), "end block is exclusive")
+
+	executor = &BaseExecutor{initialBlock: 10}
+	assert.True(t, executor.RunsOnBlock(1_000_000), "no end block")
+}
@@ -15,17 +15,20 @@ type Graph struct {
 	moduleHashes          *manifest.ModuleHashes
 	stores                []*pbsubstreams.Module // subset of allModules: only the stores
 	modulesInitBlocks     map[string]uint64
+	modulesEndBlocks      map[string]uint64 // only the modules with an end block
 	lowestInitBlock       uint64
 	lowestStoresInitBlock *uint64
-	outputModule          *pbsubstreams.Module
+	outputModule          *pbsubstreams.Module   // first of the outputModules, the one identifying the request
+	outputModules         []*pbsubstreams.Module // all the requested output modules
 
 	schedulableModules      []*pbsubstreams.Module // stores and output mappers needed to execute to produce output for all `output_modules`.
 	schedulableAncestorsMap map[string][]string    // modules that are ancestors (therefore dependencies) of a given module
 }
 
-func (g *Graph) OutputModule() *pbsubstreams.Module  { return g.outputModule }
-func (g *Graph) Stores() []*pbsubstreams.Module      { return g.stores }
-func (g *Graph) UsedModules() []*pbsubstreams.Module { return g.usedModules }
+func (g *Graph) OutputModule() *pbsubstreams.Module    { return g.outputModule }
+func (g *Graph) OutputModules() []*pbsubstreams.Module { return g.outputModules }
+func (g *Graph) Stores() []*pbsubstreams.Module        { return g.stores }
+func (g *Graph) UsedModules() []*pbsubstreams.Module   { return g.usedModules }
 func (g *Graph) UsedIndexModules() []*pbsubstreams.Module {
 	indexModules := make([]*pbsubstreams.Module, 0)
 	for _, mod := range g.usedModules {
@@ -60,37 +63,52 @@ func (g *Graph) UsedIndexesModulesUpToStage(stage int) (out []*pbsubstreams.Modu
 	return
 }
 func (g *Graph) StagedUsedModules() ExecutionStages   { return g.stagedUsedModules }
-func (g *Graph) IsOutputModule(name string) bool      { return g.outputModule.Name == name }
 func (g *Graph) ModuleHashes() *manifest.ModuleHashes { return g.moduleHashes }
 func (g *Graph) LowestInitBlock() uint64              { return g.lowestInitBlock }
 func (g *Graph) LowestStoresInitBlock() *uint64       { return g.lowestStoresInitBlock }
 func (g *Graph) ModulesInitBlocks() map[string]uint64 { return g.modulesInitBlocks }
+func (g *Graph) ModulesEndBlocks() map[string]uint64  { return g.modulesEndBlocks }
 func (g *Graph) OutputModuleStageIndex() int          { return len(g.stagedUsedModules) - 1 }
 
-func NewOutputModuleGraph(outputModule string, productionMode bool, modules *pbsubstreams.Modules, firstStreamableBlock uint64) (out *Graph, err error) {
+func (g *Graph) IsOutputModule(name string) bool {
+	for _, mod := range g.outputModules {
+		if mod.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewOutputModuleGraph computes the graph of the modules needed to produce the outputs
+// of all the `outputModules`, the union of their ancestors.
+func NewOutputModuleGraph(outputModules []string, productionMode bool, modules *pbsubstreams.Modules, firstStreamableBlock uint64) (out *Graph, err error) {
+	if len(outputModules) == 0 {
+		return nil, fmt.Errorf("module graph: no output module")
+	}
+
 	out = &Graph{
 		requestModules: modules,
 	}
-	if err := out.computeGraph(outputModule, productionMode, modules, firstStreamableBlock); err != nil {
+	if err := out.computeGraph(outputModules, productionMode, modules, firstStreamableBlock); err != nil {
 		return nil, fmt.Errorf("module graph: %w", err)
 	}
 
 	return out, nil
 }
 
-func (g *Graph) computeGraph(outputModule string, productionMode bool, modules *pbsubstreams.Modules, firstStreamableBlock uint64) error {
+func (g *Graph) computeGraph(outputModules []string, productionMode bool, modules *pbsubstreams.Modules, firstStreamableBlock uint64) error {
 	graph, err := manifest.NewModuleGraph(modules.Modules)
 	if err != nil {
 		return fmt.Errorf("compute graph: %w", err)
 	}
-	outputModuleName := outputModule
 
-	processModules, err := graph.ModulesDownTo(outputModuleName)
+	processModules, err := graph.ModulesDownTo(outputModules...)
 	if err != nil {
 		return fmt.Errorf("building execution moduleGraph: %w", err)
 	}
 	g.usedModules = processModules
 	g.modulesInitBlocks = map[string]uint64{}
+	g.modulesEndBlocks = map[string]uint64{}
 	for _, mod := range g.usedModules {
 		initialBlock := mod.InitialBlock
 		if initialBlock == 0 {
@@ -99,6 +117,13 @@ func (g *Graph) computeGraph(outputModule string, productionMode bool, modules *
 			return fmt.Errorf("module %q has initial block %d smaller than first streamable block %d", mod.Name, initialBlock, firstStreamableBlock)
 		}
 		g.modulesInitBlocks[mod.Name] = initialBlock
+
+		if mod.EndBlock != 0 {
+			if mod.EndBlock <= initialBlock {
+				return fmt.Errorf("module %q has end block %d not greater than its initial block %d", mod.Name, mod.EndBlock, initialBlock)
+			}
+			g.modulesEndBlocks[mod.Name] = mod.EndBlock
+		}
 	}
 
 	g.stagedUsedModules, err = computeStages(g.usedModules, g.modulesInitBlocks)
@@ -112,15 +137,18 @@ func (g *Graph) computeGraph(outputModule string, productionMode bool, modules *
 		return fmt.Errorf("cannot hash module: %w", err)
 	}
 
-	g.outputModule = computeOutputModule(g.usedModules, outputModuleName)
+	for _, name := range outputModules {
+		g.outputModules = append(g.outputModules, computeOutputModule(g.usedModules, name))
+	}
+	g.outputModule = g.outputModules[0]
 
-	storeModules, err := graph.StoresDownTo(g.outputModule.Name)
+	storeModules, err := graph.StoresDownTo(outputModules...)
 	if err != nil {
 		return fmt.Errorf("stores down: %w", err)
 	}
 	g.stores = storeModules
 
-	g.schedulableModules = computeSchedulableModules(storeModules, g.outputModule, productionMode)
+	g.schedulableModules = computeSchedulableModules(storeModules, g.outputModules, productionMode)
 
 	ancestorsMap, err := computeSchedulableAncestors(graph, g.schedulableModules)
 	if err != nil {
@@ -322,16 +350,19 @@ func computeOutputModule(mods []*pbsubstreams.Module, outputModule string) *pbsu
 
 }
 
-func computeSchedulableModules(stores []*pbsubstreams.Module, outputModule *pbsubstreams.Module, productionMode bool) []*pbsubstreams.Module {
+func computeSchedulableModules(stores []*pbsubstreams.Module, outputModules []*pbsubstreams.Module, productionMode bool) []*pbsubstreams.Module {
 	if !productionMode { // dev never schedules maps, all stores are in there
 		return stores
 	}
 
-	if outputModule.GetKindStore() != nil {
-		return stores
+	out := append([]*pbsubstreams.Module{}, stores...)
+	for _, outputModule := range outputModules {
+		if outputModule.GetKindStore() != nil {
+			continue
+		}
+		out = append(out, outputModule)
 	}
-
-	return append(stores, outputModule)
+	return out
 }
 
 func computeSchedulableAncestors(graph *manifest.ModuleGraph, schedulableModules []*pbsubstreams.Module) (out map[string][]string, err error) {
@@ -372,8 +403,10 @@ func (g *Graph) hashModules(graph *manifest.ModuleGraph) error {
 }
 
 func (g *Graph) ValidateRequestStartBlock(requestStartBlockNum uint64) error {
-	if requestStartBlockNum < g.outputModule.InitialBlock {
-		return fmt.Errorf("start block %d smaller than request outputs for module %q with start block %d", requestStartBlockNum, g.outputModule.Name, g.outputModule.InitialBlock)
+	for _, outputModule := range g.outputModules {
+		if requestStartBlockNum < outputModule.InitialBlock {
+			return fmt.Errorf("start block %d smaller than request outputs for module %q with start block %d", requestStartBlockNum, outputModule.Name, outputModule.InitialBlock)
+		}
 	}
 	return nil
 }
@@ -144,44 +144,120 @@ func TestGraph_computeSchedulableModules(t *testing.T) {
 	tests := []struct {
 		name           string
 		stores         []*pbsubstreams.Module
-		outputModule   *pbsubstreams.Module
+		outputModules  []*pbsubstreams.Module
 		productionMode bool
 		expect         []*pbsubstreams.Module
 	}{
 
 		{
-			name:         "dev mode with output module map",
-			stores:       []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
-			outputModule: pbsubstreamsrpc.TestNewMapModule("map_a"),
-			expect:       []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
+			name:          "dev mode with output module map",
+			stores:        []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
+			outputModules: []*pbsubstreams.Module{pbsubstreamsrpc.TestNewMapModule("map_a")},
+			expect:        []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
 		},
 		{
-			name:         "dev mode with output module store",
-			stores:       []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
-			outputModule: pbsubstreamsrpc.TestNewStoreModule("store_b"),
-			expect:       []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
+			name:          "dev mode with output module store",
+			stores:        []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
+			outputModules: []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_b")},
+			expect:        []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
 		},
 		{
 			name:           "prod mode with output module map",
 			stores:         []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
-			outputModule:   pbsubstreamsrpc.TestNewMapModule("map_a"),
+			outputModules:  []*pbsubstreams.Module{pbsubstreamsrpc.TestNewMapModule("map_a")},
 			productionMode: true,
 			expect:         []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b"), pbsubstreamsrpc.TestNewMapModule("map_a")},
 		},
 		{
 			name:           "prod mode with output module store",
 			stores:         []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
-			outputModule:   pbsubstreamsrpc.TestNewStoreModule("store_b"),
+			outputModules:  []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_b")},
 			productionMode: true,
 			expect:         []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewStoreModule("store_b")},
 		},
+		{
+			name:           "prod mode with multiple output modules",
+			stores:         []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a")},
+			outputModules:  []*pbsubstreams.Module{pbsubstreamsrpc.TestNewMapModule("map_a"), pbsubstreamsrpc.TestNewMapModule("map_b")},
+			productionMode: true,
+			expect:         []*pbsubstreams.Module{pbsubstreamsrpc.TestNewStoreModule("store_a"), pbsubstreamsrpc.TestNewMapModule("map_a"), pbsubstreamsrpc.TestNewMapModule("map_b")},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			out := computeSchedulableModules(test.stores, test.outputModule, test.productionMode)
+			out := computeSchedulableModules(test.stores, test.outputModules, test.productionMode)
 
 			assert.Equal(t, test.expect, out)
 		})
 	}
 }
+
+func TestGraph_ModulesEndBlocks(t *testing.T) {
+	newModules := func(endBlock uint64) *pbsubstreams.Modules {
+		return &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1"}},
+			Modules: []*pbsubstreams.Module{
+				{
+					Name:         "map_a",
+					Kind:         &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}},
+					Inputs:       []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.test.Block"}}}},
+					InitialBlock: 10,
+					EndBlock:     endBlock,
+				},
+			},
+		}
+	}
+
+	g, err := NewOutputModuleGraph([]string{"map_a"}, true, newModules(100), 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"map_a": 100}, g.ModulesEndBlocks())
+
+	g, err = NewOutputModuleGraph([]string{"map_a"}, true, newModules(0), 0)
+	require.NoError(t, err)
+	assert.Empty(t, g.ModulesEndBlocks())
+
+	_, err = NewOutputModuleGraph([]string{"map_a"}, true, newModules(10), 0)
+	assert.EqualError(t, err, `module graph: module "map_a" has end block 10 not greater than its initial block 10`)
+}
+
+func TestGraph_MultipleOutputModules(t *testing.T) {
+	modules := &pbsubstreams.Modules{
+		Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1"}},
+		Modules: []*pbsubstreams.Module{
+			{Name: "map_a", Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}}, Inputs: []*pbsubstreams.Module_Input{sourceInput()}},
+			{Name: "store_b", Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{}}, Inputs: []*pbsubstreams.Module_Input{mapInput("map_a")}},
+			{Name: "map_c", Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}}, Inputs: []*pbsubstreams.Module_Input{storeInput("store_b")}},
+			{Name: "map_d", Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}}, Inputs: []*pbsubstreams.Module_Input{mapInput("map_a"), storeInput("store_b")}},
+			{Name: "map_e", Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}}, Inputs: []*pbsubstreams.Module_Input{sourceInput()}},
+		},
+	}
+
+	g, err := NewOutputModuleGraph([]string{"map_c", "map_d"}, true, modules, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, "map_c", g.OutputModule().Name)
+	assert.Equal(t, []string{"map_c", "map_d"}, moduleNames(g.OutputModules()))
+	assert.True(t, g.IsOutputModule("map_d"))
+	assert.False(t, g.IsOutputModule("map_a"))
+	assert.ElementsMatch(t, []string{"map_a", "store_b", "map_c", "map_d"}, moduleNames(g.UsedModules()))
+	assert.Equal(t, []string{"store_b"}, moduleNames(g.Stores()))
+	assert.Equal(t, []string{"store_b", "map_c", "map_d"}, g.SchedulableModuleNames())
+	assert.Len(t, g.StagedUsedModules(), 2)
+	assert.ElementsMatch(t, []string{"map_c", "map_d"}, moduleNames(g.StagedUsedModules().LastStage().LastLayer()))
+
+	_, err = NewOutputModuleGraph(nil, true, modules, 0)
+	assert.EqualError(t, err, "module graph: no output module")
+}
+
+func sourceInput() *pbsubstreams.Module_Input {
+	return &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.test.Block"}}}
+}
+
+func mapInput(name string) *pbsubstreams.Module_Input {
+	return &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: name}}}
+}
+
+func storeInput(name string) *pbsubstreams.Module_Input {
+	return &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Store_{Store: &pbsubstreams.Module_Input_Store{ModuleName: name}}}
+}
@@ -53,7 +53,7 @@ type Pipeline struct {
 	ModuleExecutors [][]exec.ModuleExecutor // Staged module executors
 	executionStages exec.ExecutionStages
 
-	mapModuleOutput         *pbsubstreamsrpc.MapModuleOutput
+	mapModuleOutputs        map[string]*pbsubstreamsrpc.MapModuleOutput // outputs of the requested output modules
 	extraMapModuleOutputs   []*pbsubstreamsrpc.MapModuleOutput
 	extraStoreModuleOutputs []*pbsubstreamsrpc.StoreModuleOutput
 	preexistingBlockIndices map[string]map[string]*roaring64.Bitmap
@@ -142,6 +142,10 @@ func (p *Pipeline) Init(ctx context.Context) (err error) {
 	}
 	p.executionStages = stagedModules
 
+	if err := p.setupStoreAggregateFuncs(ctx); err != nil {
+		return fmt.Errorf("setting up store aggregate functions: %w", err)
+	}
+
 	return nil
 }
 
@@ -180,7 +184,7 @@ func (p *Pipeline) GetStoreMap() store.Map {
 
 func (p *Pipeline) setupProcessingModule(reqDetails *reqctx.RequestDetails) {
 	for _, module := range reqDetails.Modules.Modules {
-		if reqDetails.IsOutputModule(module.Name) {
+		if module.Name == reqDetails.OutputModule {
 			p.processingModule = &processingModule{
 				name:            module.GetName(),
 				initialBlockNum: reqDetails.ResolvedStartBlockNum,
@@ -452,6 +456,46 @@ func (p *Pipeline) returnInternalModuleProgressOutputs(clock *pbsubstreams.Clock
 	return nil
 }
 
+// loadModule returns the wasm module of the binary at binaryIndex, instantiated on first use.
+func (p *Pipeline) loadModule(ctx context.Context, binaryIndex uint32) (wasm.Module, error) {
+	if m, exists := p.loadedModules[binaryIndex]; exists {
+		return m, nil
+	}
+
+	code := reqctx.Details(ctx).Modules.Binaries[binaryIndex]
+	m, err := p.wasmRuntime.NewModule(ctx, code.Content, code.Type)
+	if err != nil {
+		return nil, fmt.Errorf("new wasm module: %w", err)
+	}
+	if p.loadedModules == nil {
+		p.loadedModules = make(map[uint32]wasm.Module)
+	}
+	p.loadedModules[binaryIndex] = m
+	return m, nil
+}
+
+// setupStoreAggregateFuncs sets the aggregate function of the stores with the custom update
+// policy, calling the merge entrypoint of their module, used both to flush and to merge them.
+func (p *Pipeline) setupStoreAggregateFuncs(ctx context.Context) error {
+	for _, module := range p.execGraph.Stores() {
+		kindStore := module.GetKindStore()
+		if kindStore.UpdatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM {
+			continue
+		}
+		storeConfig := p.stores.configs[module.Name]
+		if storeConfig == nil {
+			continue
+		}
+
+		m, err := p.loadModule(ctx, module.BinaryIndex)
+		if err != nil {
+			return fmt.Errorf("store %q: %w", module.Name, err)
+		}
+		storeConfig.SetAggregateFunc(wasm.NewStoreAggregateFunc(ctx, m, module.Name, kindStore.MergeEntrypoint, reqctx.ReqStats(ctx), p.wasmRuntime.InstanceCacheEnabled()))
+	}
+	return nil
+}
+
 // BuildModuleExecutors builds the ModuleExecutors, and the loadedModules.
 func (p *Pipeline) BuildModuleExecutors(ctx context.Context) error {
 	if p.ModuleExecutors != nil {
@@ -460,28 +504,21 @@ func (p *Pipeline) BuildModuleExecutors(ctx context.Context) error {
 		return nil
 	}
 
-	reqModules := reqctx.Details(ctx).Modules
 	tracer := otel.GetTracerProvider().Tracer("executor")
 
-	loadedModules := make(map[uint32]wasm.Module)
 	for _, stage := range p.executionStages {
 		for _, layer := range stage {
 			for _, module := range layer {
-				if _, exists := loadedModules[module.BinaryIndex]; exists {
-					continue
-				}
-				code := reqModules.Binaries[module.BinaryIndex]
-				m, err := p.wasmRuntime.NewModule(ctx, code.Content, code.Type)
-				if err != nil {
-					return fmt.Errorf("new wasm module: %w", err)
+				if _, err := p.loadModule(ctx, module.BinaryIndex); err != nil {
+					return err
 				}
-				loadedModules[module.BinaryIndex] = m
 			}
 		}
 	}
 
-	p.loadedModules = loadedModules
+	loadedModules := p.loadedModules
 	modulesInitBlocks := p.execGraph.ModulesInitBlocks()
+	modulesEndBlocks := p.execGraph.ModulesEndBlocks()
 
 	var stagedModuleExecutors [][]exec.ModuleExecutor
 	for _, stage := range p.executionStages {
@@ -520,6 +557,7 @@ func (p *Pipeline) BuildModuleExecutors(ctx context.Context) error {
 						ctx,
 						module.Name,
 						modulesInitBlocks[module.Name],
+						modulesEndBlocks[module.Name],
 						mod,
 						p.wasmRuntime.InstanceCacheEnabled(),
 						inputs,
@@ -544,6 +582,7 @@ func (p *Pipeline) BuildModuleExecutors(ctx context.Context) error {
 						ctx,
 						module.Name,
 						modulesInitBlocks[module.Name],
+						modulesEndBlocks[module.Name],
 						mod,
 						p.wasmRuntime.InstanceCacheEnabled(),
 						inputs,
@@ -562,6 +601,7 @@ func (p *Pipeline) BuildModuleExecutors(ctx context.Context) error {
 						ctx,
 						module.Name,
 						modulesInitBlocks[module.Name],
+						modulesEndBlocks[module.Name],
 						mod,
 						p.wasmRuntime.InstanceCacheEnabled(),
 						inputs,
@@ -605,6 +645,7 @@ func returnModuleDataOutputs(
 	clock *pbsubstreams.Clock,
 	cursor *bstream.Cursor,
 	mapModuleOutput *pbsubstreamsrpc.MapModuleOutput,
+	mapModuleOutputs []*pbsubstreamsrpc.MapModuleOutput,
 	extraMapModuleOutputs []*pbsubstreamsrpc.MapModuleOutput,
 	extraStoreModuleOutputs []*pbsubstreamsrpc.StoreModuleOutput,
 	respFunc substreams.ResponseFunc,
@@ -619,6 +660,7 @@ func returnModuleDataOutputs(
 	out := &pbsubstreamsrpc.BlockScopedData{
 		Clock:             clock,
 		Output:            mapModuleOutput,
+		Outputs:           mapModuleOutputs,
 		DebugMapOutputs:   extraMapModuleOutputs,
 		DebugStoreOutputs: extraStoreModuleOutputs,
 		Cursor:            cursor.ToOpaque(),
@@ -637,7 +679,7 @@ func (p *Pipeline) renderWasmInputs(module *pbsubstreams.Module) (out []wasm.Arg
 	for _, input := range module.Inputs {
 		switch in := input.Input.(type) {
 		case *pbsubstreams.Module_Input_Params_:
-			out = append(out, wasm.NewParamsInput(input.GetParams().GetValue()))
+			out = append(out, wasm.NewParamsInput(string(input.GetParams().ModuleValue())))
 		case *pbsubstreams.Module_Input_Map_:
 			out = append(out, wasm.NewMapInput(in.Map.ModuleName, p.execGraph.ModulesInitBlocks()[in.Map.ModuleName]))
 		case *pbsubstreams.Module_Input_Store_:
@@ -94,6 +94,7 @@ func mapTestExecutor(t *testing.T, ctx context.Context, name string) *exec.Mappe
 			ctx,
 			name,
 			0,
+			0,
 			module,
 			false, // could exercice with cache enabled too
 			[]wasm.Argument{
@@ -176,6 +176,7 @@ func (p *Pipeline) processBlock(
 func (p *Pipeline) handleStepStalled(clock *pbsubstreams.Clock) error {
 	p.execOutputCache.HandleStalled(clock)
 	p.forkHandler.removeReversibleOutput(clock.Id)
+	p.stores.removeRetainUndo(clock)
 	return nil
 }
 
@@ -184,6 +185,7 @@ func (p *Pipeline) handleStepUndo(clock *pbsubstreams.Clock, cursor *bstream.Cur
 	if err := p.forkHandler.handleUndo(clock); err != nil {
 		return fmt.Errorf("reverting outputs: %w", err)
 	}
+	p.stores.undoRetainStores(clock)
 
 	if bstream.EqualsBlockRefs(p.insideReorgUpTo, reorgJunctionBlock) {
 		return nil
@@ -217,6 +219,7 @@ func (p *Pipeline) handleStepFinal(clock *pbsubstreams.Clock) error {
 		return fmt.Errorf("exec output cache: handle final: %w", err)
 	}
 	p.forkHandler.removeReversibleOutput(clock.Id)
+	p.stores.removeRetainUndo(clock)
 	return nil
 }
 
@@ -252,6 +255,8 @@ func (p *Pipeline) handleStepNew(ctx context.Context, clock *pbsubstreams.Clock,
 
 	logger := reqctx.Logger(ctx)
 
+	p.stores.retainStores(clock)
+
 	if err := p.runPreBlockHooks(ctx, clock); err != nil {
 		return fmt.Errorf("pre block hook: %w", err)
 	}
@@ -273,18 +278,20 @@ func (p *Pipeline) handleStepNew(ctx context.Context, clock *pbsubstreams.Clock,
 
 		// LIVE and DEV mode always receive module data outputs, even when they are empty
 		// so they can follow progress (and dev also gets debug output...)
-		mapModuleOutput := p.mapModuleOutput
-		if mapModuleOutput == nil && !reqDetails.IsTier2Request {
-			mapModuleOutput = &pbsubstreamsrpc.MapModuleOutput{
-				Name:      reqDetails.OutputModule,
-				MapOutput: &anypb.Any{},
+		mapModuleOutput := p.requestedModuleOutput(reqDetails, reqDetails.OutputModule)
+
+		var mapModuleOutputs []*pbsubstreamsrpc.MapModuleOutput
+		if len(reqDetails.OutputModules) > 1 {
+			for _, outputModule := range reqDetails.OutputModules {
+				mapModuleOutputs = append(mapModuleOutputs, p.requestedModuleOutput(reqDetails, outputModule))
 			}
 		}
-		if err = returnModuleDataOutputs(clock, cursor, mapModuleOutput, p.extraMapModuleOutputs, p.extraStoreModuleOutputs, p.respFunc, logger); err != nil {
+		if err = returnModuleDataOutputs(clock, cursor, mapModuleOutput, mapModuleOutputs, p.extraMapModuleOutputs, p.extraStoreModuleOutputs, p.respFunc, logger); err != nil {
 			return fmt.Errorf("failed to return module data output: %w", err)
 		}
 	}
 
+	p.stores.takeRetainUndo(clock)
 	p.stores.resetStores()
 	logger.Debug("block processed", zap.Uint64("block_num", clock.Number))
 	return nil
@@ -294,7 +301,7 @@ func (p *Pipeline) executeModules(ctx context.Context, execOutput execout.Execut
 	ctx, span := reqctx.WithModuleExecutionSpan(ctx, "modules_executions")
 	defer span.EndWithErr(&err)
 
-	p.mapModuleOutput = nil
+	p.mapModuleOutputs = nil
 	p.extraMapModuleOutputs = nil
 	p.extraStoreModuleOutputs = nil
 	blockNum := execOutput.Clock().Number
@@ -420,10 +427,26 @@ func (p *Pipeline) applyExecutionResult(ctx context.Context, executor exec.Modul
 	return nil
 }
 
+// requestedModuleOutput returns the output of `moduleName` for the current block, an empty
+// one outside of tier2 when the module did not output anything.
+func (p *Pipeline) requestedModuleOutput(reqDetails *reqctx.RequestDetails, moduleName string) *pbsubstreamsrpc.MapModuleOutput {
+	mapModuleOutput := p.mapModuleOutputs[moduleName]
+	if mapModuleOutput == nil && !reqDetails.IsTier2Request {
+		mapModuleOutput = &pbsubstreamsrpc.MapModuleOutput{
+			Name:      moduleName,
+			MapOutput: &anypb.Any{},
+		}
+	}
+	return mapModuleOutput
+}
+
 // this will be sent to the requestor
 func (p *Pipeline) saveModuleOutput(output *pbssinternal.ModuleOutput, moduleName string, isProduction bool) {
 	if p.isOutputModule(moduleName) {
-		p.mapModuleOutput = toRPCMapModuleOutputs(output)
+		if p.mapModuleOutputs == nil {
+			p.mapModuleOutputs = make(map[string]*pbsubstreamsrpc.MapModuleOutput)
+		}
+		p.mapModuleOutputs[moduleName] = toRPCMapModuleOutputs(output)
 		return
 	}
 	if isProduction {
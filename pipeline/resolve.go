@@ -23,12 +23,12 @@ type getBlockFunc func() (uint64, error)
 
 // if some stores need to gather data from a block below startBlock,
 // we return the lowest block number required, else nil
-func reprocStateRequired(startBlock uint64, outputModule string, modules []*pbsubstreams.Module) (*uint64, error) {
+func reprocStateRequired(startBlock uint64, outputModules []string, modules []*pbsubstreams.Module) (*uint64, error) {
 	graph, err := manifest.NewModuleGraph(modules)
 	if err != nil {
 		return nil, err
 	}
-	requiredStores, err := graph.StoresDownTo(outputModule)
+	requiredStores, err := graph.StoresDownTo(outputModules...)
 	if err != nil {
 		return nil, err
 	}
@@ -52,12 +52,15 @@ func BuildRequestDetails(
 	segmentSize uint64) (req *reqctx.RequestDetails, undoSignal *pbsubstreamsrpc.BlockUndoSignal, err error) {
 	req = &reqctx.RequestDetails{
 		Modules:                             request.Modules,
-		OutputModule:                        request.OutputModule,
+		OutputModules:                       request.OutputModuleNames(),
 		DebugInitialStoreSnapshotForModules: request.DebugInitialStoreSnapshotForModules,
 		ProductionMode:                      request.ProductionMode,
 		StopBlockNum:                        request.StopBlockNum,
 		UniqueID:                            nextUniqueID(),
 	}
+	if len(req.OutputModules) != 0 {
+		req.OutputModule = req.OutputModules[0]
+	}
 
 	req.ResolvedStartBlockNum, req.ResolvedCursor, undoSignal, err = resolveStartBlockNum(ctx, request, resolveCursor, getHeadBlock)
 	if err != nil {
@@ -69,7 +72,7 @@ func BuildRequestDetails(
 		x := uint64(0)
 		stateRequiredAt = &x // FIXME this is for test compatibility, it never happens in real life
 	} else {
-		stateRequiredAt, err = reprocStateRequired(req.ResolvedStartBlockNum, request.OutputModule, request.Modules.Modules)
+		stateRequiredAt, err = reprocStateRequired(req.ResolvedStartBlockNum, req.OutputModules, request.Modules.Modules)
 		if err != nil {
 			return nil, nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid modules: %w", err))
 		}
@@ -100,6 +103,7 @@ func BuildRequestDetailsFromSubrequest(request *pbssinternal.ProcessRangeRequest
 	req = &reqctx.RequestDetails{
 		Modules:               request.Modules,
 		OutputModule:          request.OutputModule,
+		OutputModules:         request.OutputModuleNames(),
 		ProductionMode:        true,
 		IsTier2Request:        true,
 		Tier2Stage:            int(request.Stage),
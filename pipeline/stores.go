@@ -9,6 +9,7 @@ import (
 
 	"github.com/streamingfast/substreams/block"
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/pipeline/exec"
 	"github.com/streamingfast/substreams/reqctx"
 	"github.com/streamingfast/substreams/storage/store"
@@ -18,8 +19,11 @@ type Stores struct {
 	logger         *zap.Logger
 	isTier2Request bool // means we're processing a tier2 request
 	bounder        *storeBoundary
-	configs        store.ConfigMap
-	StoreMap       store.Map
+	retainBounder  *storeBoundary // nil unless a store has retain blocks, in a tier1 request
+	// retainUndos are the changes to the retain state of the stores made by the reversible blocks, by block ID
+	retainUndos map[string]*retainUndo
+	configs     store.ConfigMap
+	StoreMap    store.Map
 	// DEPRECATED: we don't need to report back, these file names are now implicitly conveyed from
 	// tier1 to tier2.
 	partialsWritten block.Ranges // when backprocessing, to report back to orchestrator
@@ -36,10 +40,25 @@ func NewStores(ctx context.Context, storeConfigs store.ConfigMap, storeSnapshotS
 		tier = "tier2"
 	}
 	bounder := NewStoreBoundary(storeSnapshotSaveInterval, requestStartBlockNum, stopBlockNum)
+
+	// tier2 stores are only retained when squashed, the linear processing of tier1
+	// retains its stores on the same boundaries.
+	var retainBounder *storeBoundary
+	if !isTier2Request {
+		for _, conf := range storeConfigs {
+			if conf.RetainBlocks() != 0 {
+				retainBounder = NewStoreBoundary(storeSnapshotSaveInterval, requestStartBlockNum, stopBlockNum)
+				break
+			}
+		}
+	}
+
 	return &Stores{
 		configs:        storeConfigs,
 		isTier2Request: isTier2Request,
 		bounder:        bounder,
+		retainBounder:  retainBounder,
+		retainUndos:    make(map[string]*retainUndo),
 		tier:           tier,
 		logger:         reqctx.Logger(ctx),
 		storesToWrite:  storesToWrite,
@@ -48,6 +67,13 @@ func NewStores(ctx context.Context, storeConfigs store.ConfigMap, storeSnapshotS
 
 func (s *Stores) SetStoreMap(storeMap store.Map) {
 	s.StoreMap = storeMap
+	if s.retainBounder != nil {
+		for _, st := range storeMap.All() {
+			if fullKV, ok := st.(*store.FullKV); ok {
+				fullKV.TrackRetainUndo()
+			}
+		}
+	}
 }
 
 func (s *Stores) resetStores() {
@@ -90,6 +116,70 @@ func (s *Stores) saveStoresSnapshots(ctx context.Context, stage int, boundaryBlo
 	return nil
 }
 
+// retainUndo are the changes to the retain state of the stores made while processing a block.
+type retainUndo struct {
+	nextBoundary uint64 // next retain boundary before the block
+	stores       []*store.RetainUndo
+}
+
+// retainStores drops the keys past their retain blocks from the full stores at each
+// boundary reached by the block, before it is processed.
+func (s *Stores) retainStores(clock *pbsubstreams.Clock) {
+	if s.retainBounder == nil || s.StoreMap == nil {
+		return
+	}
+
+	s.retainUndos[clock.Id] = &retainUndo{nextBoundary: s.retainBounder.nextBoundary}
+	for s.retainBounder.OverBoundary(clock.Number) {
+		for _, st := range s.StoreMap.All() {
+			if fullKV, ok := st.(*store.FullKV); ok {
+				fullKV.Retain(s.retainBounder.nextBoundary)
+			}
+		}
+		s.retainBounder.BumpBoundary()
+	}
+}
+
+// takeRetainUndo keeps the changes made to the retain state of the stores while
+// processing the block, to undo them along with its deltas.
+func (s *Stores) takeRetainUndo(clock *pbsubstreams.Clock) {
+	undo, found := s.retainUndos[clock.Id]
+	if !found {
+		return
+	}
+
+	for _, st := range s.StoreMap.All() {
+		if fullKV, ok := st.(*store.FullKV); ok {
+			if storeUndo := fullKV.TakeRetainUndo(); storeUndo != nil {
+				undo.stores = append(undo.stores, storeUndo)
+			}
+		}
+	}
+	if len(undo.stores) == 0 && undo.nextBoundary == s.retainBounder.nextBoundary {
+		delete(s.retainUndos, clock.Id)
+	}
+}
+
+// undoRetainStores restores the retain state of the stores and the next retain
+// boundary as they were before the block, once its deltas have been reverted.
+func (s *Stores) undoRetainStores(clock *pbsubstreams.Clock) {
+	undo, found := s.retainUndos[clock.Id]
+	if !found {
+		return
+	}
+
+	for _, storeUndo := range undo.stores {
+		storeUndo.Undo()
+	}
+	s.retainBounder.nextBoundary = undo.nextBoundary
+	delete(s.retainUndos, clock.Id)
+}
+
+// removeRetainUndo is called once the block cannot be undone anymore.
+func (s *Stores) removeRetainUndo(clock *pbsubstreams.Clock) {
+	delete(s.retainUndos, clock.Id)
+}
+
 func (s *Stores) storesHandleUndo(moduleOutput *pbssinternal.ModuleOutput) {
 	if s, found := s.StoreMap.Get(moduleOutput.ModuleName); found {
 		if deltaStore, ok := s.(store.DeltaAccessor); ok {
@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+)
+
+type retainTestBlock struct {
+	clock *pbsubstreams.Clock
+	sets  map[string]string
+}
+
+func newRetainTestBlock(num uint64, fork string, sets map[string]string) retainTestBlock {
+	return retainTestBlock{
+		clock: &pbsubstreams.Clock{Number: num, Id: fmt.Sprintf("%d%s", num, fork)},
+		sets:  sets,
+	}
+}
+
+func newRetainTestStores(t *testing.T) (*Stores, *store.FullKV) {
+	config, err := store.NewConfig("store_a", 0, "abc", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	config.SetRetainBlocks(2000)
+
+	stores := NewStores(context.Background(), store.ConfigMap{"store_a": config}, 1000, 0, 0, false, nil)
+	fullKV := config.NewFullKV(zap.NewNop())
+	stores.SetStoreMap(store.Map{"store_a": fullKV})
+	return stores, fullKV
+}
+
+// processRetainTestBlock processes the block as handleStepNew does, returning its module output
+func processRetainTestBlock(t *testing.T, stores *Stores, fullKV *store.FullKV, block retainTestBlock) *pbssinternal.ModuleOutput {
+	t.Helper()
+
+	stores.retainStores(block.clock)
+	for key, value := range block.sets {
+		fullKV.Set(0, key, value)
+	}
+	require.NoError(t, fullKV.Flush())
+	output := &pbssinternal.ModuleOutput{
+		ModuleName: "store_a",
+		Data:       &pbssinternal.ModuleOutput_StoreDeltas{StoreDeltas: &pbsubstreams.StoreDeltas{StoreDeltas: fullKV.GetDeltas()}},
+	}
+	stores.takeRetainUndo(block.clock)
+	stores.resetStores()
+	return output
+}
+
+func storeContent(t *testing.T, fullKV *store.FullKV) map[string]string {
+	t.Helper()
+
+	content := map[string]string{}
+	require.NoError(t, fullKV.Iter(func(key string, value []byte) error {
+		content[key] = string(value)
+		return nil
+	}))
+	return content
+}
+
+func TestStores_UndoRetainAcrossBoundary(t *testing.T) {
+	common := []retainTestBlock{
+		newRetainTestBlock(500, "a", map[string]string{"a": "1", "b": "1"}),
+		newRetainTestBlock(1500, "a", map[string]string{"c": "1"}),
+	}
+	forked := []retainTestBlock{
+		newRetainTestBlock(2999, "a", map[string]string{"b": "2"}),
+		newRetainTestBlock(3000, "a", map[string]string{"d": "1"}),
+	}
+	canonical := []retainTestBlock{
+		newRetainTestBlock(2999, "b", map[string]string{"c": "2"}),
+		newRetainTestBlock(3000, "b", nil),
+		newRetainTestBlock(5000, "b", map[string]string{"e": "1"}),
+	}
+
+	stores, fullKV := newRetainTestStores(t)
+	for _, block := range common {
+		processRetainTestBlock(t, stores, fullKV, block)
+	}
+
+	var outputs []*pbssinternal.ModuleOutput
+	var contents []map[string]string
+	for _, block := range forked {
+		contents = append(contents, storeContent(t, fullKV))
+		outputs = append(outputs, processRetainTestBlock(t, stores, fullKV, block))
+	}
+	assert.Equal(t, map[string]string{"b": "2", "c": "1", "d": "1"}, storeContent(t, fullKV), "key 'a' dropped at boundary 3000")
+
+	// the undo signals come from the latest block to the oldest one
+	for i := len(forked) - 1; i >= 0; i-- {
+		stores.storesHandleUndo(outputs[i])
+		stores.undoRetainStores(forked[i].clock)
+		assert.Equal(t, contents[i], storeContent(t, fullKV), "after undoing block %s", forked[i].clock.Id)
+	}
+	assert.Len(t, stores.retainUndos, len(common))
+
+	linearStores, linearKV := newRetainTestStores(t)
+	for _, block := range common {
+		processRetainTestBlock(t, linearStores, linearKV, block)
+	}
+	for _, block := range canonical {
+		processRetainTestBlock(t, stores, fullKV, block)
+		processRetainTestBlock(t, linearStores, linearKV, block)
+		assert.Equal(t, storeContent(t, linearKV), storeContent(t, fullKV), "after block %s", block.clock.Id)
+	}
+	assert.Equal(t, map[string]string{"e": "1"}, storeContent(t, fullKV))
+
+	for _, block := range append(common, canonical...) {
+		stores.removeRetainUndo(block.clock)
+	}
+	assert.Empty(t, stores.retainUndos)
+}
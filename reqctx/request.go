@@ -13,6 +13,7 @@ type RequestDetails struct {
 
 	DebugInitialStoreSnapshotForModules []string
 	OutputModule                        string
+	OutputModules                       []string // all the requested output modules, starting with OutputModule
 	// What the user requested, derived from either the Request.StartBlockNum or Request.Cursor
 	ResolvedStartBlockNum uint64
 	ResolvedCursor        string
@@ -33,6 +34,11 @@ func (d *RequestDetails) UniqueIDString() string {
 }
 
 func (d *RequestDetails) IsOutputModule(modName string) bool {
+	for _, outputModule := range d.OutputModules {
+		if modName == outputModule {
+			return true
+		}
+	}
 	return modName == d.OutputModule
 }
 
@@ -0,0 +1,10 @@
+package schemas
+
+import (
+	_ "embed"
+)
+
+// ManifestSchema is the JSON Schema of `substreams.yaml` manifests.
+//
+//go:embed manifest-schema.json
+var ManifestSchema []byte
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// outputResponseFunc wraps `respFunc` to apply the request's output filter and output
+// field mask to the BlockScopedData, returning `respFunc` as is when neither is set.
+func outputResponseFunc(ctx context.Context, request *pbsubstreamsrpc.Request, respFunc substreams.ResponseFunc) (substreams.ResponseFunc, error) {
+	if request.OutputFilter == nil && request.OutputFieldMask == nil {
+		return respFunc, nil
+	}
+	if request.NoopMode {
+		return nil, fmt.Errorf("output filter and output field mask cannot be used in noop mode")
+	}
+
+	files, err := desc.CreateFileDescriptors(request.OutputProtoFiles)
+	if err != nil {
+		return nil, fmt.Errorf("loading output protobuf definitions: %w", err)
+	}
+	outputModules := request.OutputModuleNames()
+
+	if request.OutputFieldMask != nil {
+		fieldMasks := make(outputFieldMasks, len(outputModules))
+		for _, outputModule := range outputModules {
+			msgDesc, err := outputMessageDescriptor(request.Modules, files, outputModule)
+			if err != nil {
+				return nil, err
+			}
+			fieldMask, err := newOutputFieldMask(request.OutputFieldMask, msgDesc)
+			if err != nil {
+				return nil, fmt.Errorf("output field mask: %w", err)
+			}
+			fieldMasks[outputModule] = fieldMask
+		}
+		respFunc = fieldMasks.responseFunc(respFunc)
+	}
+
+	// wrapped last so the filter sees the whole message, before the field mask is applied
+	if request.OutputFilter != nil {
+		msgDesc, err := outputMessageDescriptor(request.Modules, files, outputModules[0])
+		if err != nil {
+			return nil, err
+		}
+		filter, err := newOutputFilter(ctx, request.OutputFilter.Expression, msgDesc)
+		if err != nil {
+			return nil, fmt.Errorf("output filter: %w", err)
+		}
+		respFunc = filter.responseFunc(respFunc)
+	}
+
+	return respFunc, nil
+}
+
+// outputMessageDescriptor finds the type of the output module's message in the request's
+// `output_proto_files`.
+func outputMessageDescriptor(modules *pbsubstreams.Modules, files map[string]*desc.FileDescriptor, outputModule string) (protoreflect.MessageDescriptor, error) {
+	var outputType string
+	for _, module := range modules.Modules {
+		if module.Name == outputModule {
+			outputType = module.Output.GetType()
+		}
+	}
+	if !strings.HasPrefix(outputType, "proto:") {
+		return nil, fmt.Errorf("output type %q of module %q is not a protobuf message", outputType, outputModule)
+	}
+	msgType := strings.TrimPrefix(outputType, "proto:")
+
+	for _, file := range files {
+		if msgDesc := file.FindMessage(msgType); msgDesc != nil {
+			return msgDesc.UnwrapMessage(), nil
+		}
+	}
+	return nil, fmt.Errorf("protobuf message type %q not found in output protobuf definitions", msgType)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+)
+
+// outputFieldMask clears the fields of an output module's message that are not listed
+// in the request's `output_field_mask`, before the BlockScopedData are sent.
+type outputFieldMask struct {
+	msgDesc protoreflect.MessageDescriptor
+	fields  fieldMaskNode
+}
+
+// fieldMaskNode holds the fields kept in a message, by number. A field whose node is nil
+// is kept whole, otherwise only the listed fields of its message (or of each of its
+// elements, for repeated fields and maps) are kept.
+type fieldMaskNode map[protoreflect.FieldNumber]fieldMaskNode
+
+func newOutputFieldMask(mask *fieldmaskpb.FieldMask, msgDesc protoreflect.MessageDescriptor) (*outputFieldMask, error) {
+	if len(mask.Paths) == 0 {
+		return nil, fmt.Errorf("no paths")
+	}
+
+	fields := fieldMaskNode{}
+	for _, path := range mask.Paths {
+		if err := fields.add(msgDesc, path); err != nil {
+			return nil, fmt.Errorf("path %q: %w", path, err)
+		}
+	}
+
+	return &outputFieldMask{
+		msgDesc: msgDesc,
+		fields:  fields,
+	}, nil
+}
+
+func (n fieldMaskNode) add(msgDesc protoreflect.MessageDescriptor, path string) error {
+	node := n
+	segments := strings.Split(path, ".")
+	for i, name := range segments {
+		field := msgDesc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			field = msgDesc.Fields().ByJSONName(name)
+		}
+		if field == nil {
+			return fmt.Errorf("field %q not found in message %s", name, msgDesc.FullName())
+		}
+
+		if i == len(segments)-1 {
+			node[field.Number()] = nil
+			return nil
+		}
+
+		child, found := node[field.Number()]
+		if found && child == nil {
+			// a shorter path already keeps the whole field
+			return nil
+		}
+
+		msgDesc = field.Message()
+		if field.IsMap() {
+			msgDesc = field.MapValue().Message()
+		}
+		if msgDesc == nil {
+			return fmt.Errorf("field %q is not a message", name)
+		}
+
+		if child == nil {
+			child = fieldMaskNode{}
+			node[field.Number()] = child
+		}
+		node = child
+	}
+	return nil
+}
+
+// prune clears the fields of `msg` that are not in the node, and its unknown fields
+func (n fieldMaskNode) prune(msg protoreflect.Message) {
+	var cleared []protoreflect.FieldDescriptor
+	msg.Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		child, found := n[field.Number()]
+		switch {
+		case !found:
+			cleared = append(cleared, field)
+		case child == nil:
+		case field.IsList():
+			list := msg.Mutable(field).List()
+			for i := 0; i < list.Len(); i++ {
+				child.prune(list.Get(i).Message())
+			}
+		case field.IsMap():
+			msg.Mutable(field).Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				child.prune(value.Message())
+				return true
+			})
+		default:
+			child.prune(msg.Mutable(field).Message())
+		}
+		return true
+	})
+
+	for _, field := range cleared {
+		msg.Clear(field)
+	}
+	msg.SetUnknown(nil)
+}
+
+// apply returns a copy of `output` holding only the fields of the mask
+func (m *outputFieldMask) apply(output *pbsubstreamsrpc.MapModuleOutput) (*pbsubstreamsrpc.MapModuleOutput, error) {
+	mapOutput := output.GetMapOutput()
+	if len(mapOutput.GetValue()) == 0 {
+		return output, nil
+	}
+
+	msg := dynamicpb.NewMessage(m.msgDesc)
+	if err := proto.Unmarshal(mapOutput.Value, msg); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", m.msgDesc.FullName(), err)
+	}
+	m.fields.prune(msg)
+
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", m.msgDesc.FullName(), err)
+	}
+
+	return &pbsubstreamsrpc.MapModuleOutput{
+		Name:      output.Name,
+		MapOutput: &anypb.Any{TypeUrl: mapOutput.TypeUrl, Value: value},
+		DebugInfo: output.DebugInfo,
+	}, nil
+}
+
+// outputFieldMasks holds the field mask of each of the requested output modules, by name
+type outputFieldMasks map[string]*outputFieldMask
+
+func (m outputFieldMasks) responseFunc(respFunc substreams.ResponseFunc) substreams.ResponseFunc {
+	return func(respAny substreams.ResponseFromAnyTier) error {
+		data := respAny.(*pbsubstreamsrpc.Response).GetBlockScopedData()
+		if data == nil || data.Output == nil {
+			return respFunc(respAny)
+		}
+
+		output, err := m.apply(data.Output, data.Clock.GetNumber())
+		if err != nil {
+			return err
+		}
+		for i, other := range data.Outputs {
+			if other.Name == output.Name {
+				data.Outputs[i] = output
+				continue
+			}
+			if data.Outputs[i], err = m.apply(other, data.Clock.GetNumber()); err != nil {
+				return err
+			}
+		}
+		data.Output = output
+
+		return respFunc(respAny)
+	}
+}
+
+func (m outputFieldMasks) apply(output *pbsubstreamsrpc.MapModuleOutput, blockNum uint64) (*pbsubstreamsrpc.MapModuleOutput, error) {
+	fieldMask, found := m[output.Name]
+	if !found {
+		return output, nil
+	}
+
+	masked, err := fieldMask.apply(output)
+	if err != nil {
+		return nil, fmt.Errorf("applying field mask to output of module %q at block %d: %w", output.Name, blockNum, err)
+	}
+	return masked, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestOutputFieldMask(t *testing.T) {
+	modules := &pbsubstreams.Modules{
+		Modules: []*pbsubstreams.Module{
+			{Name: "map_a", InitialBlock: 10, Inputs: []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "map_b"}}}}},
+			{Name: "store_b", InitialBlock: 200, Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{UpdatePolicy: pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, ValueType: "int64"}}},
+		},
+		Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte{0xca, 0xfe}}},
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		paths    []string
+		expected *pbsubstreams.Modules
+	}{
+		{
+			"repeated message fields",
+			"",
+			[]string{"modules.name", "modules.kind_store.update_policy"},
+			&pbsubstreams.Modules{Modules: []*pbsubstreams.Module{
+				{Name: "map_a"},
+				{Name: "store_b", Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{UpdatePolicy: pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD}}},
+			}},
+		},
+		{
+			"whole field wins over sub-fields",
+			"",
+			[]string{"binaries.type", "binaries", "binaries.content"},
+			&pbsubstreams.Modules{Binaries: modules.Binaries},
+		},
+		{
+			"json names",
+			"",
+			[]string{"modules.initialBlock"},
+			&pbsubstreams.Modules{Modules: []*pbsubstreams.Module{{InitialBlock: 10}, {InitialBlock: 200}}},
+		},
+		{
+			"filter on the whole message",
+			"binaries[].type == wasm/rust-v1",
+			[]string{"modules.inputs.map.module_name"},
+			&pbsubstreams.Modules{Modules: []*pbsubstreams.Module{
+				{Inputs: []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "map_b"}}}}},
+				{},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := outputFilterRequest("proto:sf.substreams.v1.Modules", test.filter)
+			if test.filter == "" {
+				request.OutputFilter = nil
+			}
+			request.OutputFieldMask = &fieldmaskpb.FieldMask{Paths: test.paths}
+			request.OutputModules = []string{"map_a", "map_b"}
+			request.Modules.Modules = append(request.Modules.Modules, &pbsubstreams.Module{Name: "map_b", Output: request.Modules.Modules[0].Output})
+
+			var sent []*pbsubstreamsrpc.BlockScopedData
+			respFunc, err := outputResponseFunc(context.Background(), request, func(resp substreams.ResponseFromAnyTier) error {
+				sent = append(sent, resp.(*pbsubstreamsrpc.Response).GetBlockScopedData())
+				return nil
+			})
+			require.NoError(t, err)
+
+			value, err := proto.Marshal(modules)
+			require.NoError(t, err)
+			output := &pbsubstreamsrpc.MapModuleOutput{Name: "map_a", MapOutput: &anypb.Any{TypeUrl: "type.googleapis.com/sf.substreams.v1.Modules", Value: value}}
+			other := &pbsubstreamsrpc.MapModuleOutput{Name: "map_b", MapOutput: &anypb.Any{TypeUrl: "type.googleapis.com/sf.substreams.v1.Modules", Value: value}}
+			require.NoError(t, respFunc(substreams.NewBlockScopedDataResponse(&pbsubstreamsrpc.BlockScopedData{
+				Output:  output,
+				Outputs: []*pbsubstreamsrpc.MapModuleOutput{output, other},
+			})))
+
+			require.Len(t, sent, 1)
+			data := sent[0]
+			assert.Equal(t, "type.googleapis.com/sf.substreams.v1.Modules", data.Output.MapOutput.TypeUrl)
+			actual := &pbsubstreams.Modules{}
+			require.NoError(t, proto.Unmarshal(data.Output.MapOutput.Value, actual))
+			assert.True(t, proto.Equal(test.expected, actual), "unexpected output %s", actual)
+
+			assert.Same(t, data.Output, data.Outputs[0])
+			actualOther := &pbsubstreams.Modules{}
+			require.NoError(t, proto.Unmarshal(data.Outputs[1].MapOutput.Value, actualOther))
+			assert.True(t, proto.Equal(test.expected, actualOther), "unexpected other output %s", actualOther)
+
+			assert.Equal(t, value, output.MapOutput.Value, "the original output is left untouched")
+			assert.Equal(t, value, other.MapOutput.Value, "the original other output is left untouched")
+		})
+	}
+}
+
+func TestOutputFieldMask_Invalid(t *testing.T) {
+	for _, test := range []struct {
+		paths       []string
+		expectedErr string
+	}{
+		{nil, "output field mask: no paths"},
+		{[]string{"modules.unknown"}, `output field mask: path "modules.unknown": field "unknown" not found in message sf.substreams.v1.Module`},
+		{[]string{"modules.name.first"}, `output field mask: path "modules.name.first": field "name" is not a message`},
+	} {
+		request := outputFilterRequest("proto:sf.substreams.v1.Modules", "")
+		request.OutputFilter = nil
+		request.OutputFieldMask = &fieldmaskpb.FieldMask{Paths: test.paths}
+
+		_, err := outputResponseFunc(context.Background(), request, nil)
+		assert.EqualError(t, err, test.expectedErr)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	"github.com/streamingfast/substreams/sqe"
+)
+
+// outputFilter drops the BlockScopedData whose output module's output does not match
+// the request's `output_filter`, before they are sent.
+type outputFilter struct {
+	filter *sqe.MessageFilter
+}
+
+func newOutputFilter(ctx context.Context, expression string, msgDesc protoreflect.MessageDescriptor) (*outputFilter, error) {
+	expr, err := sqe.ParseOutputFilter(ctx, expression)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", expression, err)
+	}
+
+	filter, err := sqe.NewMessageFilter(expr, msgDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outputFilter{filter: filter}, nil
+}
+
+// keep returns whether `data` is sent, its output being set and matching the filter.
+func (f *outputFilter) keep(data *pbsubstreamsrpc.BlockScopedData) (bool, error) {
+	output := data.Output.GetMapOutput().GetValue()
+	if len(output) == 0 {
+		return false, nil
+	}
+
+	matched, err := f.filter.MatchBytes(output)
+	if err != nil {
+		return false, fmt.Errorf("filtering output of block %d: %w", data.Clock.GetNumber(), err)
+	}
+	return matched, nil
+}
+
+func (f *outputFilter) responseFunc(respFunc substreams.ResponseFunc) substreams.ResponseFunc {
+	return func(respAny substreams.ResponseFromAnyTier) error {
+		if data := respAny.(*pbsubstreamsrpc.Response).GetBlockScopedData(); data != nil {
+			keep, err := f.keep(data)
+			if err != nil {
+				return err
+			}
+			if !keep {
+				return nil
+			}
+		}
+		return respFunc(respAny)
+	}
+}
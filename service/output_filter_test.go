@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestOutputFilter(t *testing.T) {
+	request := outputFilterRequest("proto:sf.substreams.v1.Modules", `modules[].name == map_a`)
+
+	var sent []uint64
+	respFunc, err := outputResponseFunc(context.Background(), request, func(resp substreams.ResponseFromAnyTier) error {
+		if data := resp.(*pbsubstreamsrpc.Response).GetBlockScopedData(); data != nil {
+			sent = append(sent, data.Clock.Number)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	send := func(blockNum uint64, output proto.Message) {
+		data := &pbsubstreamsrpc.BlockScopedData{
+			Clock:  &pbsubstreams.Clock{Number: blockNum},
+			Output: &pbsubstreamsrpc.MapModuleOutput{Name: "map_a", MapOutput: &anypb.Any{}},
+		}
+		if output != nil {
+			value, err := proto.Marshal(output)
+			require.NoError(t, err)
+			data.Output.MapOutput.Value = value
+		}
+		require.NoError(t, respFunc(substreams.NewBlockScopedDataResponse(data)))
+	}
+
+	send(1, &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{{Name: "map_b"}, {Name: "map_a"}}})
+	send(2, &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{{Name: "map_b"}}})
+	send(3, nil)
+	require.NoError(t, respFunc(&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_Progress{}}))
+	send(4, &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{{Name: "map_a"}}})
+
+	assert.Equal(t, []uint64{1, 4}, sent)
+}
+
+func TestOutputFilter_Invalid(t *testing.T) {
+	_, err := outputResponseFunc(context.Background(), outputFilterRequest("proto:sf.substreams.v1.Modules", `modules[].unknown`), nil)
+	assert.EqualError(t, err, `output filter: field "modules[].unknown": "unknown" not found in message sf.substreams.v1.Module`)
+
+	_, err = outputResponseFunc(context.Background(), outputFilterRequest("proto:sf.substreams.v1.Missing", `modules`), nil)
+	assert.EqualError(t, err, `protobuf message type "sf.substreams.v1.Missing" not found in output protobuf definitions`)
+
+	_, err = outputResponseFunc(context.Background(), outputFilterRequest("bytes", `modules`), nil)
+	assert.EqualError(t, err, `output type "bytes" of module "map_a" is not a protobuf message`)
+
+	_, err = outputResponseFunc(context.Background(), outputFilterRequest("proto:sf.substreams.v1.Modules", `modules &&`), nil)
+	assert.ErrorContains(t, err, `output filter: parse "modules &&"`)
+
+	request := outputFilterRequest("proto:sf.substreams.v1.Modules", `modules`)
+	request.NoopMode = true
+	_, err = outputResponseFunc(context.Background(), request, nil)
+	assert.EqualError(t, err, "output filter and output field mask cannot be used in noop mode")
+}
+
+func outputFilterRequest(outputType string, expression string) *pbsubstreamsrpc.Request {
+	return &pbsubstreamsrpc.Request{
+		OutputModule: "map_a",
+		Modules: &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{{
+			Name:   "map_a",
+			Kind:   &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: outputType}},
+			Output: &pbsubstreams.Module_Output{Type: outputType},
+		}}},
+		OutputFilter:     &pbsubstreamsrpc.OutputFilter{Expression: expression},
+		OutputProtoFiles: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(pbsubstreams.File_sf_substreams_v1_modules_proto)},
+	}
+}
@@ -29,13 +29,21 @@ func TestNewService(runtimeConfig config.RuntimeConfig, linearHandoffBlockNum ui
 			}
 			return 0, fmt.Errorf("no live feed")
 		},
+		tier2RequestParameters: reqctx.Tier2RequestParameters{
+			FirstStreamableBlock: bstream.GetProtocolFirstStreamableBlock,
+		},
 		tracer: nil,
 		logger: zlog,
 	}
 }
 
 func (s *Tier1Service) TestBlocks(ctx context.Context, isSubRequest bool, request *pbsubstreamsrpc.Request, respFunc substreams.ResponseFunc) error {
-	execGraph, err := exec.NewOutputModuleGraph(request.OutputModule, request.ProductionMode, request.Modules, bstream.GetProtocolFirstStreamableBlock)
+	execGraph, err := exec.NewOutputModuleGraph(request.OutputModuleNames(), request.ProductionMode, request.Modules, s.tier2RequestParameters.FirstStreamableBlock)
+	if err != nil {
+		return stream.NewErrInvalidArg(err.Error())
+	}
+
+	respFunc, err = outputResponseFunc(ctx, request, respFunc)
 	if err != nil {
 		return stream.NewErrInvalidArg(err.Error())
 	}
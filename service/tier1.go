@@ -75,7 +75,7 @@ type Tier1Service struct {
 	tier2RequestParameters reqctx.Tier2RequestParameters
 }
 
-func getBlockTypeFromStreamFactory(sf *StreamFactory) (string, error) {
+func getBlockTypeFromStreamFactory(sf *StreamFactory, firstStreamableBlock uint64) (string, error) {
 	var out string
 	ctx := context.Background()
 	stream, err := sf.New(
@@ -84,8 +84,8 @@ func getBlockTypeFromStreamFactory(sf *StreamFactory) (string, error) {
 			out = blk.Payload.TypeUrl
 			return io.EOF
 		}),
-		int64(bstream.GetProtocolFirstStreamableBlock),
-		bstream.GetProtocolFirstStreamableBlock,
+		int64(firstStreamableBlock),
+		firstStreamableBlock,
 		"", false, false, zlog,
 	)
 	if err != nil {
@@ -154,7 +154,7 @@ func NewTier1(
 
 	var err error
 	if blockType == "" {
-		blockType, err = getBlockTypeFromStreamFactory(sf)
+		blockType, err = getBlockTypeFromStreamFactory(sf, tier2RequestParameters.FirstStreamableBlock)
 		if err != nil {
 			return nil, fmt.Errorf("getting block type from stream factory: %w", err)
 		}
@@ -231,12 +231,19 @@ func (s *Tier1Service) Blocks(
 	if err := ValidateTier1Request(request, s.blockType); err != nil {
 		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("validate request: %w", err))
 	}
+	// the first of the output modules identifies the request (module hash, stats, etc.)
+	outputModule := request.OutputModuleNames()[0]
 
-	execGraph, err := exec.NewOutputModuleGraph(request.OutputModule, request.ProductionMode, request.Modules, bstream.GetProtocolFirstStreamableBlock)
+	respFunc, err = outputResponseFunc(ctx, request, respFunc)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("validate request: %w", err))
+	}
+
+	execGraph, err := exec.NewOutputModuleGraph(request.OutputModuleNames(), request.ProductionMode, request.Modules, s.tier2RequestParameters.FirstStreamableBlock)
 	if err != nil {
 		return bsstream.NewErrInvalidArg(err.Error())
 	}
-	outputModuleHash := execGraph.ModuleHashes().Get(request.OutputModule)
+	outputModuleHash := execGraph.ModuleHashes().Get(outputModule)
 
 	moduleNames := make([]string, len(request.Modules.Modules))
 	for i := 0; i < len(moduleNames); i++ {
@@ -257,11 +264,17 @@ func (s *Tier1Service) Blocks(
 		zap.Uint64("stop_block", request.StopBlockNum),
 		zap.String("cursor", request.StartCursor),
 		zap.Strings("modules", moduleNames),
-		zap.String("output_module", request.OutputModule),
+		zap.String("output_module", outputModule),
 		zap.String("output_module_hash", outputModuleHash),
 		zap.Bool("compressed", compressed),
 		zap.Bool("final_blocks_only", request.FinalBlocksOnly),
 	}
+	if request.OutputFilter != nil {
+		fields = append(fields, zap.String("output_filter", request.OutputFilter.Expression))
+	}
+	if request.OutputFieldMask != nil {
+		fields = append(fields, zap.Strings("output_field_mask", request.OutputFieldMask.Paths))
+	}
 	fields = append(fields, zap.Bool("production_mode", request.ProductionMode))
 	fields = append(fields, zap.Bool("noop_mode", request.NoopMode))
 	if auth := dauth.FromContext(ctx); auth != nil {
@@ -346,7 +359,7 @@ func (s *Tier1Service) writePackage(ctx context.Context, request *pbsubstreamsrp
 		return fmt.Errorf("marshalling package: %w", err)
 	}
 
-	moduleStore, err := cacheStore.SubStore(execGraph.ModuleHashes().Get(request.OutputModule))
+	moduleStore, err := cacheStore.SubStore(execGraph.ModuleHashes().Get(execGraph.OutputModule().Name))
 	if err != nil {
 		return fmt.Errorf("getting substore: %w", err)
 	}
@@ -365,7 +378,7 @@ func (s *Tier1Service) writePackage(ctx context.Context, request *pbsubstreamsrp
 var IsValidCacheTag = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`).MatchString
 
 func (s *Tier1Service) blocks(ctx context.Context, request *pbsubstreamsrpc.Request, execGraph *exec.Graph, respFunc substreams.ResponseFunc) error {
-	chainFirstStreamableBlock := bstream.GetProtocolFirstStreamableBlock
+	chainFirstStreamableBlock := s.tier2RequestParameters.FirstStreamableBlock
 	if request.StartBlockNum > 0 && request.StartBlockNum < int64(chainFirstStreamableBlock) {
 		return bsstream.NewErrInvalidArg("invalid start block %d, must be >= %d (the first streamable block of the chain)", request.StartBlockNum, chainFirstStreamableBlock)
 	} else if request.StartBlockNum < 0 && request.StopBlockNum > 0 {
@@ -532,7 +545,7 @@ func (s *Tier1Service) blocks(ctx context.Context, request *pbsubstreamsrpc.Requ
 		zap.String("request_start_cursor", request.StartCursor),
 		zap.String("resolved_cursor", requestDetails.ResolvedCursor),
 		zap.Uint64("max_parallel_jobs", requestDetails.MaxParallelJobs),
-		zap.String("output_module", request.OutputModule),
+		zap.String("output_module", requestDetails.OutputModule),
 	)
 
 	if err := pipe.Init(ctx); err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
@@ -248,7 +249,7 @@ func (s *Tier2Service) processRange(ctx context.Context, request *pbssinternal.P
 		return err
 	}
 
-	execGraph, err := exec.NewOutputModuleGraph(request.OutputModule, true, request.Modules, request.FirstStreamableBlock)
+	execGraph, err := exec.NewOutputModuleGraph(request.OutputModuleNames(), true, request.Modules, request.FirstStreamableBlock)
 	if err != nil {
 		return stream.NewErrInvalidArg(err.Error())
 	}
@@ -293,7 +294,7 @@ func (s *Tier2Service) processRange(ctx context.Context, request *pbssinternal.P
 		return fmt.Errorf("configuring indexes: %w", err)
 	}
 
-	executionPlan, err := GetExecutionPlan(ctx, logger, execGraph, request.Stage, startBlock, stopBlock, request.OutputModule, execOutputConfigs, indexConfigs, storeConfigs)
+	executionPlan, err := GetExecutionPlan(ctx, logger, execGraph, request.Stage, startBlock, stopBlock, request.OutputModuleNames(), execOutputConfigs, indexConfigs, storeConfigs)
 	if err != nil {
 		return fmt.Errorf("creating execution plan: %w", err)
 	}
@@ -624,7 +625,7 @@ func GetExecutionPlan(
 	stage uint32,
 	startBlock uint64,
 	stopBlock uint64,
-	outputModule string,
+	outputModules []string,
 	execoutConfigs *execout.Configs,
 	indexConfigs *index.Configs,
 	storeConfigs store.ConfigMap,
@@ -649,6 +650,7 @@ func GetExecutionPlan(
 			stageUsedModulesName[mod.Name] = true
 		}
 	}
+	existingOutputs := 0
 	for _, mod := range usedModules {
 		if mod.InitialBlock >= stopBlock {
 			continue
@@ -683,9 +685,12 @@ func GetExecutionPlan(
 			}
 			existingExecOuts[name] = file
 
-			if runningLastStage && name == outputModule {
-				logger.Info("found existing exec output for output_module, skipping run", zap.String("output_module", name))
-				return nil, nil
+			if runningLastStage && slices.Contains(outputModules, name) {
+				existingOutputs++
+				if existingOutputs == len(outputModules) {
+					logger.Info("found existing exec output for output_module, skipping run", zap.Strings("output_modules", outputModules))
+					return nil, nil
+				}
 			}
 
 		case pbsubstreams.ModuleKindStore:
@@ -19,7 +19,7 @@ func ValidateTier1Request(request *pbsubstreamsrpc.Request, blockType string) er
 		return fmt.Errorf("validate tier1 request: %s", err)
 	}
 
-	err := validateRequest(request.Modules.Binaries, request.Modules, request.OutputModule, blockType)
+	err := validateRequest(request.Modules.Binaries, request.Modules, request.OutputModuleNames(), blockType)
 	if err != nil {
 		return err
 	}
@@ -32,7 +32,7 @@ func ValidateTier2Request(request *pbssinternal.ProcessRangeRequest) error {
 		return fmt.Errorf("validate tier2 request: %s", err)
 	}
 
-	err := validateRequest(request.Modules.Binaries, request.Modules, request.OutputModule, request.BlockType)
+	err := validateRequest(request.Modules.Binaries, request.Modules, request.OutputModuleNames(), request.BlockType)
 	if err != nil {
 		return err
 	}
@@ -40,7 +40,7 @@ func ValidateTier2Request(request *pbssinternal.ProcessRangeRequest) error {
 	return nil
 }
 
-func validateRequest(binaries []*pbsubstreams.Binary, modules *pbsubstreams.Modules, outputModule string, blockType string) error {
+func validateRequest(binaries []*pbsubstreams.Binary, modules *pbsubstreams.Modules, outputModules []string, blockType string) error {
 	if err := validateBinaryTypes(binaries); err != nil {
 		return err
 	}
@@ -49,8 +49,10 @@ func validateRequest(binaries []*pbsubstreams.Binary, modules *pbsubstreams.Modu
 		return fmt.Errorf("modules validation failed: %w", err)
 	}
 
-	if err := validateModuleGraph(modules.Modules, outputModule, blockType); err != nil {
-		return err
+	for _, outputModule := range outputModules {
+		if err := validateModuleGraph(modules.Modules, outputModule, blockType); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -65,6 +65,12 @@ func (v *TestVisitor) Visit_KeyTerm(ctx context.Context, e *KeyTerm) error {
 	return nil
 }
 
+func (v *TestVisitor) Visit_Comparison(ctx context.Context, e *ComparisonExpression) error {
+	v.printStringLiteral(e.Field)
+	v.print(" %s ", e.Operator)
+	return v.printStringLiteral(e.Value)
+}
+
 func (v *TestVisitor) printStringLiteral(literal *StringLiteral) error {
 	if literal.QuotingChar != "" {
 		return v.print("%s%s%s", literal.QuotingChar, literal.Value, literal.QuotingChar)
@@ -66,6 +66,7 @@ var lexerDefinition = lex.Must(lex.Regexp(
 		`|(?P<NotOperator>\-)` +
 		`|(?P<OrOperator>\|\|)` +
 		`|(?P<AndOperator>&&)` +
+		`|(?P<ComparisonOperator>==|!=|<=|>=|<|>)` +
 		`|(?P<LeftParenthesis>\()` +
 		`|(?P<RightParenthesis>\))` +
 		// `|(?P<LeftSquareBracket>\[)` +
@@ -79,11 +80,12 @@ func (l *lexer) isQuoting(t lex.Token) bool { return l.isTokenType(t, "Quoting")
 
 // func (l *lexer) isColon(t lex.Token) bool              { return l.isTokenType(t, "Colon") }
 // func (l *lexer) isComma(t lex.Token) bool              { return l.isTokenType(t, "Comma") }
-func (l *lexer) isNotOperator(t lex.Token) bool      { return l.isTokenType(t, "NotOperator") }
-func (l *lexer) isOrOperator(t lex.Token) bool       { return l.isTokenType(t, "OrOperator") }
-func (l *lexer) isAndOperator(t lex.Token) bool      { return l.isTokenType(t, "AndOperator") }
-func (l *lexer) isLeftParenthesis(t lex.Token) bool  { return l.isTokenType(t, "LeftParenthesis") }
-func (l *lexer) isRightParenthesis(t lex.Token) bool { return l.isTokenType(t, "RightParenthesis") }
+func (l *lexer) isNotOperator(t lex.Token) bool        { return l.isTokenType(t, "NotOperator") }
+func (l *lexer) isOrOperator(t lex.Token) bool         { return l.isTokenType(t, "OrOperator") }
+func (l *lexer) isAndOperator(t lex.Token) bool        { return l.isTokenType(t, "AndOperator") }
+func (l *lexer) isComparisonOperator(t lex.Token) bool { return l.isTokenType(t, "ComparisonOperator") }
+func (l *lexer) isLeftParenthesis(t lex.Token) bool    { return l.isTokenType(t, "LeftParenthesis") }
+func (l *lexer) isRightParenthesis(t lex.Token) bool   { return l.isTokenType(t, "RightParenthesis") }
 
 // func (l *lexer) isLeftSquareBracket(t lex.Token) bool  { return l.isTokenType(t, "LeftSquareBracket") }
 // func (l *lexer) isRightSquareBracket(t lex.Token) bool { return l.isTokenType(t, "RightSquareBracket") }
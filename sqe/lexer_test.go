@@ -31,6 +31,9 @@ func TestLexer(t *testing.T) {
 		// {"square_brackets", `[field, "double quoted"]`, []string{"LeftSquareBracket", "Name", "Comma", "Space", "Quoting", "Name", "Space", "Name", "Quoting", "RightSquareBracket", "EOF"}},
 
 		{"expresion_with_and", `action && field`, []string{"Name", "Space", "AndOperator", "Space", "Name", "EOF"}},
+
+		{"comparison", `field >= 10`, []string{"Name", "Space", "ComparisonOperator", "Space", "Name", "EOF"}},
+		{"comparison_without_spaces", `field==10`, []string{"Name", "EOF"}},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,308 @@
+package sqe
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// MessageFilter matches the messages of a single type against an expression parsed with
+// `ParseOutputFilter`. Field paths are joined with `.`, and a `[]` suffix on a repeated
+// field matches when any of its elements does. A field path alone matches when the field
+// is set (non-zero, or non-empty for repeated fields), comparisons match when the field's
+// value compares with the literal.
+type MessageFilter struct {
+	msgDesc   protoreflect.MessageDescriptor
+	predicate messagePredicate
+}
+
+type messagePredicate func(msg protoreflect.Message) bool
+
+// NewMessageFilter resolves the field paths of `expr` against `msgDesc`, failing on unknown
+// fields and on literals that cannot be compared with the values of their field.
+func NewMessageFilter(expr Expression, msgDesc protoreflect.MessageDescriptor) (*MessageFilter, error) {
+	predicate, err := compilePredicate(expr, msgDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageFilter{
+		msgDesc:   msgDesc,
+		predicate: predicate,
+	}, nil
+}
+
+func (f *MessageFilter) Match(msg protoreflect.Message) bool {
+	return f.predicate(msg)
+}
+
+// MatchBytes decodes `data`, a serialized message of the filter's type, and matches it.
+func (f *MessageFilter) MatchBytes(data []byte) (bool, error) {
+	msg := dynamicpb.NewMessage(f.msgDesc)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return false, fmt.Errorf("decoding %s: %w", f.msgDesc.FullName(), err)
+	}
+
+	return f.predicate(msg), nil
+}
+
+func compilePredicate(expr Expression, msgDesc protoreflect.MessageDescriptor) (messagePredicate, error) {
+	switch v := expr.(type) {
+	case *KeyTerm:
+		path, err := newFieldPath(msgDesc, v.Value.Value, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(msg protoreflect.Message) bool {
+			return path.walk(msg, 0, func(parent protoreflect.Message) bool {
+				return parent.Has(path.last())
+			})
+		}, nil
+
+	case *ComparisonExpression:
+		path, err := newFieldPath(msgDesc, v.Field.Value, false)
+		if err != nil {
+			return nil, err
+		}
+
+		match, err := newValueMatcher(path.last(), v.Operator, v.Value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", v.Field.Value, err)
+		}
+
+		return func(msg protoreflect.Message) bool {
+			return path.walk(msg, 0, func(parent protoreflect.Message) bool {
+				value := parent.Get(path.last())
+				if !path.each[len(path.each)-1] {
+					return match(value)
+				}
+
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					if match(list.Get(i)) {
+						return true
+					}
+				}
+				return false
+			})
+		}, nil
+
+	case *AndExpression, *OrExpression:
+		children := v.(HasChildrenExpression).GetChildren()
+		predicates := make([]messagePredicate, len(children))
+		for i, child := range children {
+			predicate, err := compilePredicate(child, msgDesc)
+			if err != nil {
+				return nil, err
+			}
+			predicates[i] = predicate
+		}
+
+		if _, ok := v.(*AndExpression); ok {
+			return func(msg protoreflect.Message) bool {
+				for _, predicate := range predicates {
+					if !predicate(msg) {
+						return false
+					}
+				}
+				return true
+			}, nil
+		}
+
+		return func(msg protoreflect.Message) bool {
+			for _, predicate := range predicates {
+				if predicate(msg) {
+					return true
+				}
+			}
+			return false
+		}, nil
+
+	case *ParenthesisExpression:
+		return compilePredicate(v.Child, msgDesc)
+
+	default:
+		return nil, fmt.Errorf("element of type %T is not supported in output filters", v)
+	}
+}
+
+// fieldPath is a path of fields resolved from the root message, `each[i]` being set when
+// `fields[i]` is a repeated field followed by `[]`.
+type fieldPath struct {
+	fields []protoreflect.FieldDescriptor
+	each   []bool
+}
+
+func newFieldPath(msgDesc protoreflect.MessageDescriptor, path string, presence bool) (*fieldPath, error) {
+	out := &fieldPath{}
+
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		name, each := strings.CutSuffix(segment, "[]")
+		if msgDesc == nil {
+			return nil, fmt.Errorf("field %q: %q is not a message", path, segments[i-1])
+		}
+
+		field := msgDesc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			field = msgDesc.Fields().ByJSONName(name)
+		}
+		if field == nil {
+			return nil, fmt.Errorf("field %q: %q not found in message %s", path, name, msgDesc.FullName())
+		}
+
+		last := i == len(segments)-1
+		switch {
+		case field.IsMap():
+			return nil, fmt.Errorf("field %q: map field %q is not supported", path, name)
+		case each && !field.IsList():
+			return nil, fmt.Errorf("field %q: %q is not repeated", path, name)
+		case field.IsList() && !each && !(last && presence):
+			return nil, fmt.Errorf("field %q: %q is repeated, use \"%s[]\" to match any of its elements", path, name, name)
+		case last && !presence && field.Message() != nil:
+			return nil, fmt.Errorf("field %q: message %q cannot be compared with a value", path, name)
+		}
+
+		out.fields = append(out.fields, field)
+		out.each = append(out.each, each)
+		msgDesc = field.Message()
+	}
+
+	return out, nil
+}
+
+func (p *fieldPath) last() protoreflect.FieldDescriptor {
+	return p.fields[len(p.fields)-1]
+}
+
+// walk calls `onParent` with the messages holding the last field of the path, reached
+// from `msg` through the fields starting at `depth`, until it returns true. Unset messages
+// are not walked through.
+func (p *fieldPath) walk(msg protoreflect.Message, depth int, onParent func(parent protoreflect.Message) bool) bool {
+	if depth == len(p.fields)-1 {
+		return onParent(msg)
+	}
+
+	field := p.fields[depth]
+	if !msg.Has(field) {
+		return false
+	}
+
+	if !p.each[depth] {
+		return p.walk(msg.Get(field).Message(), depth+1, onParent)
+	}
+
+	list := msg.Get(field).List()
+	for i := 0; i < list.Len(); i++ {
+		if p.walk(list.Get(i).Message(), depth+1, onParent) {
+			return true
+		}
+	}
+	return false
+}
+
+// newValueMatcher returns a function matching the values of `field` compared with
+// `literal`, parsed according to the field's type.
+func newValueMatcher(field protoreflect.FieldDescriptor, operator string, literal string) (func(value protoreflect.Value) bool, error) {
+	var compare func(value protoreflect.Value) int
+	ordered := true
+
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		expected, err := strconv.ParseBool(literal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool value %q", literal)
+		}
+		ordered = false
+		compare = func(value protoreflect.Value) int {
+			if value.Bool() == expected {
+				return 0
+			}
+			return 1
+		}
+
+	case protoreflect.EnumKind:
+		var expected protoreflect.EnumNumber
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(literal)); enumValue != nil {
+			expected = enumValue.Number()
+		} else {
+			number, err := strconv.ParseInt(literal, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for enum %s", literal, field.Enum().FullName())
+			}
+			expected = protoreflect.EnumNumber(number)
+		}
+		ordered = false
+		compare = func(value protoreflect.Value) int {
+			if value.Enum() == expected {
+				return 0
+			}
+			return 1
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		expected, err := strconv.ParseInt(literal, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value %q", literal)
+		}
+		compare = func(value protoreflect.Value) int { return cmp.Compare(value.Int(), expected) }
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		expected, err := strconv.ParseUint(literal, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unsigned integer value %q", literal)
+		}
+		compare = func(value protoreflect.Value) int { return cmp.Compare(value.Uint(), expected) }
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		expected, err := strconv.ParseFloat(literal, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number value %q", literal)
+		}
+		compare = func(value protoreflect.Value) int { return cmp.Compare(value.Float(), expected) }
+
+	case protoreflect.StringKind:
+		compare = func(value protoreflect.Value) int { return strings.Compare(value.String(), literal) }
+
+	case protoreflect.BytesKind:
+		expected, err := hex.DecodeString(strings.TrimPrefix(literal, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex value %q", literal)
+		}
+		compare = func(value protoreflect.Value) int { return bytes.Compare(value.Bytes(), expected) }
+
+	default:
+		return nil, fmt.Errorf("fields of type %s cannot be compared", field.Kind())
+	}
+
+	if !ordered && operator != "==" && operator != "!=" {
+		return nil, fmt.Errorf("operator %s is not supported on %s values", operator, field.Kind())
+	}
+
+	switch operator {
+	case "==":
+		return func(value protoreflect.Value) bool { return compare(value) == 0 }, nil
+	case "!=":
+		return func(value protoreflect.Value) bool { return compare(value) != 0 }, nil
+	case "<":
+		return func(value protoreflect.Value) bool { return compare(value) < 0 }, nil
+	case "<=":
+		return func(value protoreflect.Value) bool { return compare(value) <= 0 }, nil
+	case ">":
+		return func(value protoreflect.Value) bool { return compare(value) > 0 }, nil
+	case ">=":
+		return func(value protoreflect.Value) bool { return compare(value) >= 0 }, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %s", operator)
+	}
+}
@@ -0,0 +1,90 @@
+package sqe
+
+import (
+	"context"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessageFilter(t *testing.T) {
+	modules := &pbsubstreams.Modules{
+		Modules: []*pbsubstreams.Module{
+			{Name: "map_a", InitialBlock: 10, Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:a"}}},
+			{Name: "store_b", InitialBlock: 200, Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{UpdatePolicy: pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD}}},
+		},
+		Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte{0xca, 0xfe}}},
+	}
+
+	tests := []struct {
+		expr   string
+		result bool
+	}{
+		{`modules[].name == map_a`, true},
+		{`modules[].name == "store_b"`, true},
+		{`modules[].name == map_c`, false},
+		{`modules[].name != map_a`, true},
+		{`modules[].initial_block > 100`, true},
+		{`modules[].initial_block > 200`, false},
+		{`modules[].initialBlock <= 10`, true},
+		{`modules[].kind_store.update_policy == UPDATE_POLICY_ADD`, true},
+		{`modules[].kind_store.update_policy == 1`, false},
+		{`modules[].kind_map.output_type == "proto:a" && modules[].initial_block == 200`, true},
+		{`(modules[].name == map_a modules[].initial_block == 200) || binaries[].type == none`, true},
+		{`modules[].kind_map`, true},
+		{`modules[].block_filter`, false},
+		{`modules`, true},
+		{`binaries[].content == 0xcafe`, true},
+		{`binaries[].content == cafe00`, false},
+		{`binaries[].content < ff`, true},
+		{`binaries[].type == "wasm/rust-v1" modules[].initial_block >= 0`, true},
+	}
+
+	data, err := proto.Marshal(modules)
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			expr, err := ParseOutputFilter(context.Background(), test.expr)
+			require.NoError(t, err)
+
+			filter, err := NewMessageFilter(expr, modules.ProtoReflect().Descriptor())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.result, filter.Match(modules.ProtoReflect()))
+
+			matched, err := filter.MatchBytes(data)
+			require.NoError(t, err)
+			assert.Equal(t, test.result, matched)
+		})
+	}
+}
+
+func TestMessageFilter_Errors(t *testing.T) {
+	tests := []struct {
+		expr        string
+		expectedErr string
+	}{
+		{`modules.name == a`, `field "modules.name": "modules" is repeated, use "modules[]" to match any of its elements`},
+		{`modules[].unknown`, `field "modules[].unknown": "unknown" not found in message sf.substreams.v1.Module`},
+		{`modules[].name[] == a`, `field "modules[].name[]": "name" is not repeated`},
+		{`modules[].name.first`, `field "modules[].name.first": "name" is not a message`},
+		{`modules[].kind_map == a`, `field "modules[].kind_map": message "kind_map" cannot be compared with a value`},
+		{`modules[].initial_block > ten`, `field "modules[].initial_block": invalid unsigned integer value "ten"`},
+		{`modules[].kind_store.update_policy > UPDATE_POLICY_ADD`, `field "modules[].kind_store.update_policy": operator > is not supported on enum values`},
+		{`binaries[].content == xyz`, `field "binaries[].content": invalid hex value "xyz"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			expr, err := ParseOutputFilter(context.Background(), test.expr)
+			require.NoError(t, err)
+
+			_, err = NewMessageFilter(expr, (&pbsubstreams.Modules{}).ProtoReflect().Descriptor())
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
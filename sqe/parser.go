@@ -24,11 +24,25 @@ func Parse(ctx context.Context, input string) (expr Expression, err error) {
 	return parser.Parse(ctx)
 }
 
+// ParseOutputFilter parses an expression applied on the fields of a message, accepting
+// comparisons (`==`, `!=`, `<`, `<=`, `>`, `>=`) between a field path and a value, in
+// addition to the field paths matched by presence.
+func ParseOutputFilter(ctx context.Context, input string) (expr Expression, err error) {
+	parser, err := NewParser(bytes.NewBufferString(input))
+	if err != nil {
+		return nil, fmt.Errorf("new parser: %w", err)
+	}
+	parser.allowComparisons = true
+
+	return parser.Parse(ctx)
+}
+
 type Parser struct {
 	ctx context.Context
 	l   *lexer
 
 	lookForRightParenthesis uint
+	allowComparisons        bool
 }
 
 func NewParser(reader io.Reader) (*Parser, error) {
@@ -171,7 +185,11 @@ func (p *Parser) parseUnaryExpression(depth int) (Expression, error) {
 
 	switch {
 	case p.l.isName(token) || p.l.isQuoting(token):
-		return p.parseKeyTerm()
+		keyTerm, err := p.parseKeyTerm()
+		if err != nil {
+			return nil, err
+		}
+		return p.parseComparison(keyTerm)
 	case p.l.isLeftParenthesis(token):
 		return p.parseParenthesisExpression(depth)
 	case p.l.isNotOperator(token):
@@ -209,7 +227,7 @@ func (p *Parser) parseParenthesisExpression(depth int) (Expression, error) {
 	return &ParenthesisExpression{child}, nil
 }
 
-func (p *Parser) parseKeyTerm() (Expression, error) {
+func (p *Parser) parseKeyTerm() (*KeyTerm, error) {
 	token := p.l.mustLexNext()
 
 	var value *StringLiteral
@@ -234,6 +252,53 @@ func (p *Parser) parseKeyTerm() (Expression, error) {
 	}, nil
 }
 
+// parseComparison turns `keyTerm` into a comparison when it is followed by a comparison operator
+func (p *Parser) parseComparison(keyTerm *KeyTerm) (Expression, error) {
+	p.l.skipSpaces()
+	operator, err := p.l.Peek(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.l.isComparisonOperator(operator) {
+		return keyTerm, nil
+	}
+
+	if !p.allowComparisons {
+		return nil, parserError("comparison operators are not supported in the block filter", operator.Pos)
+	}
+
+	p.l.mustLexNext()
+	p.l.skipSpaces()
+
+	token := p.l.mustLexNext()
+	var value *StringLiteral
+	switch {
+	case p.l.isName(token):
+		value = &StringLiteral{Value: token.String()}
+	case p.l.isNotOperator(token):
+		// negative numbers, the minus sign being lexed as the NOT operator
+		next := p.l.mustLexNext()
+		if !p.l.isName(next) {
+			return nil, parserError(fmt.Sprintf("expecting a value after %s, got %s", operator.Value, p.l.getTokenType(next)), next.Pos)
+		}
+		value = &StringLiteral{Value: "-" + next.String()}
+	case p.l.isQuoting(token):
+		value, err = p.parseQuotedString(token)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, parserError(fmt.Sprintf("expecting a value after %s, got %s", operator.Value, p.l.getTokenType(token)), token.Pos)
+	}
+
+	return &ComparisonExpression{
+		Field:    keyTerm.Value,
+		Operator: operator.Value,
+		Value:    value,
+	}, nil
+}
+
 func (p *Parser) parseQuotedString(startQuoting lex.Token) (*StringLiteral, error) {
 	builder := &strings.Builder{}
 	for {
@@ -273,6 +273,12 @@ func TestParser(t *testing.T) {
 			"",
 			&ParseError{"expecting closing parenthesis, got end of input", pos(1, 0, 1)},
 		},
+		{
+			"error_comparison_in_block_filter",
+			`a == b`,
+			"",
+			&ParseError{"comparison operators are not supported in the block filter", pos(1, 2, 3)},
+		},
 		{
 			"error_deepness_reached",
 			buildFromOrToList(MaxRecursionDeepness + 1),
@@ -300,6 +306,33 @@ func TestParser(t *testing.T) {
 	}
 }
 
+func TestParseOutputFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		sqe         string
+		expected    string
+		expectedErr error
+	}{
+		{"comparison", `transfers[].to == "0xabc"`, `transfers[].to == "0xabc"`, nil},
+		{"comparison_negative_number", `amount > -10`, `amount > -10`, nil},
+		{"comparison_and_key_term", `amount >= 10 && to`, `<amount >= 10 && to>`, nil},
+		{"comparisons_or", `(a != b) || c < 'd e'`, `[(a != b) || c < 'd e']`, nil},
+		{"error_missing_value", `a ==`, "", &ParseError{"expecting a value after ==, got EOF", pos(1, 4, 5)}},
+		{"error_operator_value", `a == &&`, "", &ParseError{"expecting a value after ==, got AndOperator", pos(1, 5, 6)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expression, err := ParseOutputFilter(context.Background(), test.sqe)
+			require.Equal(t, test.expectedErr, err)
+
+			if test.expectedErr == nil {
+				assert.Equal(t, test.expected, expressionToString(expression))
+			}
+		})
+	}
+}
+
 func pos(line, offset, column int) lex.Position {
 	return lex.Position{Filename: "", Line: line, Offset: offset, Column: column}
 }
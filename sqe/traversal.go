@@ -90,6 +90,18 @@ func (v *DepthFirstVisitor) Visit_KeyTerm(ctx context.Context, e *KeyTerm) error
 	return nil
 }
 
+func (v *DepthFirstVisitor) Visit_Comparison(ctx context.Context, e *ComparisonExpression) error {
+	if stop, err := v.executeCallback(ctx, e, v.beforeVisit); stop {
+		return err
+	}
+
+	if stop, err := v.executeCallback(ctx, e, v.afterVisit); stop {
+		return err
+	}
+
+	return nil
+}
+
 func (v *DepthFirstVisitor) executeCallback(ctx context.Context, e Expression, callback OnExpression) (stop bool, err error) {
 	if callback == nil {
 		return false, nil
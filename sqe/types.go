@@ -11,6 +11,7 @@ type Visitor interface {
 	Visit_Parenthesis(ctx context.Context, expr *ParenthesisExpression) error
 	Visit_Not(ctx context.Context, expr *NotExpression) error
 	Visit_KeyTerm(ctx context.Context, expr *KeyTerm) error
+	Visit_Comparison(ctx context.Context, expr *ComparisonExpression) error
 }
 
 type Expression interface {
@@ -89,6 +90,18 @@ func (e *KeyTerm) Visit(ctx context.Context, visitor Visitor) error {
 	return visitor.Visit_KeyTerm(ctx, e)
 }
 
+// ComparisonExpression compares the value of a field with a literal, like `transfers[].to == "0xabc"`.
+// It is only accepted by `ParseOutputFilter`, block filters matching index keys.
+type ComparisonExpression struct {
+	Field    *StringLiteral
+	Operator string
+	Value    *StringLiteral
+}
+
+func (e *ComparisonExpression) Visit(ctx context.Context, visitor Visitor) error {
+	return visitor.Visit_Comparison(ctx, e)
+}
+
 type StringLiteral struct {
 	Value       string
 	QuotingChar string
@@ -17,6 +17,21 @@ type baseStore struct {
 
 	kv    map[string][]byte        // kv is the state, and assumes all deltas were already applied to it.
 	kvOps *pbssinternal.Operations // operations to the curent block called from the WASM module
+	// keyIndex orders the keys of kv for scans, nil until the first one
+	keyIndex *keyIndex
+	// writtenAt is the boundary at which each key was last written, zero for keys written since
+	// the last call to Retain. Only tracked in full stores having retain blocks, nil otherwise.
+	writtenAt map[string]uint64
+	// retainUndo records the changes made to writtenAt and the keys dropped by Retain, only tracked in full
+	// stores processing reversible blocks, nil otherwise
+	retainUndo *RetainUndo
+	// indexes are the secondary indexes of the store by name, only maintained in full stores, nil otherwise
+	indexes map[string]*storeIndex
+	// onDeleteRange is called by Flush with each range deleted and the pointers it resolved, only set in partial
+	// stores, nil otherwise
+	onDeleteRange func(deletedRange marshaller.DeleteRange)
+	// onWrite is called with each key written, only set in partial stores, nil otherwise
+	onWrite func(key string)
 	// deltas are always deltas for the given block. they are produced when store is flushed
 	// 	and used to read back in the store at different ordinals
 	deltas         []*pbsubstreams.StoreDelta
@@ -112,6 +127,8 @@ func (b *baseStore) Flush() (err error) {
 			}
 		case pbssinternal.Operation_DELETE_PREFIX:
 			b.deletePrefix(op.Ord, op.Key)
+		case pbssinternal.Operation_DELETE_RANGE:
+			b.deleteRange(op.Ord, op.Key, op.HighKey, op.PointerSeparator)
 		case pbssinternal.Operation_SET_MAX_BIG_INT:
 			b.setMaxBigInt(op.Ord, op.Key, valueToBigInt(op.Value))
 		case pbssinternal.Operation_SET_MAX_INT64:
@@ -156,6 +173,10 @@ func (b *baseStore) Flush() (err error) {
 			b.setSumBigDecimal(op.Ord, op.Key, op.Value)
 		case pbssinternal.Operation_SET_SUM_BIG_INT:
 			b.setSumBigInt(op.Ord, op.Key, op.Value)
+		case pbssinternal.Operation_AGGREGATE:
+			if err := b.aggregate(op.Ord, op.Key, op.Value); err != nil {
+				return err
+			}
 		}
 		b.lastOrdinal = op.Ord
 	}
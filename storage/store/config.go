@@ -24,6 +24,9 @@ type Config struct {
 	moduleInitialBlock uint64
 	updatePolicy       pbsubstreams.Module_KindStore_UpdatePolicy
 	valueType          string
+	retainBlocks       uint64
+	indexes            []*pbsubstreams.Module_KindStore_Index
+	aggregateFunc      AggregateFunc
 
 	appendLimit    uint64
 	totalSizeLimit uint64
@@ -91,8 +94,41 @@ func (c *Config) ModuleInitialBlock() uint64 {
 	return c.moduleInitialBlock
 }
 
+// SetRetainBlocks makes the full stores of this config drop the keys not written
+// for that many blocks when calling Retain, zero keeps them indefinitely.
+func (c *Config) SetRetainBlocks(retainBlocks uint64) {
+	c.retainBlocks = retainBlocks
+}
+
+func (c *Config) RetainBlocks() uint64 {
+	return c.retainBlocks
+}
+
+// SetIndexes sets the secondary indexes maintained by the full stores of this config.
+func (c *Config) SetIndexes(indexes []*pbsubstreams.Module_KindStore_Index) error {
+	if err := validateIndexes(indexes); err != nil {
+		return err
+	}
+	c.indexes = indexes
+	return nil
+}
+
+// SetAggregateFunc sets the function merging the values of the stores of this config,
+// required by the custom update policy.
+func (c *Config) SetAggregateFunc(f AggregateFunc) {
+	c.aggregateFunc = f
+}
+
 func (c *Config) NewFullKV(logger *zap.Logger) *FullKV {
-	return &FullKV{c.newBaseStore(logger), "N/A"}
+	b := c.newBaseStore(logger)
+	if c.retainBlocks != 0 {
+		b.writtenAt = make(map[string]uint64)
+	}
+	if len(c.indexes) != 0 {
+		b.indexes = make(map[string]*storeIndex, len(c.indexes))
+		b.resetIndexes(nil)
+	}
+	return &FullKV{b, "N/A"}
 }
 
 func (c *Config) ExistsFullKV(ctx context.Context, upTo uint64) (bool, error) {
@@ -106,11 +142,7 @@ func (c *Config) ExistsPartialKV(ctx context.Context, from, to uint64) (bool, er
 }
 
 func (c *Config) NewPartialKV(initialBlock uint64, logger *zap.Logger) *PartialKV {
-	return &PartialKV{
-		baseStore:    c.newBaseStore(logger),
-		initialBlock: initialBlock,
-		seen:         make(map[string]bool),
-	}
+	return newPartialKV(c.newBaseStore(logger), initialBlock)
 }
 
 func (c *Config) FileSize(ctx context.Context, fileInfo *FileInfo) (int64, error) {
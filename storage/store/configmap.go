@@ -28,6 +28,10 @@ func NewConfigMap(baseObjectStore dstore.Store, storeModules []*pbsubstreams.Mod
 		if err != nil {
 			return nil, fmt.Errorf("new store config for %q: %w", storeModule.Name, err)
 		}
+		c.SetRetainBlocks(storeModule.GetKindStore().RetainBlocks)
+		if err := c.SetIndexes(storeModule.GetKindStore().Indexes); err != nil {
+			return nil, fmt.Errorf("store %q: %w", storeModule.Name, err)
+		}
 		out[storeModule.Name] = c
 	}
 	return out, nil
@@ -21,7 +21,12 @@ func (b *baseStore) ApplyDelta(delta *pbsubstreams.StoreDelta) {
 	keySize := uint64(len(delta.Key))
 	switch delta.Operation {
 	case pbsubstreams.StoreDelta_UPDATE:
+		if b.indexes != nil {
+			b.removeFromIndexes(delta.Key, b.kv[delta.Key])
+			b.addToIndexes(delta.Key, delta.NewValue)
+		}
 		b.kv[delta.Key] = delta.NewValue
+		b.markWritten(delta.Key)
 		switch {
 		case newSize > oldSize:
 			b.totalSizeBytes += (newSize - oldSize)
@@ -31,11 +36,18 @@ func (b *baseStore) ApplyDelta(delta *pbsubstreams.StoreDelta) {
 
 	case pbsubstreams.StoreDelta_CREATE:
 		b.kv[delta.Key] = delta.NewValue
+		b.indexKey(delta.Key)
+		b.addToIndexes(delta.Key, delta.NewValue)
+		b.markWritten(delta.Key)
 		b.totalSizeBytes += newSize
 		b.totalSizeBytes += keySize
 
 	case pbsubstreams.StoreDelta_DELETE:
+		if b.indexes != nil {
+			b.removeFromIndexes(delta.Key, b.kv[delta.Key])
+		}
 		delete(b.kv, delta.Key)
+		b.unindexKey(delta.Key)
 		b.totalSizeBytes -= oldSize
 		b.totalSizeBytes -= keySize
 		return
@@ -61,6 +73,10 @@ func (b *baseStore) ApplyDeltasReverse(deltas []*pbsubstreams.StoreDelta) {
 		keySize := uint64(len(delta.Key))
 		switch delta.Operation {
 		case pbsubstreams.StoreDelta_UPDATE:
+			if b.indexes != nil {
+				b.removeFromIndexes(delta.Key, b.kv[delta.Key])
+				b.addToIndexes(delta.Key, delta.OldValue)
+			}
 			b.kv[delta.Key] = delta.OldValue
 			switch {
 			case newSize > oldSize:
@@ -70,15 +86,20 @@ func (b *baseStore) ApplyDeltasReverse(deltas []*pbsubstreams.StoreDelta) {
 			}
 
 		case pbsubstreams.StoreDelta_CREATE:
+			if b.indexes != nil {
+				b.removeFromIndexes(delta.Key, b.kv[delta.Key])
+			}
 			delete(b.kv, delta.Key)
+			b.unindexKey(delta.Key)
 			b.totalSizeBytes -= newSize
 			b.totalSizeBytes -= keySize
 
 		case pbsubstreams.StoreDelta_DELETE:
 			b.kv[delta.Key] = delta.OldValue
+			b.indexKey(delta.Key)
+			b.addToIndexes(delta.Key, delta.OldValue)
 			b.totalSizeBytes += oldSize
 			b.totalSizeBytes += keySize
-			return
 		}
 	}
 }
@@ -29,11 +29,7 @@ func (s *FullKV) DerivePartialStore(initialBlock uint64) *PartialKV {
 		logger:     s.logger,
 		marshaller: marshaller.Default(),
 	}
-	return &PartialKV{
-		baseStore:    b,
-		initialBlock: initialBlock,
-		seen:         make(map[string]bool),
-	}
+	return newPartialKV(b, initialBlock)
 }
 
 func (s *FullKV) Load(ctx context.Context, file *FileInfo) error {
@@ -51,10 +47,18 @@ func (s *FullKV) Load(ctx context.Context, file *FileInfo) error {
 	}
 
 	s.kv = storeData.Kv
+	s.keyIndex = nil
 	s.totalSizeBytes = size
 	if s.kv == nil {
 		s.kv = make(map[string][]byte)
 	}
+	s.resetIndexes(storeData.IndexEntries)
+	if s.retainBlocks != 0 {
+		s.writtenAt = storeData.WrittenAt
+		if s.writtenAt == nil {
+			s.writtenAt = make(map[string]uint64)
+		}
+	}
 
 	s.logger.Debug("full store loaded", zap.String("fileName", file.Filename), zap.Int("key_count", len(s.kv)), zap.Uint64("data_size", size))
 	return nil
@@ -67,7 +71,9 @@ func (s *FullKV) Save(endBoundaryBlock uint64) (*FileInfo, *fileWriter, error) {
 	s.logger.Debug("writing full store state", zap.Object("store", s))
 
 	stateData := &marshaller.StoreData{
-		Kv: s.kv,
+		Kv:           s.kv,
+		WrittenAt:    s.writtenAt,
+		IndexEntries: s.indexEntries(),
 	}
 
 	content, err := s.marshaller.Marshal(stateData)
@@ -52,6 +52,8 @@ type Store interface {
 	SetSumFloat64Setter
 	SetSumBigIntSetter
 	SetSumBigDecimalSetter
+
+	Aggregator
 }
 
 type PartialStore interface {
@@ -101,6 +103,14 @@ type Reader interface {
 	HasFirst(key string) bool
 	HasLast(key string) bool
 	HasAt(ord uint64, key string) bool
+
+	// ScanAt calls f, in key order, with the keys between low (inclusive) and high (exclusive, no upper bound when
+	// empty) and their value for the state that includes the processing of `ord`, until f returns false.
+	ScanAt(ord uint64, low, high string, f func(key string, value []byte) bool)
+
+	// IndexLookupAt calls f, in key order, with the keys after `after` having `value` in the secondary index
+	// named `index`, and their value for the state that includes the processing of `ord`, until f returns false.
+	IndexLookupAt(ord uint64, index, value, after string, f func(key string, value []byte) bool) error
 }
 
 type Mergeable interface {
@@ -124,10 +134,10 @@ type Appender interface {
 
 type Deleter interface {
 	DeletePrefix(ord uint64, prefix string)
-	//// Deletes a range of keys, lexicographically between `lowKey` and `highKey`
-	//DeleteRange(lowKey, highKey string)
-	//// Deletes a range of keys, first considering the _value_ of such keys as a _pointerSeparator_-separated list of keys to _also_ delete.
-	//DeleteRangePointers(lowKey, highKey, pointerSeparator string)
+	// Deletes a range of keys, lexicographically between `lowKey` (inclusive) and `highKey` (exclusive, no upper bound when empty)
+	DeleteRange(ord uint64, lowKey, highKey string)
+	// Deletes a range of keys, first considering the _value_ of such keys as a _pointerSeparator_-separated list of keys to _also_ delete.
+	DeleteRangePointers(ord uint64, lowKey, highKey, pointerSeparator string)
 }
 
 type MaxBigIntSetter interface {
@@ -181,3 +191,8 @@ type SetSumBigIntSetter interface {
 type SetSumBigDecimalSetter interface {
 	SetSumBigDecimal(ord uint64, key string, value []byte)
 }
+
+type Aggregator interface {
+	// Aggregate merges value into the value of key, with the aggregate function of the store
+	Aggregate(ord uint64, key string, value []byte)
+}
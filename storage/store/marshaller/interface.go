@@ -1,8 +1,92 @@
 package marshaller
 
+import (
+	"slices"
+
+	pbstore "github.com/streamingfast/substreams/storage/store/marshaller/pb"
+)
+
 type StoreData struct {
 	Kv             map[string][]byte
 	DeletePrefixes []string
+	DeleteRanges   []DeleteRange
+	// WrittenAt is the boundary block at which each key was last written, kept for stores with retain blocks
+	WrittenAt map[string]uint64
+	// IndexEntries are the keys of each value of the secondary indexes of a full store
+	IndexEntries []IndexEntries
+}
+
+// IndexEntries are the keys, in order, having Value in the secondary index named Index.
+type IndexEntries struct {
+	Index string
+	Value string
+	Keys  []string
+}
+
+// DeleteRange is a range of keys deleted from a partial store, from LowKey (inclusive) to HighKey (exclusive,
+// no upper bound when empty). When PointerSeparator is set, the values of the deleted keys are also split
+// with it into keys to delete.
+//
+// For the merge into the full store, Pointers are the keys listed in the values the partial store held for
+// the range when deleting it, and WrittenKeys are the keys of the range it held then: their values in the full
+// store are outdated, so they are not followed. WrittenBefore are the other keys it held then, not written again
+// afterwards: when the full store values list them, they are deleted from the partial store too.
+type DeleteRange struct {
+	LowKey           string
+	HighKey          string
+	PointerSeparator string
+	Pointers         []string
+	WrittenKeys      []string
+	WrittenBefore    []string
+}
+
+// Equal reports whether both ranges deleted the same keys.
+func (r DeleteRange) Equal(other DeleteRange) bool {
+	return r.LowKey == other.LowKey && r.HighKey == other.HighKey && r.PointerSeparator == other.PointerSeparator &&
+		slices.Equal(r.Pointers, other.Pointers) && slices.Equal(r.WrittenKeys, other.WrittenKeys) &&
+		slices.Equal(r.WrittenBefore, other.WrittenBefore)
+}
+
+func deleteRangesToProto(ranges []DeleteRange) (out []*pbstore.DeleteRange) {
+	for _, r := range ranges {
+		out = append(out, &pbstore.DeleteRange{
+			LowKey:           r.LowKey,
+			HighKey:          r.HighKey,
+			PointerSeparator: r.PointerSeparator,
+			Pointers:         r.Pointers,
+			WrittenKeys:      r.WrittenKeys,
+			WrittenBefore:    r.WrittenBefore,
+		})
+	}
+	return
+}
+
+func deleteRangesFromProto(ranges []*pbstore.DeleteRange) (out []DeleteRange) {
+	for _, r := range ranges {
+		out = append(out, DeleteRange{
+			LowKey:           r.LowKey,
+			HighKey:          r.HighKey,
+			PointerSeparator: r.PointerSeparator,
+			Pointers:         r.Pointers,
+			WrittenKeys:      r.WrittenKeys,
+			WrittenBefore:    r.WrittenBefore,
+		})
+	}
+	return
+}
+
+func indexEntriesToProto(entries []IndexEntries) (out []*pbstore.IndexEntries) {
+	for _, e := range entries {
+		out = append(out, &pbstore.IndexEntries{Index: e.Index, Value: e.Value, Keys: e.Keys})
+	}
+	return
+}
+
+func indexEntriesFromProto(entries []*pbstore.IndexEntries) (out []IndexEntries) {
+	for _, e := range entries {
+		out = append(out, IndexEntries{Index: e.Index, Value: e.Value, Keys: e.Keys})
+	}
+	return
 }
 
 type Marshaller interface {
@@ -17,6 +17,9 @@ func (p *Proto) Unmarshal(in []byte) (*StoreData, uint64, error) {
 	return &StoreData{
 		Kv:             stateData.GetKv(),
 		DeletePrefixes: stateData.GetDeletePrefixes(),
+		DeleteRanges:   deleteRangesFromProto(stateData.GetDeleteRanges()),
+		WrittenAt:      stateData.GetWrittenAt(),
+		IndexEntries:   indexEntriesFromProto(stateData.GetIndexEntries()),
 	}, 0, nil
 }
 
@@ -24,6 +27,9 @@ func (p *Proto) Marshal(data *StoreData) ([]byte, error) {
 	stateData := &pbsubstreams.StoreData{
 		Kv:             data.Kv,
 		DeletePrefixes: data.DeletePrefixes,
+		DeleteRanges:   deleteRangesToProto(data.DeleteRanges),
+		WrittenAt:      data.WrittenAt,
+		IndexEntries:   indexEntriesToProto(data.IndexEntries),
 	}
 	return proto.Marshal(stateData)
 }
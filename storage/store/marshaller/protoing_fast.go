@@ -12,6 +12,20 @@ const KVEntryProtoTag = 0x0a
 const KVEntryKeyProtoTag = 0x0a
 const KVEntryValueProtoTag = 0x12
 const DeletePrefixEntryProtoTag = 0x12
+const DeleteRangeEntryProtoTag = 0x1a
+const DeleteRangeLowKeyProtoTag = 0x0a
+const DeleteRangeHighKeyProtoTag = 0x12
+const DeleteRangePointerSeparatorProtoTag = 0x1a
+const DeleteRangePointerProtoTag = 0x22
+const DeleteRangeWrittenKeyProtoTag = 0x2a
+const DeleteRangeWrittenBeforeProtoTag = 0x32
+const WrittenAtEntryProtoTag = 0x22
+const WrittenAtEntryKeyProtoTag = 0x0a
+const WrittenAtEntryValueProtoTag = 0x10
+const IndexEntriesProtoTag = 0x2a
+const IndexEntriesIndexProtoTag = 0x0a
+const IndexEntriesValueProtoTag = 0x12
+const IndexEntriesKeyProtoTag = 0x1a
 
 // ProtoingFast is a custom proto marshaller, that will marshal and unmarshall the storeData into a predefined
 // proto struct (see below). The motivation here is that we want to write a proto message, making it readable by
@@ -20,6 +34,24 @@ const DeletePrefixEntryProtoTag = 0x12
 //	message StoreData {
 //		map<string, bytes> kv = 1;
 //		repeated string delete_prefixes = 2;
+//		repeated DeleteRange delete_ranges = 3;
+//		map<string, uint64> written_at = 4;
+//		repeated IndexEntries index_entries = 5;
+//	}
+//
+//	message IndexEntries {
+//		string index = 1;
+//		string value = 2;
+//		repeated string keys = 3;
+//	}
+//
+//	message DeleteRange {
+//		string low_key = 1;
+//		string high_key = 2;
+//		string pointer_separator = 3;
+//		repeated string pointers = 4;
+//		repeated string written_keys = 5;
+//		repeated string written_before = 6;
 //	}
 type ProtoingFast struct{}
 
@@ -31,16 +63,25 @@ func (p *ProtoingFast) Unmarshal(in []byte) (*StoreData, uint64, error) {
 	return &StoreData{
 		Kv:             stateData.GetKv(),
 		DeletePrefixes: stateData.GetDeletePrefixes(),
+		DeleteRanges:   deleteRangesFromProto(stateData.GetDeleteRanges()),
+		WrittenAt:      stateData.GetWrittenAt(),
+		IndexEntries:   indexEntriesFromProto(stateData.GetIndexEntries()),
 	}, 0, nil
 }
 
 func (p *ProtoingFast) Marshal(data *StoreData) ([]byte, error) {
 	sizeInBytes := p.kvByteSize(data.Kv)
 	sizeInBytes += p.listByteSize(data.DeletePrefixes)
+	sizeInBytes += p.deleteRangesByteSize(data.DeleteRanges)
+	sizeInBytes += p.writtenAtByteSize(data.WrittenAt)
+	sizeInBytes += p.indexEntriesByteSize(data.IndexEntries)
 	buffer := make([]byte, sizeInBytes)
 	cursor := buffer
 	cursor = p.writeKV(cursor, data.Kv)
-	p.writeDeletePrefix(cursor, data.DeletePrefixes)
+	cursor = p.writeDeletePrefix(cursor, data.DeletePrefixes)
+	cursor = p.writeDeleteRanges(cursor, data.DeleteRanges)
+	cursor = p.writeWrittenAt(cursor, data.WrittenAt)
+	p.writeIndexEntries(cursor, data.IndexEntries)
 	return buffer, nil
 
 }
@@ -76,6 +117,86 @@ func (p *ProtoingFast) listByteSize(list []string) int {
 	return size
 }
 
+func (p *ProtoingFast) deleteRangesByteSize(ranges []DeleteRange) int {
+	size := 0
+	for _, r := range ranges {
+		entrySize := deleteRangeEntryByteSize(r)
+		size += 1                                   // List element proto tag 0x1a (field number 3 [the DeleteRanges field], type LEN [message])
+		size += uvarintByteCount(uint64(entrySize)) // Number of bytes of the message
+		size += entrySize
+	}
+	return size
+}
+
+func deleteRangeEntryByteSize(r DeleteRange) int {
+	size := 0
+	for _, field := range []string{r.LowKey, r.HighKey, r.PointerSeparator} {
+		if field == "" {
+			continue // empty fields are not written
+		}
+		size += 1                                    // Field proto tag (field number 1, 2 or 3, type LEN [string])
+		size += uvarintByteCount(uint64(len(field))) // Number of bytes (characters) in the field
+		size += len(field)                           // field
+	}
+	for _, keys := range [][]string{r.Pointers, r.WrittenKeys, r.WrittenBefore} {
+		for _, key := range keys {
+			size += 1                                  // List element proto tag 0x22, 0x2a or 0x32 (field number 4, 5 or 6 [the pointers, written_keys or written_before field], type LEN [string])
+			size += uvarintByteCount(uint64(len(key))) // Number of bytes (characters) in the key
+			size += len(key)                           // key
+		}
+	}
+	return size
+}
+
+func (p *ProtoingFast) writtenAtByteSize(entries map[string]uint64) int {
+	size := 0
+	for k, v := range entries {
+		entrySize := writtenAtEntryByteSize(k, v)
+		size += 1                                   // Map Key/Value proto tag 0x22 (field number 4 [the WrittenAt field], type LEN [map entry])
+		size += uvarintByteCount(uint64(entrySize)) // Number of bytes to represent both key and value
+		size += entrySize
+	}
+	return size
+}
+
+func writtenAtEntryByteSize(key string, value uint64) int {
+	size := 1                                  // Key proto tag 0x0a (field number 1 [the key], type LEN [string])
+	size += uvarintByteCount(uint64(len(key))) // Number of bytes (characters) in the key
+	size += len(key)                           // key
+	size += 1                                  // Value proto tag 0x10 (field number 2 [the value], type VARINT)
+	size += uvarintByteCount(value)            // value
+	return size
+}
+
+func (p *ProtoingFast) indexEntriesByteSize(entries []IndexEntries) int {
+	size := 0
+	for _, e := range entries {
+		entrySize := indexEntriesEntryByteSize(e)
+		size += 1                                   // List element proto tag 0x2a (field number 5 [the IndexEntries field], type LEN [message])
+		size += uvarintByteCount(uint64(entrySize)) // Number of bytes of the message
+		size += entrySize
+	}
+	return size
+}
+
+func indexEntriesEntryByteSize(e IndexEntries) int {
+	size := 0
+	for _, field := range []string{e.Index, e.Value} {
+		if field == "" {
+			continue // empty fields are not written
+		}
+		size += 1                                    // Field proto tag (field number 1 or 2, type LEN [string])
+		size += uvarintByteCount(uint64(len(field))) // Number of bytes (characters) in the field
+		size += len(field)                           // field
+	}
+	for _, key := range e.Keys {
+		size += 1                                  // List element proto tag 0x1a (field number 3 [the keys field], type LEN [string])
+		size += uvarintByteCount(uint64(len(key))) // Number of bytes (characters) in the key
+		size += len(key)                           // key
+	}
+	return size
+}
+
 func (p *ProtoingFast) writeKV(cursor []byte, entries map[string][]byte) []byte {
 	for key, value := range entries {
 		copy(cursor, []byte{KVEntryProtoTag})
@@ -118,3 +239,101 @@ func (p *ProtoingFast) writeDeletePrefix(cursor []byte, entries []string) []byte
 	}
 	return cursor
 }
+
+func (p *ProtoingFast) writeDeleteRanges(cursor []byte, ranges []DeleteRange) []byte {
+	for _, r := range ranges {
+		copy(cursor, []byte{DeleteRangeEntryProtoTag})
+		cursor = cursor[1:]
+
+		written := binary.PutUvarint(cursor, uint64(deleteRangeEntryByteSize(r)))
+		cursor = cursor[written:]
+
+		cursor = writeStringField(cursor, DeleteRangeLowKeyProtoTag, r.LowKey)
+		cursor = writeStringField(cursor, DeleteRangeHighKeyProtoTag, r.HighKey)
+		cursor = writeStringField(cursor, DeleteRangePointerSeparatorProtoTag, r.PointerSeparator)
+		cursor = writeStringList(cursor, DeleteRangePointerProtoTag, r.Pointers)
+		cursor = writeStringList(cursor, DeleteRangeWrittenKeyProtoTag, r.WrittenKeys)
+		cursor = writeStringList(cursor, DeleteRangeWrittenBeforeProtoTag, r.WrittenBefore)
+	}
+	return cursor
+}
+
+// writeStringList writes each value as an element of a repeated string field, empty ones included.
+func writeStringList(cursor []byte, tag byte, values []string) []byte {
+	for _, value := range values {
+		copy(cursor, []byte{tag})
+		cursor = cursor[1:]
+
+		written := binary.PutUvarint(cursor, uint64(len(value)))
+		cursor = cursor[written:]
+
+		copy(cursor, unsafeGetBytes(value))
+		cursor = cursor[len(value):]
+	}
+	return cursor
+}
+
+func writeStringField(cursor []byte, tag byte, value string) []byte {
+	if value == "" {
+		return cursor
+	}
+
+	copy(cursor, []byte{tag})
+	cursor = cursor[1:]
+
+	written := binary.PutUvarint(cursor, uint64(len(value)))
+	cursor = cursor[written:]
+
+	copy(cursor, unsafeGetBytes(value))
+	return cursor[len(value):]
+}
+
+func (p *ProtoingFast) writeWrittenAt(cursor []byte, entries map[string]uint64) []byte {
+	for key, value := range entries {
+		copy(cursor, []byte{WrittenAtEntryProtoTag})
+		cursor = cursor[1:]
+
+		written := binary.PutUvarint(cursor, uint64(writtenAtEntryByteSize(key, value)))
+		cursor = cursor[written:]
+
+		copy(cursor, []byte{WrittenAtEntryKeyProtoTag})
+		cursor = cursor[1:]
+
+		written = binary.PutUvarint(cursor, uint64(len(key)))
+		cursor = cursor[written:]
+
+		copy(cursor, unsafeGetBytes(key))
+		cursor = cursor[len(key):]
+
+		copy(cursor, []byte{WrittenAtEntryValueProtoTag})
+		cursor = cursor[1:]
+
+		written = binary.PutUvarint(cursor, value)
+		cursor = cursor[written:]
+	}
+	return cursor
+}
+
+func (p *ProtoingFast) writeIndexEntries(cursor []byte, entries []IndexEntries) []byte {
+	for _, e := range entries {
+		copy(cursor, []byte{IndexEntriesProtoTag})
+		cursor = cursor[1:]
+
+		written := binary.PutUvarint(cursor, uint64(indexEntriesEntryByteSize(e)))
+		cursor = cursor[written:]
+
+		cursor = writeStringField(cursor, IndexEntriesIndexProtoTag, e.Index)
+		cursor = writeStringField(cursor, IndexEntriesValueProtoTag, e.Value)
+		for _, key := range e.Keys {
+			copy(cursor, []byte{IndexEntriesKeyProtoTag})
+			cursor = cursor[1:]
+
+			written = binary.PutUvarint(cursor, uint64(len(key)))
+			cursor = cursor[written:]
+
+			copy(cursor, unsafeGetBytes(key))
+			cursor = cursor[len(key):]
+		}
+	}
+	return cursor
+}
@@ -53,6 +53,49 @@ func TestProtoFast_CompareMarshal(t *testing.T) {
 				DeletePrefixes: []string{"22"},
 			},
 		},
+		{
+			name: "only delete ranges",
+			data: &StoreData{
+				DeleteRanges: []DeleteRange{
+					{LowKey: "a", HighKey: "b"},
+					{LowKey: "c", PointerSeparator: ","},
+					{HighKey: "z"},
+				},
+			},
+		},
+		{
+			name: "delete prefix and ranges",
+			data: &StoreData{
+				DeletePrefixes: []string{"22"},
+				DeleteRanges: []DeleteRange{
+					{LowKey: "a", HighKey: "b", PointerSeparator: ","},
+					{LowKey: "c", PointerSeparator: ",", Pointers: []string{"x", ""}, WrittenKeys: []string{"c", "d"}, WrittenBefore: []string{"y"}},
+				},
+			},
+		},
+		{
+			name: "only written at",
+			data: &StoreData{
+				WrittenAt: map[string]uint64{"a": 12_000},
+			},
+		},
+		{
+			name: "kv and written at",
+			data: &StoreData{
+				Kv:        map[string][]byte{"a": {0xaa}},
+				WrittenAt: map[string]uint64{"a": 0},
+			},
+		},
+		{
+			name: "kv and index entries",
+			data: &StoreData{
+				Kv: map[string][]byte{"pool:a": {0xaa}},
+				IndexEntries: []IndexEntries{
+					{Index: "by_token", Value: "x", Keys: []string{"pool:a", "pool:b"}},
+					{Index: "by_token", Value: "", Keys: []string{"pool:c"}},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -77,6 +120,10 @@ func TestProtoFast_CompareMarshal(t *testing.T) {
 
 			assert.Equal(t, test.data, v)
 
+			v, _, err = vp.Unmarshal(vtProtoData)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.data, v)
 		})
 	}
 }
@@ -18,16 +18,23 @@ import (
 func (b *baseStore) setKV(k string, v []byte) {
 	if prev, ok := b.kv[k]; ok {
 		b.totalSizeBytes -= uint64(len(prev))
+		b.removeFromIndexes(k, prev)
 	} else {
 		b.totalSizeBytes += uint64(len(k))
+		b.indexKey(k)
 	}
 	b.totalSizeBytes += uint64(len(v))
 	b.kv[k] = v
+	b.addToIndexes(k, v)
+	b.markWritten(k)
 }
 
 func (b *baseStore) setNewKV(k string, v []byte) {
 	b.totalSizeBytes += uint64(len(k) + len(v))
 	b.kv[k] = v
+	b.indexKey(k)
+	b.addToIndexes(k, v)
+	b.markWritten(k)
 }
 
 // Merge nextStore _into_ `s`, where nextStore is for the next contiguous segment's store output.
@@ -49,8 +56,11 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 	if err := b.Flush(); err != nil {
 		return err
 	}
-	if len(kvPartialStore.DeletedPrefixes) > 0 {
-		b.logger.Debug("merging: applied delete prefixes", zap.Duration("duration", time.Since(partialKvTime)))
+	for i := range kvPartialStore.DeletedRanges {
+		b.mergeDeletedRange(kvPartialStore.lastOrdinal, kvPartialStore, i)
+	}
+	if len(kvPartialStore.DeletedPrefixes) > 0 || len(kvPartialStore.DeletedRanges) > 0 {
+		b.logger.Debug("merging: applied delete prefixes and ranges", zap.Duration("duration", time.Since(partialKvTime)))
 	}
 
 	intoValueTypeLower := strings.ToLower(b.valueType)
@@ -357,6 +367,19 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 		default:
 			return fmt.Errorf("update policy %q not supported for value type %q", b.updatePolicy, b.valueType)
 		}
+	case pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM:
+		for k, v := range kvPartialStore.kv {
+			prevVal, found := b.kv[k]
+			if !found {
+				b.setNewKV(k, v)
+				continue
+			}
+			nextVal, err := b.mergeValues(prevVal, v)
+			if err != nil {
+				return fmt.Errorf("merging key %q: %w", k, err)
+			}
+			b.setKV(k, nextVal)
+		}
 	default:
 		return fmt.Errorf("update policy %q not supported", b.updatePolicy) // should have been validated already
 	}
@@ -484,7 +484,9 @@ func newPartialStore(kv map[string][]byte, updatePolicy pbsubstreams.Module_Kind
 		},
 	}
 
-	return &PartialKV{baseStore: b, DeletedPrefixes: deletedPrefixes, seen: make(map[string]bool)}
+	p := newPartialKV(b, 0)
+	p.DeletedPrefixes = deletedPrefixes
+	return p
 }
 
 func newStore(kv map[string][]byte, updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy, valueType string) *FullKV {
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
+	"sort"
 
 	"github.com/shopspring/decimal"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -20,14 +22,33 @@ type PartialKV struct {
 
 	initialBlock    uint64 // block at which we initialized this store
 	DeletedPrefixes []string
+	DeletedRanges   []marshaller.DeleteRange
 
 	loadedFrom string
 	seen       map[string]bool
+	// written are the keys written since the last range deleted with a pointer separator, and writtenBefore
+	// the index in DeletedRanges of the range listing each key in its WrittenBefore
+	written       map[string]bool
+	writtenBefore map[string]int
+}
+
+func newPartialKV(b *baseStore, initialBlock uint64) *PartialKV {
+	p := &PartialKV{
+		baseStore:     b,
+		initialBlock:  initialBlock,
+		seen:          make(map[string]bool),
+		written:       make(map[string]bool),
+		writtenBefore: make(map[string]int),
+	}
+	b.onDeleteRange = p.addDeletedRange
+	b.onWrite = p.recordWrite
+	return p
 }
 
 func (p *PartialKV) Roll(lastBlock uint64) {
 	p.initialBlock = lastBlock
 	p.baseStore.kv = map[string][]byte{}
+	p.baseStore.keyIndex = nil
 }
 
 func (p *PartialKV) InitialBlock() uint64 { return p.initialBlock }
@@ -47,11 +68,19 @@ func (p *PartialKV) Load(ctx context.Context, file *FileInfo) error {
 	}
 
 	p.kv = storeData.Kv
+	p.keyIndex = nil
 	if p.kv == nil {
 		p.kv = map[string][]byte{}
 	}
 	p.totalSizeBytes = size
 	p.DeletedPrefixes = storeData.DeletePrefixes
+	p.DeletedRanges = storeData.DeleteRanges
+	p.writtenBefore = make(map[string]int)
+	for i, deletedRange := range p.DeletedRanges {
+		for _, key := range deletedRange.WrittenBefore {
+			p.writtenBefore[key] = i
+		}
+	}
 
 	p.logger.Debug("partial store loaded", zap.String("filename", file.Filename), zap.Int("key_count", len(p.kv)), zap.Uint64("data_size", size))
 	return nil
@@ -63,6 +92,7 @@ func (p *PartialKV) Save(endBoundaryBlock uint64) (*FileInfo, *fileWriter, error
 	stateData := &marshaller.StoreData{
 		Kv:             p.kv,
 		DeletePrefixes: p.DeletedPrefixes,
+		DeleteRanges:   p.DeletedRanges,
 	}
 
 	content, err := p.marshaller.Marshal(stateData)
@@ -91,6 +121,56 @@ func (p *PartialKV) DeletePrefix(ord uint64, prefix string) {
 	}
 }
 
+// addDeletedRange records a range deleted when flushing, for the merge into the full store. With a pointer
+// separator, the keys written since the previous one and held outside of the range are listed in its
+// WrittenBefore, and it is only skipped when repeating the last range: the merge drops the keys written
+// before it depending on its position.
+func (p *PartialKV) addDeletedRange(deletedRange marshaller.DeleteRange) {
+	if deletedRange.PointerSeparator == "" {
+		if !slices.ContainsFunc(p.DeletedRanges, deletedRange.Equal) {
+			p.DeletedRanges = append(p.DeletedRanges, deletedRange)
+		}
+		return
+	}
+
+	for key := range p.written {
+		if _, found := p.kv[key]; found && !inRange(key, deletedRange.LowKey, deletedRange.HighKey) {
+			deletedRange.WrittenBefore = append(deletedRange.WrittenBefore, key)
+		}
+	}
+	sort.Strings(deletedRange.WrittenBefore)
+	clear(p.written)
+
+	if n := len(p.DeletedRanges); n > 0 && p.DeletedRanges[n-1].Equal(deletedRange) {
+		return
+	}
+	p.DeletedRanges = append(p.DeletedRanges, deletedRange)
+	for _, key := range deletedRange.WrittenBefore {
+		p.writtenBefore[key] = len(p.DeletedRanges) - 1
+	}
+}
+
+// recordWrite removes a key written again from the WrittenBefore of the range listing it.
+func (p *PartialKV) recordWrite(key string) {
+	if i, found := p.writtenBefore[key]; found {
+		deletedRange := &p.DeletedRanges[i]
+		if j, found := slices.BinarySearch(deletedRange.WrittenBefore, key); found {
+			deletedRange.WrittenBefore = slices.Delete(deletedRange.WrittenBefore, j, j+1)
+		}
+		delete(p.writtenBefore, key)
+	}
+	p.written[key] = true
+}
+
+// dropKey removes a key written before a range whose deletion, replayed by the merge, deletes it.
+func (p *PartialKV) dropKey(key string) {
+	if value, found := p.kv[key]; found {
+		delete(p.kv, key)
+		p.unindexKey(key)
+		p.totalSizeBytes -= uint64(len(key) + len(value))
+	}
+}
+
 func (p *PartialKV) DeleteStore(ctx context.Context, file *FileInfo) (err error) {
 	zlog.Debug("deleting partial store file", zap.String("file_name", file.Filename))
 
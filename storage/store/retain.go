@@ -0,0 +1,135 @@
+package store
+
+import (
+	"go.uber.org/zap"
+)
+
+func (b *baseStore) markWritten(key string) {
+	if b.onWrite != nil {
+		b.onWrite(key)
+	}
+	if b.writtenAt != nil {
+		b.retainUndo.recordStamp(key)
+		b.writtenAt[key] = 0
+	}
+}
+
+// Retain is to be called when reaching `boundaryBlock`, a multiple of the
+// segment size. Keys written since the previous boundary are stamped with
+// this one, and keys not written for `retainBlocks` blocks are dropped. The
+// drops emit no deltas: the same keys are dropped whether the segments were
+// processed linearly or merged from partial stores, keeping the store
+// content deterministic.
+//
+// Undoing a block crossing a boundary restores the dropped keys and the
+// stamps through the RetainUndo of the block, see TrackRetainUndo.
+func (s *FullKV) Retain(boundaryBlock uint64) {
+	if s.writtenAt == nil {
+		return
+	}
+
+	dropped := 0
+	for key, value := range s.kv {
+		writtenAt := s.writtenAt[key]
+		if writtenAt == 0 {
+			s.retainUndo.recordStamp(key)
+			s.writtenAt[key] = boundaryBlock
+			continue
+		}
+		if writtenAt+s.retainBlocks <= boundaryBlock {
+			s.retainUndo.recordDropped(key, value)
+			delete(s.kv, key)
+			s.unindexKey(key)
+			s.removeFromIndexes(key, value)
+			s.totalSizeBytes -= uint64(len(key) + len(value))
+			dropped++
+		}
+	}
+
+	for key := range s.writtenAt {
+		if _, found := s.kv[key]; !found {
+			s.retainUndo.recordStamp(key)
+			delete(s.writtenAt, key)
+		}
+	}
+
+	if dropped > 0 {
+		s.logger.Debug("dropped keys not written within retain blocks", zap.Uint64("boundary_block", boundaryBlock), zap.Uint64("retain_blocks", s.retainBlocks), zap.Int("dropped_key_count", dropped))
+	}
+}
+
+// TrackRetainUndo starts recording the changes made to the retain state of a
+// store having retain blocks, the stamps of the keys and the keys dropped by
+// Retain, for TakeRetainUndo. Reverting the deltas of a block does not restore
+// them.
+func (s *FullKV) TrackRetainUndo() {
+	if s.writtenAt != nil && s.retainUndo == nil {
+		s.retainUndo = &RetainUndo{store: s.baseStore}
+	}
+}
+
+// TakeRetainUndo returns the changes made to the retain state since the
+// previous call, nil when there are none or they are not tracked.
+func (s *FullKV) TakeRetainUndo() *RetainUndo {
+	undo := s.retainUndo
+	if undo == nil || (len(undo.stamps) == 0 && len(undo.dropped) == 0) {
+		return nil
+	}
+	s.retainUndo = &RetainUndo{store: s.baseStore}
+	return undo
+}
+
+// RetainUndo holds the previous retain state of the keys changed in a store.
+type RetainUndo struct {
+	store   *baseStore
+	stamps  map[string]previousStamp
+	dropped map[string][]byte
+}
+
+type previousStamp struct {
+	stamp uint64
+	found bool
+}
+
+func (u *RetainUndo) recordStamp(key string) {
+	if u == nil {
+		return
+	}
+	if _, found := u.stamps[key]; found {
+		return
+	}
+	if u.stamps == nil {
+		u.stamps = make(map[string]previousStamp)
+	}
+	stamp, found := u.store.writtenAt[key]
+	u.stamps[key] = previousStamp{stamp: stamp, found: found}
+}
+
+func (u *RetainUndo) recordDropped(key string, value []byte) {
+	if u == nil {
+		return
+	}
+	if u.dropped == nil {
+		u.dropped = make(map[string][]byte)
+	}
+	u.dropped[key] = value
+}
+
+// Undo restores the keys dropped and the stamps changed, to be called once the
+// deltas of the same blocks have been reverted, the latest changes first.
+func (u *RetainUndo) Undo() {
+	s := u.store
+	for key, value := range u.dropped {
+		s.kv[key] = value
+		s.indexKey(key)
+		s.addToIndexes(key, value)
+		s.totalSizeBytes += uint64(len(key) + len(value))
+	}
+	for key, previous := range u.stamps {
+		if previous.found {
+			s.writtenAt[key] = previous.stamp
+		} else {
+			delete(s.writtenAt, key)
+		}
+	}
+}
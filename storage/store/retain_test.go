@@ -0,0 +1,152 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func newRetainConfig(t *testing.T, retainBlocks uint64, objStore dstore.Store) *Config {
+	if objStore == nil {
+		objStore = dstore.NewMockStore(nil)
+	}
+	config, err := NewConfig("test", 0, "test.module.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", objStore)
+	require.NoError(t, err)
+	config.SetRetainBlocks(retainBlocks)
+	return config
+}
+
+type retainSegment struct {
+	sets            map[string]string
+	deletedPrefixes []string
+}
+
+// retainSegments are processed with a segment size of 1000 and retain blocks of 2000
+var retainSegments = []retainSegment{
+	{sets: map[string]string{"a": "1", "b": "1"}},
+	{sets: map[string]string{"b": "2"}},
+	{sets: map[string]string{"c": "1"}},
+	{deletedPrefixes: []string{"c"}},
+}
+
+func TestFullKV_Retain(t *testing.T) {
+	s := newRetainConfig(t, 2000, nil).NewFullKV(zap.NewNop())
+
+	type expect struct {
+		kv        map[string][]byte
+		writtenAt map[string]uint64
+	}
+	expected := []expect{
+		{
+			kv:        map[string][]byte{"a": []byte("1"), "b": []byte("1")},
+			writtenAt: map[string]uint64{"a": 1000, "b": 1000},
+		},
+		{
+			kv:        map[string][]byte{"a": []byte("1"), "b": []byte("2")},
+			writtenAt: map[string]uint64{"a": 1000, "b": 2000},
+		},
+		{
+			kv:        map[string][]byte{"b": []byte("2"), "c": []byte("1")},
+			writtenAt: map[string]uint64{"b": 2000, "c": 3000},
+		},
+		{
+			kv:        map[string][]byte{},
+			writtenAt: map[string]uint64{},
+		},
+	}
+
+	for i, segment := range retainSegments {
+		for key, value := range segment.sets {
+			s.Set(0, key, value)
+		}
+		for _, prefix := range segment.deletedPrefixes {
+			s.DeletePrefix(0, prefix)
+		}
+		require.NoError(t, s.Flush())
+		s.Reset()
+
+		s.Retain(uint64(i+1) * 1000)
+		assert.Equal(t, expected[i].kv, s.kv, "segment %d", i)
+		assert.Equal(t, expected[i].writtenAt, s.writtenAt, "segment %d", i)
+	}
+	assert.Equal(t, uint64(0), s.totalSizeBytes)
+}
+
+func TestFullKV_Retain_Merge(t *testing.T) {
+	config := newRetainConfig(t, 2000, nil)
+	linear := config.NewFullKV(zap.NewNop())
+	merged := config.NewFullKV(zap.NewNop())
+
+	for i, segment := range retainSegments {
+		partial := config.NewPartialKV(uint64(i)*1000, zap.NewNop())
+		for key, value := range segment.sets {
+			linear.Set(0, key, value)
+			partial.Set(0, key, value)
+		}
+		for _, prefix := range segment.deletedPrefixes {
+			linear.DeletePrefix(0, prefix)
+			partial.DeletePrefix(0, prefix)
+		}
+		require.NoError(t, linear.Flush())
+		require.NoError(t, partial.Flush())
+		linear.Reset()
+
+		require.NoError(t, merged.Merge(partial))
+
+		boundary := uint64(i+1) * 1000
+		linear.Retain(boundary)
+		merged.Retain(boundary)
+		assert.Equal(t, linear.kv, merged.kv, "segment %d", i)
+		assert.Equal(t, linear.writtenAt, merged.writtenAt, "segment %d", i)
+		assert.Equal(t, linear.totalSizeBytes, merged.totalSizeBytes, "segment %d", i)
+	}
+}
+
+func TestFullKV_Retain_SaveLoad(t *testing.T) {
+	var writtenBytes []byte
+	objStore := dstore.NewMockStore(func(base string, f io.Reader) (err error) {
+		writtenBytes, err = io.ReadAll(f)
+		return err
+	})
+	objStore.OpenObjectFunc = func(ctx context.Context, name string) (out io.ReadCloser, err error) {
+		return io.NopCloser(bytes.NewBuffer(writtenBytes)), nil
+	}
+	config := newRetainConfig(t, 2000, objStore)
+
+	s := config.NewFullKV(zap.NewNop())
+	s.Set(0, "a", "1")
+	require.NoError(t, s.Flush())
+	s.Retain(1000)
+
+	file, writer, err := s.Save(1000)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(context.Background()))
+
+	loaded := config.NewFullKV(zap.NewNop())
+	require.NoError(t, loaded.Load(context.Background(), file))
+	assert.Equal(t, map[string]uint64{"a": 1000}, loaded.writtenAt)
+
+	loaded.Retain(2000)
+	assert.Equal(t, map[string][]byte{"a": []byte("1")}, loaded.kv)
+	loaded.Retain(3000)
+	assert.Empty(t, loaded.kv)
+}
+
+func TestFullKV_Retain_Disabled(t *testing.T) {
+	s := newRetainConfig(t, 0, nil).NewFullKV(zap.NewNop())
+	s.Set(0, "a", "1")
+	require.NoError(t, s.Flush())
+
+	s.Retain(1000)
+	s.Retain(100_000)
+	assert.Nil(t, s.writtenAt)
+	assert.Equal(t, map[string][]byte{"a": []byte("1")}, s.kv)
+}
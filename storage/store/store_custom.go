@@ -0,0 +1,40 @@
+package store
+
+import (
+	"fmt"
+
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+)
+
+// AggregateFunc merges the value `next` of a key into its previous value `prev`, for stores with
+// the custom update policy. It must be deterministic and associative, so that stores merged from
+// partial stores hold the same values as stores processed linearly.
+type AggregateFunc func(prev, next []byte) ([]byte, error)
+
+func (b *baseStore) Aggregate(ord uint64, key string, value []byte) {
+	b.kvOps.Add(&pbssinternal.Operation{
+		Type:  pbssinternal.Operation_AGGREGATE,
+		Ord:   ord,
+		Key:   key,
+		Value: cloneBytes(value),
+	})
+}
+
+func (b *baseStore) aggregate(ord uint64, key string, value []byte) error {
+	if prev, found := b.GetAt(ord, key); found {
+		merged, err := b.mergeValues(prev, value)
+		if err != nil {
+			return fmt.Errorf("aggregating key %q: %w", key, err)
+		}
+		value = merged
+	}
+	b.set(ord, key, value)
+	return nil
+}
+
+func (b *baseStore) mergeValues(prev, next []byte) ([]byte, error) {
+	if b.aggregateFunc == nil {
+		return nil, fmt.Errorf("store %q has no aggregate function for update policy %q", b.name, b.updatePolicy)
+	}
+	return b.aggregateFunc(prev, next)
+}
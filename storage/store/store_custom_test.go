@@ -0,0 +1,91 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// unionAggregate merges comma-separated sets of values
+func unionAggregate(prev, next []byte) ([]byte, error) {
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(string(prev)+","+string(next), ",") {
+		seen[value] = true
+	}
+	values := make([]string, 0, len(seen))
+	for value := range seen {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return []byte(strings.Join(values, ",")), nil
+}
+
+func newCustomConfig(t *testing.T, f AggregateFunc) *Config {
+	config, err := NewConfig("test", 0, "test.module.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM, "string", dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	config.SetAggregateFunc(f)
+	return config
+}
+
+func TestStore_Aggregate(t *testing.T) {
+	s := newCustomConfig(t, unionAggregate).NewFullKV(zap.NewNop())
+
+	s.Aggregate(0, "users", []byte("bob"))
+	s.Aggregate(1, "users", []byte("alice"))
+	s.Aggregate(2, "users", []byte("bob"))
+	s.Aggregate(2, "admins", []byte("carol"))
+	require.NoError(t, s.Flush())
+
+	assert.Equal(t, map[string][]byte{
+		"users":  []byte("alice,bob"),
+		"admins": []byte("carol"),
+	}, s.kv)
+
+	val, found := s.GetAt(0, "users")
+	require.True(t, found)
+	assert.Equal(t, []byte("bob"), val)
+}
+
+func TestStore_Aggregate_Merge(t *testing.T) {
+	segments := [][]string{{"bob", "alice"}, {"bob", "dave"}, {"carol"}}
+
+	config := newCustomConfig(t, unionAggregate)
+	linear := config.NewFullKV(zap.NewNop())
+	merged := config.NewFullKV(zap.NewNop())
+	for i, values := range segments {
+		partial := config.NewPartialKV(uint64(i)*1000, zap.NewNop())
+		for ord, value := range values {
+			linear.Aggregate(uint64(ord), "users", []byte(value))
+			partial.Aggregate(uint64(ord), "users", []byte(value))
+		}
+		require.NoError(t, linear.Flush())
+		require.NoError(t, partial.Flush())
+		linear.Reset()
+
+		require.NoError(t, merged.Merge(partial))
+		assert.Equal(t, linear.kv, merged.kv, "segment %d", i)
+	}
+	assert.Equal(t, []byte("alice,bob,carol,dave"), merged.kv["users"])
+}
+
+func TestStore_Aggregate_NoAggregateFunc(t *testing.T) {
+	config := newCustomConfig(t, nil)
+
+	s := config.NewFullKV(zap.NewNop())
+	s.Aggregate(0, "users", []byte("bob"))
+	s.Aggregate(1, "users", []byte("alice"))
+	assert.Error(t, s.Flush())
+
+	full := config.NewFullKV(zap.NewNop())
+	full.kv["users"] = []byte("bob")
+	partial := config.NewPartialKV(1000, zap.NewNop())
+	partial.kv["users"] = []byte("alice")
+	assert.Error(t, full.Merge(partial))
+}
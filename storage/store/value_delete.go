@@ -1,11 +1,13 @@
 package store
 
 import (
+	"slices"
 	"sort"
 	"strings"
 
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store/marshaller"
 )
 
 func (b *baseStore) DeletePrefix(ord uint64, prefix string) {
@@ -38,3 +40,138 @@ func (b *baseStore) deletePrefix(ord uint64, prefix string) {
 	})
 	b.deltas = append(b.deltas, deltas...)
 }
+
+func (b *baseStore) DeleteRange(ord uint64, lowKey, highKey string) {
+	b.kvOps.Add(&pbssinternal.Operation{
+		Type:    pbssinternal.Operation_DELETE_RANGE,
+		Ord:     ord,
+		Key:     lowKey,
+		HighKey: highKey,
+	})
+}
+
+func (b *baseStore) DeleteRangePointers(ord uint64, lowKey, highKey, pointerSeparator string) {
+	b.kvOps.Add(&pbssinternal.Operation{
+		Type:             pbssinternal.Operation_DELETE_RANGE,
+		Ord:              ord,
+		Key:              lowKey,
+		HighKey:          highKey,
+		PointerSeparator: pointerSeparator,
+	})
+}
+
+// deleteRange deletes the keys between lowKey (inclusive) and highKey (exclusive, no upper bound when empty) and,
+// when pointerSeparator is set, the keys listed in their values, in key order with one delta per key deleted.
+func (b *baseStore) deleteRange(ord uint64, lowKey, highKey, pointerSeparator string) {
+	keys := b.rangeKeys(lowKey, highKey)
+
+	var pointers []string
+	if pointerSeparator != "" {
+		pointers = b.listedPointers(keys, lowKey, highKey, pointerSeparator)
+	}
+
+	if b.onDeleteRange != nil {
+		deletedRange := marshaller.DeleteRange{LowKey: lowKey, HighKey: highKey, PointerSeparator: pointerSeparator}
+		if pointerSeparator != "" {
+			deletedRange.Pointers = pointers
+			deletedRange.WrittenKeys = keys
+		}
+		b.onDeleteRange(deletedRange)
+	}
+
+	b.deleteKeys(ord, keys, pointers)
+}
+
+// mergeDeletedRange deletes the i-th range deleted by a partial store. The pointers are those the partial store
+// resolved and those listed in the values of the range keys it did not hold when deleting it, the others having
+// been overwritten in the segment before the delete. The keys listed in the full store values that the partial
+// store last wrote before the delete are dropped from it, instead of being written back by the merge.
+func (b *baseStore) mergeDeletedRange(ord uint64, partial *PartialKV, i int) {
+	deletedRange := partial.DeletedRanges[i]
+	keys := b.rangeKeys(deletedRange.LowKey, deletedRange.HighKey)
+
+	var pointers []string
+	if deletedRange.PointerSeparator != "" {
+		written := make(map[string]bool, len(deletedRange.WrittenKeys))
+		for _, key := range deletedRange.WrittenKeys {
+			written[key] = true
+		}
+
+		var followed []string
+		for _, key := range keys {
+			if !written[key] {
+				followed = append(followed, key)
+			}
+		}
+
+		followedPointers := b.listedPointers(followed, deletedRange.LowKey, deletedRange.HighKey, deletedRange.PointerSeparator)
+		for _, pointer := range followedPointers {
+			if j, found := partial.writtenBefore[pointer]; found && j <= i {
+				partial.dropKey(pointer)
+			}
+		}
+
+		pointers = append(followedPointers, deletedRange.Pointers...)
+		sort.Strings(pointers)
+		pointers = slices.Compact(pointers)
+	}
+
+	b.deleteKeys(ord, keys, pointers)
+}
+
+// rangeKeys returns, in order, the keys between lowKey (inclusive) and highKey (exclusive, no upper bound when empty).
+func (b *baseStore) rangeKeys(lowKey, highKey string) []string {
+	if b.keyIndex == nil {
+		b.keyIndex = newKeyIndex(b.kv)
+	}
+
+	var keys []string
+	b.keyIndex.ascend(lowKey, func(key string) bool {
+		if highKey != "" && key >= highKey {
+			return false
+		}
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// listedPointers returns, in order, the keys outside of the range listed in the values of keys.
+func (b *baseStore) listedPointers(keys []string, lowKey, highKey, pointerSeparator string) []string {
+	seen := make(map[string]bool)
+	var pointers []string
+	for _, key := range keys {
+		for _, pointer := range strings.Split(string(b.kv[key]), pointerSeparator) {
+			if !seen[pointer] && !inRange(pointer, lowKey, highKey) {
+				seen[pointer] = true
+				pointers = append(pointers, pointer)
+			}
+		}
+	}
+	sort.Strings(pointers)
+	return pointers
+}
+
+// deleteKeys deletes keys, then the pointers found in the store.
+func (b *baseStore) deleteKeys(ord uint64, keys, pointers []string) {
+	for _, key := range keys {
+		b.deleteKey(ord, key)
+	}
+	for _, pointer := range pointers {
+		if _, found := b.kv[pointer]; found {
+			b.deleteKey(ord, pointer)
+		}
+	}
+}
+
+func (b *baseStore) deleteKey(ord uint64, key string) {
+	delta := &pbsubstreams.StoreDelta{
+		Operation: pbsubstreams.StoreDelta_DELETE,
+		Ordinal:   ord,
+		Key:       key,
+		OldValue:  b.kv[key],
+		NewValue:  nil,
+	}
+	b.ApplyDelta(delta)
+	b.deltas = append(b.deltas, delta)
+}
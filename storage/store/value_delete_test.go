@@ -0,0 +1,212 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store/marshaller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteRange(t *testing.T) {
+	kv := map[string][]byte{
+		"pool:a":  []byte("token:x,token:y"),
+		"pool:b":  []byte("token:z,pool:a,token:missing"),
+		"pool:c":  []byte("token:x"),
+		"token:x": []byte("1"),
+		"token:y": []byte("2"),
+		"token:z": []byte("3"),
+	}
+
+	tests := []struct {
+		name             string
+		lowKey           string
+		highKey          string
+		pointerSeparator string
+		expectedDeleted  []string
+	}{
+		{"range", "pool:a", "pool:c", "", []string{"pool:a", "pool:b"}},
+		{"no upper bound", "pool:c", "", "", []string{"pool:c", "token:x", "token:y", "token:z"}},
+		{"empty range", "pool:d", "pool:z", "", nil},
+		{"pointers", "pool:a", "pool:c", ",", []string{"pool:a", "pool:b", "token:x", "token:y", "token:z"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+			for k, v := range kv {
+				s.kv[k] = v
+			}
+
+			if test.pointerSeparator == "" {
+				s.DeleteRange(1, test.lowKey, test.highKey)
+			} else {
+				s.DeleteRangePointers(1, test.lowKey, test.highKey, test.pointerSeparator)
+			}
+			require.NoError(t, s.Flush())
+
+			var deleted []string
+			for _, delta := range s.deltas {
+				assert.Equal(t, pbsubstreams.StoreDelta_DELETE, delta.Operation)
+				assert.Equal(t, kv[delta.Key], delta.OldValue)
+				deleted = append(deleted, delta.Key)
+			}
+			assert.Equal(t, test.expectedDeleted, deleted)
+			assert.Len(t, s.kv, len(kv)-len(test.expectedDeleted))
+
+			s.ApplyDeltasReverse(s.deltas)
+			assert.Equal(t, kv, s.kv)
+		})
+	}
+}
+
+func TestPartialKV_DeleteRange(t *testing.T) {
+	s := newPartialKV(newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil), 0)
+	s.kv["a"] = []byte("b")
+	s.DeleteRange(1, "a", "c")
+	s.DeleteRangePointers(2, "x", "", ",")
+	s.DeleteRange(3, "a", "c")
+	require.NoError(t, s.Flush())
+
+	assert.Empty(t, s.kv)
+	assert.Equal(t, []marshaller.DeleteRange{
+		{LowKey: "a", HighKey: "c"},
+		{LowKey: "x", PointerSeparator: ","},
+	}, s.DeletedRanges)
+}
+
+func TestMerge_DeletedRanges(t *testing.T) {
+	full := map[string]string{
+		"pool:a":  "token:x",
+		"pool:b":  "token:y,pool:a",
+		"token:x": "1",
+		"token:y": "2",
+		"token:z": "3",
+	}
+
+	tests := []struct {
+		name     string
+		segment  func(s Store)
+		expected []string
+	}{
+		{
+			name: "range only in full store",
+			segment: func(s Store) {
+				s.DeleteRangePointers(0, "pool:a", "pool:b", ",")
+			},
+			expected: []string{"pool:b", "token:y", "token:z"},
+		},
+		{
+			name: "pointers of values set before the delete",
+			segment: func(s Store) {
+				s.SetBytes(0, "pool:a", []byte("token:z"))
+				s.DeleteRangePointers(1, "pool:a", "pool:b", ",")
+			},
+			expected: []string{"pool:b", "token:x", "token:y"},
+		},
+		{
+			name: "pointers to full store keys",
+			segment: func(s Store) {
+				s.SetBytes(0, "pool:c", []byte("token:x,token:z"))
+				s.DeleteRangePointers(1, "pool:c", "pool:d", ",")
+			},
+			expected: []string{"pool:a", "pool:b", "token:y"},
+		},
+		{
+			name: "pointers written before the delete",
+			segment: func(s Store) {
+				s.SetBytes(0, "token:x", []byte("9"))
+				s.DeleteRangePointers(1, "pool:a", "pool:b", ",")
+			},
+			expected: []string{"pool:b", "token:y", "token:z"},
+		},
+		{
+			name: "pointers written before an earlier delete",
+			segment: func(s Store) {
+				s.SetBytes(0, "token:x", []byte("9"))
+				s.DeleteRangePointers(1, "pool:c", "pool:d", ",")
+				s.DeleteRangePointers(2, "pool:a", "pool:b", ",")
+			},
+			expected: []string{"pool:b", "token:y", "token:z"},
+		},
+		{
+			name: "pointers written again after the delete",
+			segment: func(s Store) {
+				s.SetBytes(0, "token:x", []byte("9"))
+				s.DeleteRangePointers(1, "pool:a", "pool:b", ",")
+				s.SetBytes(2, "token:x", []byte("8"))
+			},
+			expected: []string{"pool:b", "token:x", "token:y", "token:z"},
+		},
+		{
+			name: "set after the delete",
+			segment: func(s Store) {
+				s.DeleteRangePointers(0, "pool:", "pool:z", ",")
+				s.SetBytes(1, "pool:a", []byte("token:z"))
+				s.SetBytes(1, "token:x", []byte("4"))
+			},
+			expected: []string{"pool:a", "token:x", "token:z"},
+		},
+		{
+			name: "without pointers",
+			segment: func(s Store) {
+				s.SetBytes(0, "pool:c", []byte("token:z"))
+				s.DeleteRange(1, "pool:b", "token:y")
+			},
+			expected: []string{"pool:a", "token:y", "token:z"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var written []byte
+			objStore := dstore.NewMockStore(func(base string, f io.Reader) (err error) {
+				written, err = io.ReadAll(f)
+				return err
+			})
+			objStore.OpenObjectFunc = func(ctx context.Context, name string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(written)), nil
+			}
+			config, err := NewConfig("test", 0, "test.module.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", objStore)
+			require.NoError(t, err)
+
+			linear := config.NewFullKV(zap.NewNop())
+			merged := config.NewFullKV(zap.NewNop())
+			for _, s := range []*FullKV{linear, merged} {
+				for k, v := range full {
+					s.SetBytes(0, k, []byte(v))
+				}
+				require.NoError(t, s.Flush())
+				s.Reset()
+			}
+
+			partial := config.NewPartialKV(1000, zap.NewNop())
+			test.segment(linear)
+			test.segment(partial)
+			require.NoError(t, linear.Flush())
+			require.NoError(t, partial.Flush())
+
+			file, writer, err := partial.Save(2000)
+			require.NoError(t, err)
+			require.NoError(t, writer.Write(context.Background()))
+			loaded := config.NewPartialKV(1000, zap.NewNop())
+			require.NoError(t, loaded.Load(context.Background(), file))
+
+			require.NoError(t, merged.Merge(loaded))
+			assert.Equal(t, linear.kv, merged.kv)
+
+			var keys []string
+			for key := range merged.kv {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, test.expected, keys)
+		})
+	}
+}
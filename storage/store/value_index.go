@@ -0,0 +1,272 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store/marshaller"
+)
+
+// storeIndex is a secondary index of a full store, holding in order the keys having each indexed value.
+type storeIndex struct {
+	extract func(key string, value []byte) (string, bool)
+	entries map[string]*keyIndex
+}
+
+func newStoreIndex(def *pbsubstreams.Module_KindStore_Index) *storeIndex {
+	index := &storeIndex{entries: make(map[string]*keyIndex)}
+	switch extract := def.Extract.(type) {
+	case *pbsubstreams.Module_KindStore_Index_KeySegment:
+		position := int(extract.KeySegment)
+		index.extract = func(key string, _ []byte) (string, bool) {
+			return keySegment(key, position)
+		}
+	case *pbsubstreams.Module_KindStore_Index_ValueField:
+		number := protowire.Number(extract.ValueField)
+		index.extract = func(_ string, value []byte) (string, bool) {
+			return protoBytesField(value, number)
+		}
+	default:
+		panic(fmt.Sprintf("index %q has no extractor", def.Name))
+	}
+	return index
+}
+
+func validateIndexes(defs []*pbsubstreams.Module_KindStore_Index) error {
+	seen := make(map[string]bool)
+	for _, def := range defs {
+		if def.Name == "" {
+			return fmt.Errorf("index without a name")
+		}
+		if seen[def.Name] {
+			return fmt.Errorf("duplicate index %q", def.Name)
+		}
+		seen[def.Name] = true
+		if def.Extract == nil {
+			return fmt.Errorf("index %q defines neither a key segment nor a value field", def.Name)
+		}
+	}
+	return nil
+}
+
+// keySegment returns the segment of key at position, segments being separated by ':'
+func keySegment(key string, position int) (string, bool) {
+	for i := 0; i < position; i++ {
+		pos := strings.IndexByte(key, ':')
+		if pos == -1 {
+			return "", false
+		}
+		key = key[pos+1:]
+	}
+	if pos := strings.IndexByte(key, ':'); pos != -1 {
+		key = key[:pos]
+	}
+	return key, true
+}
+
+// protoBytesField returns the value of the string or bytes field `number` of the protobuf message
+// encoded in value, the last one winning when repeated. Values that are not valid protobuf messages
+// and fields absent or of another wire type are not found.
+func protoBytesField(value []byte, number protowire.Number) (out string, found bool) {
+	for len(value) > 0 {
+		num, typ, n := protowire.ConsumeTag(value)
+		if n < 0 {
+			return "", false
+		}
+		value = value[n:]
+
+		if num == number && typ == protowire.BytesType {
+			field, n := protowire.ConsumeBytes(value)
+			if n < 0 {
+				return "", false
+			}
+			out, found = string(field), true
+			value = value[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, value)
+		if n < 0 {
+			return "", false
+		}
+		value = value[n:]
+	}
+	return
+}
+
+func (i *storeIndex) add(key string, value []byte) {
+	indexed, ok := i.extract(key, value)
+	if !ok {
+		return
+	}
+	keys := i.entries[indexed]
+	if keys == nil {
+		keys = newKeyIndexFromKeys(nil)
+		i.entries[indexed] = keys
+	}
+	keys.add(key)
+}
+
+func (i *storeIndex) remove(key string, value []byte) {
+	indexed, ok := i.extract(key, value)
+	if !ok {
+		return
+	}
+	if keys := i.entries[indexed]; keys != nil {
+		keys.remove(key)
+		if keys.len() == 0 {
+			delete(i.entries, indexed)
+		}
+	}
+}
+
+func (b *baseStore) addToIndexes(key string, value []byte) {
+	for _, index := range b.indexes {
+		index.add(key, value)
+	}
+}
+
+func (b *baseStore) removeFromIndexes(key string, value []byte) {
+	for _, index := range b.indexes {
+		index.remove(key, value)
+	}
+}
+
+// resetIndexes builds the indexes from the persisted entries, or from the keys of the store when
+// none were persisted.
+func (b *baseStore) resetIndexes(persisted []marshaller.IndexEntries) {
+	if b.indexes == nil {
+		return
+	}
+
+	for name, def := range b.indexDefs() {
+		b.indexes[name] = newStoreIndex(def)
+	}
+
+	if len(persisted) == 0 {
+		for key, value := range b.kv {
+			b.addToIndexes(key, value)
+		}
+		return
+	}
+
+	for _, entries := range persisted {
+		index := b.indexes[entries.Index]
+		if index == nil {
+			continue
+		}
+		index.entries[entries.Value] = newKeyIndexFromKeys(entries.Keys)
+	}
+}
+
+func (b *baseStore) indexDefs() map[string]*pbsubstreams.Module_KindStore_Index {
+	defs := make(map[string]*pbsubstreams.Module_KindStore_Index, len(b.Config.indexes))
+	for _, def := range b.Config.indexes {
+		defs[def.Name] = def
+	}
+	return defs
+}
+
+// indexEntries returns the entries of the indexes to persist, sorted by index and value.
+func (b *baseStore) indexEntries() (out []marshaller.IndexEntries) {
+	names := make([]string, 0, len(b.indexes))
+	for name := range b.indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		index := b.indexes[name]
+		values := make([]string, 0, len(index.entries))
+		for value := range index.entries {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+
+		for _, value := range values {
+			out = append(out, marshaller.IndexEntries{Index: name, Value: value, Keys: index.entries[value].list()})
+		}
+	}
+	return
+}
+
+// IndexLookupAt calls f, in key order, with the keys greater than `after` whose value for the index
+// named `index` is `value`, and their value, for the state that includes the processing of `ord`,
+// until f returns false.
+func (b *baseStore) IndexLookupAt(ord uint64, index, value, after string, f func(key string, value []byte) bool) error {
+	storeIndex := b.indexes[index]
+	if storeIndex == nil {
+		return fmt.Errorf("store %q has no index %q", b.name, index)
+	}
+
+	indexed := storeIndex.entries[value]
+
+	// changed are the keys changed after `ord`, whose value at `ord` may be indexed under another value
+	changed := map[string]bool{}
+	for i := len(b.deltas) - 1; i >= 0 && b.deltas[i].Ordinal > ord; i-- {
+		if key := b.deltas[i].Key; key > after {
+			changed[key] = true
+		}
+	}
+
+	// unindexed are the changed keys not indexed under `value` anymore, merged in order with the indexed ones
+	var unindexed []string
+	for key := range changed {
+		if indexed == nil || !indexed.has(key) {
+			unindexed = append(unindexed, key)
+		}
+	}
+	sort.Strings(unindexed)
+
+	// emit calls f with the key if its value at `ord` is indexed under `value`, returning false once the lookup is over
+	emit := func(key string) bool {
+		var keyValue []byte
+		var found bool
+		if changed[key] {
+			keyValue, found = b.GetAt(ord, key)
+		} else {
+			keyValue, found = b.GetLast(key)
+		}
+		if !found {
+			return true
+		}
+		if indexedValue, ok := storeIndex.extract(key, keyValue); !ok || indexedValue != value {
+			return true
+		}
+		return f(key, keyValue)
+	}
+
+	if indexed != nil {
+		over := false
+		indexed.ascend(after, func(key string) bool {
+			for len(unindexed) != 0 && unindexed[0] < key {
+				if !emit(unindexed[0]) {
+					over = true
+					return false
+				}
+				unindexed = unindexed[1:]
+			}
+			if key == after {
+				return true
+			}
+			if !emit(key) {
+				over = true
+				return false
+			}
+			return true
+		})
+		if over {
+			return nil
+		}
+	}
+	for _, key := range unindexed {
+		if !emit(key) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func newIndexedConfig(t *testing.T, objStore dstore.Store) *Config {
+	if objStore == nil {
+		objStore = dstore.NewMockStore(nil)
+	}
+	config, err := NewConfig("test", 0, "test.module.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", objStore)
+	require.NoError(t, err)
+	require.NoError(t, config.SetIndexes([]*pbsubstreams.Module_KindStore_Index{
+		{Name: "owner", Extract: &pbsubstreams.Module_KindStore_Index_KeySegment{KeySegment: 1}},
+	}))
+	return config
+}
+
+func indexLookup(t *testing.T, s Reader, ord uint64, index, value, after string) (out []string) {
+	t.Helper()
+	require.NoError(t, s.IndexLookupAt(ord, index, value, after, func(key string, _ []byte) bool {
+		out = append(out, key)
+		return true
+	}))
+	return
+}
+
+func TestSetIndexes_Invalid(t *testing.T) {
+	config, err := NewConfig("test", 0, "test.module.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil))
+	require.NoError(t, err)
+
+	assert.Error(t, config.SetIndexes([]*pbsubstreams.Module_KindStore_Index{{Name: "a"}}))
+	assert.Error(t, config.SetIndexes([]*pbsubstreams.Module_KindStore_Index{
+		{Name: "a", Extract: &pbsubstreams.Module_KindStore_Index_KeySegment{KeySegment: 0}},
+		{Name: "a", Extract: &pbsubstreams.Module_KindStore_Index_KeySegment{KeySegment: 1}},
+	}))
+}
+
+func TestKeySegment(t *testing.T) {
+	tests := []struct {
+		key      string
+		position int
+		expect   string
+		found    bool
+	}{
+		{"token:alice:1", 0, "token", true},
+		{"token:alice:1", 1, "alice", true},
+		{"token:alice:1", 2, "1", true},
+		{"token:alice:1", 3, "", false},
+		{"token", 0, "token", true},
+		{"token::1", 1, "", true},
+	}
+	for _, test := range tests {
+		out, found := keySegment(test.key, test.position)
+		assert.Equal(t, test.expect, out, "%s at %d", test.key, test.position)
+		assert.Equal(t, test.found, found, "%s at %d", test.key, test.position)
+	}
+}
+
+func TestProtoBytesField(t *testing.T) {
+	var value []byte
+	value = protowire.AppendTag(value, 1, protowire.VarintType)
+	value = protowire.AppendVarint(value, 42)
+	value = protowire.AppendTag(value, 2, protowire.BytesType)
+	value = protowire.AppendString(value, "alice")
+	value = protowire.AppendTag(value, 2, protowire.BytesType)
+	value = protowire.AppendString(value, "bob")
+
+	out, found := protoBytesField(value, 2)
+	assert.True(t, found)
+	assert.Equal(t, "bob", out)
+
+	_, found = protoBytesField(value, 1)
+	assert.False(t, found)
+	_, found = protoBytesField(value, 3)
+	assert.False(t, found)
+	_, found = protoBytesField([]byte{0xff}, 2)
+	assert.False(t, found)
+}
+
+func TestFullKV_Indexes(t *testing.T) {
+	s := newIndexedConfig(t, nil).NewFullKV(zap.NewNop())
+
+	s.SetBytes(1, "token:alice:1", []byte("a"))
+	s.SetBytes(2, "token:bob:2", []byte("b"))
+	s.SetBytes(3, "token:alice:3", []byte("c"))
+	s.SetBytes(4, "other", []byte("d"))
+	s.DeletePrefix(5, "token:alice:1")
+	s.DeletePrefix(6, "token:bob")
+	require.NoError(t, s.Flush())
+
+	assert.Equal(t, []string{"token:alice:3"}, indexLookup(t, s, 10, "owner", "alice", ""))
+	assert.Equal(t, []string{"token:alice:1", "token:alice:3"}, indexLookup(t, s, 4, "owner", "alice", ""))
+	assert.Equal(t, []string{"token:alice:3"}, indexLookup(t, s, 4, "owner", "alice", "token:alice:1"))
+	assert.Equal(t, []string{"token:alice:1"}, indexLookup(t, s, 2, "owner", "alice", ""))
+	assert.Empty(t, indexLookup(t, s, 10, "owner", "bob", ""))
+	assert.Equal(t, []string{"token:bob:2"}, indexLookup(t, s, 5, "owner", "bob", ""))
+
+	err := s.IndexLookupAt(10, "missing", "alice", "", func(string, []byte) bool { return true })
+	assert.Error(t, err)
+
+	// undoing the deltas restores the index
+	deltas := s.GetDeltas()
+	s.Reset()
+	s.ApplyDeltasReverse(deltas)
+	assert.Empty(t, s.indexEntries())
+}
+
+func TestFullKV_Indexes_ValueChanged(t *testing.T) {
+	config, err := NewConfig("test", 0, "test.module.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	require.NoError(t, config.SetIndexes([]*pbsubstreams.Module_KindStore_Index{
+		{Name: "owner", Extract: &pbsubstreams.Module_KindStore_Index_ValueField{ValueField: 1}},
+	}))
+	owner := func(name string) []byte {
+		return protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), name)
+	}
+
+	s := config.NewFullKV(zap.NewNop())
+	s.SetBytes(1, "0", owner("alice"))
+	s.SetBytes(1, "a", owner("alice"))
+	s.SetBytes(1, "b", owner("bob"))
+	s.SetBytes(1, "c", owner("alice"))
+	s.SetBytes(1, "d", owner("alice"))
+	s.SetBytes(2, "0", owner("bob"))
+	s.SetBytes(2, "b", owner("alice"))
+	s.SetBytes(2, "c", owner("bob"))
+	s.DeletePrefix(3, "d")
+	require.NoError(t, s.Flush())
+
+	assert.Equal(t, []string{"a", "b"}, indexLookup(t, s, 10, "owner", "alice", ""))
+	assert.Equal(t, []string{"a", "b", "d"}, indexLookup(t, s, 2, "owner", "alice", ""))
+	assert.Equal(t, []string{"0", "a", "c", "d"}, indexLookup(t, s, 1, "owner", "alice", ""))
+	assert.Equal(t, []string{"c", "d"}, indexLookup(t, s, 1, "owner", "alice", "a"))
+	assert.Equal(t, []string{"b"}, indexLookup(t, s, 1, "owner", "bob", ""))
+	assert.Equal(t, []string{"0", "c"}, indexLookup(t, s, 10, "owner", "bob", ""))
+
+	var keys []string
+	require.NoError(t, s.IndexLookupAt(1, "owner", "alice", "a", func(key string, _ []byte) bool {
+		keys = append(keys, key)
+		return false
+	}))
+	assert.Equal(t, []string{"c"}, keys)
+}
+
+func TestFullKV_Indexes_Stop(t *testing.T) {
+	s := newIndexedConfig(t, nil).NewFullKV(zap.NewNop())
+	s.SetBytes(1, "token:alice:1", []byte("a"))
+	s.SetBytes(2, "token:alice:2", []byte("b"))
+	require.NoError(t, s.Flush())
+
+	var keys []string
+	require.NoError(t, s.IndexLookupAt(10, "owner", "alice", "", func(key string, _ []byte) bool {
+		keys = append(keys, key)
+		return false
+	}))
+	assert.Equal(t, []string{"token:alice:1"}, keys)
+}
+
+func TestFullKV_Indexes_Merge(t *testing.T) {
+	config := newIndexedConfig(t, nil)
+	s := config.NewFullKV(zap.NewNop())
+	s.SetBytes(1, "token:alice:1", []byte("a"))
+	s.SetBytes(2, "token:bob:2", []byte("b"))
+	require.NoError(t, s.Flush())
+	s.Reset()
+
+	partial := config.NewPartialKV(1000, zap.NewNop())
+	partial.SetBytes(1, "token:alice:2", []byte("c"))
+	partial.DeletePrefix(2, "token:bob")
+	require.NoError(t, partial.Flush())
+
+	require.NoError(t, s.Merge(partial))
+	assert.Equal(t, []string{"token:alice:1", "token:alice:2"}, indexLookup(t, s, 0, "owner", "alice", ""))
+	assert.Empty(t, indexLookup(t, s, 0, "owner", "bob", ""))
+}
+
+func TestFullKV_Indexes_SaveLoad(t *testing.T) {
+	var writtenBytes []byte
+	objStore := dstore.NewMockStore(func(base string, f io.Reader) (err error) {
+		writtenBytes, err = io.ReadAll(f)
+		return err
+	})
+	objStore.OpenObjectFunc = func(ctx context.Context, name string) (out io.ReadCloser, err error) {
+		return io.NopCloser(bytes.NewBuffer(writtenBytes)), nil
+	}
+	config := newIndexedConfig(t, objStore)
+
+	s := config.NewFullKV(zap.NewNop())
+	s.SetBytes(1, "token:alice:1", []byte("a"))
+	s.SetBytes(2, "token:bob:2", []byte("b"))
+	require.NoError(t, s.Flush())
+
+	file, writer, err := s.Save(1000)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(context.Background()))
+
+	loaded := config.NewFullKV(zap.NewNop())
+	require.NoError(t, loaded.Load(context.Background(), file))
+	assert.Equal(t, s.indexEntries(), loaded.indexEntries())
+	assert.Equal(t, []string{"token:alice:1"}, indexLookup(t, loaded, 0, "owner", "alice", ""))
+
+	// a store saved without indexes has them rebuilt from its keys
+	plain, err := NewConfig("test", 0, "test.module.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", objStore)
+	require.NoError(t, err)
+	unindexed := plain.NewFullKV(zap.NewNop())
+	unindexed.SetBytes(1, "token:bob:2", []byte("b"))
+	require.NoError(t, unindexed.Flush())
+	file, writer, err = unindexed.Save(1000)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(context.Background()))
+
+	rebuilt := config.NewFullKV(zap.NewNop())
+	require.NoError(t, rebuilt.Load(context.Background(), file))
+	assert.Equal(t, []string{"token:bob:2"}, indexLookup(t, rebuilt, 0, "owner", "bob", ""))
+}
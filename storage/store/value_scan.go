@@ -0,0 +1,183 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/google/btree"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// keyIndexDegree is the degree of the B-trees holding the keys of the key indexes
+const keyIndexDegree = 32
+
+// keyIndex holds the keys of a store in order. It is built on the first scan of the store, and kept
+// in sync with the keys created and deleted from then on.
+type keyIndex struct {
+	keys *btree.BTreeG[string]
+}
+
+func newKeyIndex(kv map[string][]byte) *keyIndex {
+	index := &keyIndex{keys: btree.NewOrderedG[string](keyIndexDegree)}
+	for key := range kv {
+		index.keys.ReplaceOrInsert(key)
+	}
+	return index
+}
+
+func newKeyIndexFromKeys(keys []string) *keyIndex {
+	index := &keyIndex{keys: btree.NewOrderedG[string](keyIndexDegree)}
+	for _, key := range keys {
+		index.keys.ReplaceOrInsert(key)
+	}
+	return index
+}
+
+func (i *keyIndex) add(key string) {
+	i.keys.ReplaceOrInsert(key)
+}
+
+func (i *keyIndex) remove(key string) {
+	i.keys.Delete(key)
+}
+
+func (i *keyIndex) has(key string) bool {
+	return i.keys.Has(key)
+}
+
+func (i *keyIndex) len() int {
+	return i.keys.Len()
+}
+
+// ascend calls f, in order, with the keys greater than or equal to from, until f returns false
+func (i *keyIndex) ascend(from string, f func(key string) bool) {
+	i.keys.AscendGreaterOrEqual(from, f)
+}
+
+// list returns all the keys, in order
+func (i *keyIndex) list() []string {
+	out := make([]string, 0, i.keys.Len())
+	i.keys.Ascend(func(key string) bool {
+		out = append(out, key)
+		return true
+	})
+	return out
+}
+
+func (b *baseStore) indexKey(key string) {
+	if b.keyIndex != nil {
+		b.keyIndex.add(key)
+	}
+}
+
+func (b *baseStore) unindexKey(key string) {
+	if b.keyIndex != nil {
+		b.keyIndex.remove(key)
+	}
+}
+
+// PrefixRange returns the range of the keys starting with prefix, as expected by ScanAt.
+func PrefixRange(prefix string) (low, high string) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			return prefix, prefix[:i] + string([]byte{prefix[i] + 1})
+		}
+	}
+	return prefix, ""
+}
+
+func inRange(key, low, high string) bool {
+	return key >= low && (high == "" || key < high)
+}
+
+// ScanAt calls f, in key order, with the keys between low (inclusive) and high (exclusive, no upper bound when
+// empty) and their value for the state that includes the processing of `ord`, until f returns false.
+func (b *baseStore) ScanAt(ord uint64, low, high string, f func(key string, value []byte) bool) {
+	if b.keyIndex == nil {
+		b.keyIndex = newKeyIndex(b.kv)
+	}
+
+	type valueAt struct {
+		value []byte
+		found bool
+	}
+
+	// changed holds the value at `ord` of the keys in range changed after it, like getAt unwinds them
+	changed := map[string]valueAt{}
+	for i := len(b.deltas) - 1; i >= 0; i-- {
+		delta := b.deltas[i]
+		if delta.Ordinal <= ord {
+			break
+		}
+		if !inRange(delta.Key, low, high) {
+			continue
+		}
+
+		switch delta.Operation {
+		case pbsubstreams.StoreDelta_DELETE, pbsubstreams.StoreDelta_UPDATE:
+			changed[delta.Key] = valueAt{delta.OldValue, true}
+		case pbsubstreams.StoreDelta_CREATE:
+			changed[delta.Key] = valueAt{nil, false}
+		default:
+			panic(fmt.Sprintf("invalid value %q for pbsubstreams.StateDelta::Op for key %q", delta.Operation, delta.Key))
+		}
+	}
+
+	// deleted are the keys deleted after `ord`, which are not in the index anymore
+	var deleted []string
+	for key, at := range changed {
+		if _, exists := b.kv[key]; at.found && !exists {
+			deleted = append(deleted, key)
+		}
+	}
+	sort.Strings(deleted)
+
+	// emit calls f with the key and its value at `ord`, returning false once the scan is over
+	emit := func(key string) bool {
+		if high != "" && key >= high {
+			return false
+		}
+
+		value := b.kv[key]
+		if at, found := changed[key]; found {
+			if !at.found {
+				return true
+			}
+			value = at.value
+		}
+
+		if b.UpdatePolicy() == pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_SUM &&
+			(bytes.HasPrefix(value, []byte("sum:")) || bytes.HasPrefix(value, []byte("set:"))) {
+
+			value = value[4:]
+		}
+
+		return f(key, value)
+	}
+
+	over := false
+	b.keyIndex.ascend(low, func(key string) bool {
+		for len(deleted) != 0 && deleted[0] < key {
+			if !emit(deleted[0]) {
+				over = true
+				return false
+			}
+			deleted = deleted[1:]
+		}
+		if !emit(key) {
+			over = true
+			return false
+		}
+		return true
+	})
+	if over {
+		return
+	}
+	for _, key := range deleted {
+		if !emit(key) {
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scanAll(s *baseStore, ord uint64, low, high string) (out []string) {
+	s.ScanAt(ord, low, high, func(key string, value []byte) bool {
+		out = append(out, key+"="+string(value))
+		return true
+	})
+	return
+}
+
+func TestPrefixRange(t *testing.T) {
+	tests := []struct {
+		prefix       string
+		expectedLow  string
+		expectedHigh string
+	}{
+		{"", "", ""},
+		{"user:", "user:", "user;"},
+		{"a\xff", "a\xff", "b"},
+		{"\xff\xff", "\xff\xff", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.prefix, func(t *testing.T) {
+			low, high := PrefixRange(test.prefix)
+			assert.Equal(t, test.expectedLow, low)
+			assert.Equal(t, test.expectedHigh, high)
+		})
+	}
+}
+
+func TestKeyIndex(t *testing.T) {
+	index := newKeyIndex(map[string][]byte{"k0050": nil, "k0010": nil})
+	expected := map[string]bool{"k0050": true, "k0010": true}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10_000; i++ {
+		key := fmt.Sprintf("k%04d", r.Intn(1000))
+		if r.Intn(3) == 0 {
+			index.remove(key)
+			delete(expected, key)
+		} else {
+			index.add(key)
+			expected[key] = true
+		}
+	}
+
+	var keys []string
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	assert.Equal(t, keys, index.list())
+	assert.Equal(t, len(keys), index.len())
+
+	var from []string
+	index.ascend("k0500", func(key string) bool {
+		from = append(from, key)
+		return true
+	})
+	assert.Equal(t, keys[sort.SearchStrings(keys, "k0500"):], from)
+}
+
+func TestScanAt(t *testing.T) {
+	s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	s.kv = map[string][]byte{
+		"user:a": []byte("1"),
+		"user:c": []byte("3"),
+		"other":  []byte("x"),
+	}
+
+	low, high := PrefixRange("user:")
+	assert.Equal(t, []string{"user:a=1", "user:c=3"}, scanAll(s, 0, low, high))
+
+	s.Set(10, "user:b", "2")
+	s.Set(20, "user:a", "10")
+	s.DeletePrefix(30, "user:c")
+	require.NoError(t, s.Flush())
+
+	assert.Equal(t, []string{"user:a=1", "user:c=3"}, scanAll(s, 5, low, high))
+	assert.Equal(t, []string{"user:a=1", "user:b=2", "user:c=3"}, scanAll(s, 10, low, high))
+	assert.Equal(t, []string{"user:a=10", "user:b=2", "user:c=3"}, scanAll(s, 20, low, high))
+	assert.Equal(t, []string{"user:a=10", "user:b=2"}, scanAll(s, 30, low, high))
+	assert.Equal(t, []string{"user:b=2", "user:c=3"}, scanAll(s, 20, "user:b", ""))
+	assert.Equal(t, []string{"other=x", "user:a=10"}, scanAll(s, 30, "", "user:b"))
+
+	var keys []string
+	s.ScanAt(30, "", "", func(key string, value []byte) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal(t, []string{"other", "user:a"}, keys)
+
+	s.Reset()
+	s.Set(0, "user:d", "4")
+	require.NoError(t, s.Flush())
+	assert.Equal(t, []string{"user:a=10", "user:b=2", "user:d=4"}, scanAll(s, 0, low, high))
+
+	s.ApplyDeltasReverse(s.GetDeltas())
+	s.Reset()
+	assert.Equal(t, []string{"user:a=10", "user:b=2"}, scanAll(s, 0, low, high))
+}
+
+func TestScanAt_Merge(t *testing.T) {
+	prev := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	prev.kv = map[string][]byte{"a": []byte("1")}
+	assert.Equal(t, []string{"a=1"}, scanAll(prev, 0, "", ""))
+
+	next := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	next.kv = map[string][]byte{"b": []byte("2")}
+
+	require.NoError(t, prev.Merge(&PartialKV{baseStore: next, DeletedPrefixes: []string{"a"}}))
+	assert.Equal(t, []string{"b=2"}, scanAll(prev, 0, "", ""))
+}
+
+func TestScanAt_SetSum(t *testing.T) {
+	s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_SUM, "int64", nil)
+	s.SetSumInt64(0, "a", []byte("set:1"))
+	s.SetSumInt64(0, "b", []byte("sum:2"))
+	require.NoError(t, s.Flush())
+
+	assert.Equal(t, []string{"a=1", "b=2"}, scanAll(s, 0, "", ""))
+}
@@ -0,0 +1,214 @@
+package tabular
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+)
+
+type tableWriter interface {
+	Write(row Row) error
+	Close() error
+}
+
+// Exporter writes the output of a module, flattened by its schema, to one directory per
+// table. Files hold the rows of a range of blocks, named `<start>-<end>.<format>` with the
+// end block exclusive.
+type Exporter struct {
+	schema     *Schema
+	msgDesc    protoreflect.MessageDescriptor
+	format     Format
+	dir        string
+	rotation   uint64
+	rangeStart uint64
+	rangeEnd   uint64
+	lastBlock  uint64
+	started    bool
+	files      []*exportFile
+
+	onFilesClosed func()
+}
+
+type exportFile struct {
+	tmpPath string
+	dir     string
+	file    *os.File
+	buf     *bufio.Writer
+	writer  tableWriter
+}
+
+// NewExporter writes the messages of type `msgDesc` under `dir`, in a new set of files
+// every `rotation` blocks, aligned on multiples of `rotation`. With no rotation, all the
+// rows end up in a single file per table.
+func NewExporter(name string, msgDesc protoreflect.MessageDescriptor, format Format, dir string, rotation uint64) (*Exporter, error) {
+	if format != FormatCSV && format != FormatParquet {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	return &Exporter{
+		schema:   NewSchema(name, msgDesc),
+		msgDesc:  msgDesc,
+		format:   format,
+		dir:      dir,
+		rotation: rotation,
+	}, nil
+}
+
+// OnFilesClosed registers a function called once the files of a range are completely
+// written, all the blocks written before then being persisted.
+func (e *Exporter) OnFilesClosed(f func()) {
+	e.onFilesClosed = f
+}
+
+func (e *Exporter) TableNames() []string {
+	out := make([]string, len(e.schema.Tables))
+	for i, table := range e.schema.Tables {
+		out[i] = table.Name
+	}
+	return out
+}
+
+// Write flattens `data`, the serialized output of the module at `clock`. Blocks must be
+// written in increasing order.
+func (e *Exporter) Write(clock *pbsubstreams.Clock, data []byte) error {
+	msg := dynamicpb.NewMessage(e.msgDesc)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("decoding output of block %d: %w", clock.Number, err)
+	}
+
+	if e.files != nil && clock.Number >= e.rangeEnd {
+		if err := e.closeFiles(e.rangeEnd); err != nil {
+			return err
+		}
+	}
+	if e.files == nil {
+		e.startRange(clock.Number)
+	}
+	e.lastBlock = clock.Number
+
+	for i, rows := range e.schema.Flatten(clock, msg) {
+		for _, row := range rows {
+			if err := e.write(i, row); err != nil {
+				return fmt.Errorf("writing table %q: %w", e.schema.Tables[i].Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Close writes out the files of the current range, ending it after the last block written.
+func (e *Exporter) Close() error {
+	if e.files == nil {
+		return nil
+	}
+	return e.closeFiles(e.lastBlock + 1)
+}
+
+// startRange starts the range of `blockNum`. The first range starts at the first block
+// written, so that resuming an export does not reuse the name of the files holding the
+// beginning of the range.
+func (e *Exporter) startRange(blockNum uint64) {
+	e.rangeStart = blockNum
+	e.rangeEnd = ^uint64(0)
+	if e.rotation != 0 {
+		if e.started {
+			e.rangeStart = blockNum - blockNum%e.rotation
+		}
+		e.rangeEnd = blockNum - blockNum%e.rotation + e.rotation
+	}
+	e.started = true
+	e.files = make([]*exportFile, len(e.schema.Tables))
+}
+
+// write adds a row to the table's file of the current range, opening it on its first row
+func (e *Exporter) write(table int, row Row) error {
+	f := e.files[table]
+	if f == nil {
+		var err error
+		f, err = e.openFile(e.schema.Tables[table])
+		if err != nil {
+			return err
+		}
+		e.files[table] = f
+	}
+	return f.writer.Write(row)
+}
+
+func (e *Exporter) openFile(table *Table) (*exportFile, error) {
+	dir := filepath.Join(e.dir, table.Name)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("creating directory: %w", err)
+	}
+
+	f := &exportFile{
+		dir:     dir,
+		tmpPath: filepath.Join(dir, fmt.Sprintf("%010d.%s.tmp", e.rangeStart, e.format)),
+	}
+
+	var err error
+	f.file, err = os.Create(f.tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("creating file: %w", err)
+	}
+	f.buf = bufio.NewWriter(f.file)
+
+	switch e.format {
+	case FormatCSV:
+		f.writer, err = newCSVWriter(f.buf, table.Columns)
+	case FormatParquet:
+		f.writer, err = newParquetWriter(f.buf, table.Columns)
+	}
+	if err != nil {
+		f.file.Close()
+		return nil, fmt.Errorf("creating %s writer: %w", e.format, err)
+	}
+	return f, nil
+}
+
+func (e *Exporter) closeFiles(rangeEnd uint64) error {
+	for _, f := range e.files {
+		if f == nil {
+			continue
+		}
+
+		if err := f.close(); err != nil {
+			return fmt.Errorf("closing %q: %w", f.tmpPath, err)
+		}
+
+		path := filepath.Join(f.dir, fmt.Sprintf("%010d-%010d.%s", e.rangeStart, rangeEnd, e.format))
+		if err := os.Rename(f.tmpPath, path); err != nil {
+			return fmt.Errorf("renaming %q: %w", f.tmpPath, err)
+		}
+	}
+
+	e.files = nil
+	if e.onFilesClosed != nil {
+		e.onFilesClosed()
+	}
+	return nil
+}
+
+func (f *exportFile) close() error {
+	if err := f.writer.Close(); err != nil {
+		f.file.Close()
+		return err
+	}
+	if err := f.buf.Flush(); err != nil {
+		f.file.Close()
+		return err
+	}
+	return f.file.Close()
+}
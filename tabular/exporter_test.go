@@ -0,0 +1,79 @@
+package tabular
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExporter_CSVRotation(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := NewExporter("modules", (&pbsubstreams.Modules{}).ProtoReflect().Descriptor(), FormatCSV, dir, 100)
+	require.NoError(t, err)
+
+	data, err := proto.Marshal(testModules())
+	require.NoError(t, err)
+	empty, err := proto.Marshal(&pbsubstreams.Modules{})
+	require.NoError(t, err)
+
+	closed := 0
+	exporter.OnFilesClosed(func() { closed++ })
+
+	for _, block := range []uint64{150, 199, 200, 342} {
+		clock := testClock()
+		clock.Number = block
+		output := empty
+		if block == 199 {
+			output = data
+		}
+		require.NoError(t, exporter.Write(clock, output))
+	}
+	require.NoError(t, exporter.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+	require.NoError(t, err)
+	for i, file := range files {
+		files[i], _ = filepath.Rel(dir, file)
+	}
+	assert.ElementsMatch(t, []string{
+		"modules/0000000150-0000000200.csv",
+		"modules/0000000200-0000000300.csv",
+		"modules/0000000300-0000000343.csv",
+		"modules.modules/0000000150-0000000200.csv",
+		"modules.modules.inputs/0000000150-0000000200.csv",
+		"modules.binaries/0000000150-0000000200.csv",
+	}, files)
+	assert.Equal(t, 3, closed)
+
+	cnt, err := os.ReadFile(filepath.Join(dir, "modules", "0000000150-0000000200.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "block_number,block_id,block_timestamp,_row\n150,0xabc,2024-01-02T03:04:05Z,0\n199,0xabc,2024-01-02T03:04:05Z,0\n", string(cnt))
+
+	cnt, err = os.ReadFile(filepath.Join(dir, "modules.binaries", "0000000150-0000000200.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "block_number,_row,_parent_row,_index,type,content\n199,0,0,0,wasm/rust-v1,cafe\n", string(cnt))
+}
+
+func TestExporter_Parquet(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := NewExporter("modules", (&pbsubstreams.Modules{}).ProtoReflect().Descriptor(), FormatParquet, dir, 0)
+	require.NoError(t, err)
+
+	data, err := proto.Marshal(testModules())
+	require.NoError(t, err)
+	require.NoError(t, exporter.Write(testClock(), data))
+	require.NoError(t, exporter.Close())
+
+	cnt, err := os.ReadFile(filepath.Join(dir, "modules.modules", "0000000042-0000000043.parquet"))
+	require.NoError(t, err)
+	assert.Equal(t, "PAR1", string(cnt[:4]))
+	assert.Equal(t, "PAR1", string(cnt[len(cnt)-4:]))
+
+	_, err = NewExporter("modules", (&pbsubstreams.Modules{}).ProtoReflect().Descriptor(), Format("xlsx"), dir, 0)
+	assert.EqualError(t, err, `unsupported format "xlsx"`)
+}
@@ -0,0 +1,169 @@
+package tabular
+
+import (
+	"fmt"
+	"sort"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Row holds the values of the columns of a table, in order. Null values are nil, others
+// are a bool, int64, uint64, float64, string, []byte or time.Time, depending on the
+// column's type.
+type Row []any
+
+// Flatten returns the rows of every table of the schema, indexed like `Schema.Tables`,
+// for the output message of the block at `clock`.
+func (s *Schema) Flatten(clock *pbsubstreams.Clock, msg protoreflect.Message) [][]Row {
+	f := &flattener{
+		clock: clock,
+		rows:  make([][]Row, len(s.Tables)),
+	}
+
+	root := s.Tables[0]
+	row := f.newRow(root)
+	row[1] = clock.Id
+	if clock.Timestamp != nil {
+		row[2] = clock.Timestamp.AsTime().UTC()
+	}
+	f.fillMessage(row, 0, root.element.fields, msg, true)
+
+	return f.rows
+}
+
+type flattener struct {
+	clock *pbsubstreams.Clock
+	rows  [][]Row
+}
+
+// newRow appends a row to `table`, with its block number and `_row` set.
+func (f *flattener) newRow(table *Table) Row {
+	row := make(Row, len(table.Columns))
+	row[0] = f.clock.Number
+	if table.Parent == nil {
+		row[3] = int64(len(f.rows[table.index]))
+	} else {
+		row[1] = int64(len(f.rows[table.index]))
+	}
+	f.rows[table.index] = append(f.rows[table.index], row)
+	return row
+}
+
+func (f *flattener) fillMessage(row Row, rowID int64, fields []*fieldPlan, msg protoreflect.Message, present bool) {
+	for _, plan := range fields {
+		fieldPresent := present && (!plan.field.HasPresence() || msg.Has(plan.field))
+		var value protoreflect.Value
+		if fieldPresent {
+			value = msg.Get(plan.field)
+		}
+		f.fillField(row, rowID, plan, value, fieldPresent)
+	}
+}
+
+func (f *flattener) fillField(row Row, rowID int64, plan *fieldPlan, value protoreflect.Value, present bool) {
+	switch plan.kind {
+	case fieldScalar:
+		if present {
+			row[plan.column] = scalarValue(plan.field, value)
+		}
+	case fieldJSON:
+		if present {
+			cnt, err := protojson.Marshal(value.Message().Interface())
+			if err != nil {
+				cnt = []byte(fmt.Sprintf("%q", err.Error()))
+			}
+			row[plan.column] = string(cnt)
+		}
+	case fieldNested:
+		var msg protoreflect.Message
+		if present {
+			msg = value.Message()
+		}
+		f.fillMessage(row, rowID, plan.nested, msg, present)
+	case fieldChild:
+		if present {
+			f.fillChild(rowID, plan, value)
+		}
+	}
+}
+
+func (f *flattener) fillChild(parentRowID int64, plan *fieldPlan, value protoreflect.Value) {
+	child := plan.child
+	element := child.element
+
+	addRow := func(index int) (Row, int64) {
+		row := f.newRow(child)
+		row[2] = parentRowID
+		row[3] = int64(index)
+		return row, row[1].(int64)
+	}
+
+	if plan.field.IsMap() {
+		entries := value.Map()
+		keys := make([]protoreflect.MapKey, 0, entries.Len())
+		entries.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, key)
+			return true
+		})
+		sort.Slice(keys, func(i, j int) bool { return lessMapKey(keys[i], keys[j]) })
+
+		for i, key := range keys {
+			row, rowID := addRow(i)
+			f.fillField(row, rowID, element.fields[0], key.Value(), true)
+			f.fillField(row, rowID, element.fields[1], entries.Get(key), true)
+		}
+		return
+	}
+
+	list := value.List()
+	for i := 0; i < list.Len(); i++ {
+		row, rowID := addRow(i)
+		if element.kind == elementMessage {
+			f.fillMessage(row, rowID, element.fields, list.Get(i).Message(), true)
+		} else {
+			f.fillField(row, rowID, element.fields[0], list.Get(i), true)
+		}
+	}
+}
+
+func lessMapKey(a, b protoreflect.MapKey) bool {
+	switch v := a.Interface().(type) {
+	case string:
+		return v < b.String()
+	case bool:
+		return !v && b.Bool()
+	case int32, int64:
+		return a.Int() < b.Int()
+	default:
+		return a.Uint() < b.Uint()
+	}
+}
+
+func scalarValue(field protoreflect.FieldDescriptor, value protoreflect.Value) any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return value.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return value.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return int64(value.Uint())
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return value.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float()
+	case protoreflect.StringKind:
+		return value.String()
+	case protoreflect.BytesKind:
+		return value.Bytes()
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return fmt.Sprintf("%d", value.Enum())
+	default:
+		panic(fmt.Errorf("unhandled field kind %s", field.Kind()))
+	}
+}
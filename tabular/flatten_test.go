@@ -0,0 +1,109 @@
+package tabular
+
+import (
+	"testing"
+	"time"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testModules() *pbsubstreams.Modules {
+	return &pbsubstreams.Modules{
+		Modules: []*pbsubstreams.Module{
+			{
+				Name:         "map_events",
+				Kind:         &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:sf.test.Events"}},
+				InitialBlock: 10,
+				Inputs: []*pbsubstreams.Module_Input{
+					{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.test.Block"}}},
+					{Input: &pbsubstreams.Module_Input_Params_{Params: &pbsubstreams.Module_Input_Params{Value: "a=b"}}},
+				},
+			},
+			{
+				Name: "store_totals",
+				Kind: &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{
+					UpdatePolicy: pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD,
+					ValueType:    "bigint",
+				}},
+				Inputs: []*pbsubstreams.Module_Input{
+					{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "map_events"}}},
+				},
+			},
+		},
+		Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte{0xca, 0xfe}}},
+	}
+}
+
+func testClock() *pbsubstreams.Clock {
+	return &pbsubstreams.Clock{Id: "0xabc", Number: 42, Timestamp: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))}
+}
+
+func TestSchema_Tables(t *testing.T) {
+	s := NewSchema("modules", (&pbsubstreams.Modules{}).ProtoReflect().Descriptor())
+
+	var names []string
+	for _, table := range s.Tables {
+		names = append(names, table.Name)
+	}
+	assert.Equal(t, []string{"modules", "modules.modules", "modules.modules.kind_store_indexes", "modules.modules.inputs", "modules.binaries"}, names)
+
+	assert.Equal(t, []string{
+		"block_number", "_row", "_parent_row", "_index",
+		"name", "kind_map_output_type", "kind_store_update_policy", "kind_store_value_type", "kind_store_retain_blocks", "kind_store_merge_entrypoint", "kind_block_index_output_type",
+		"binary_index", "binary_entrypoint", "output_type", "initial_block", "block_filter_module", "block_filter_query_string", "end_block",
+	}, columnNames(s.Table("modules.modules")))
+	assert.Equal(t, s.Table("modules"), s.Table("modules.modules.inputs").Parent.Parent)
+}
+
+func TestSchema_MapsAndRecursion(t *testing.T) {
+	s := NewSchema("package", (&pbsubstreams.Package{}).ProtoReflect().Descriptor())
+
+	assert.Equal(t, []string{"block_number", "_row", "_parent_row", "_index", "key", "value"}, columnNames(s.Table("package.block_filters")))
+	assert.Equal(t, []string{"block_number", "_row", "_parent_row", "_index", "key", "value"}, columnNames(s.Table("package.networks.value_initialBlocks")))
+
+	// DescriptorProto.nested_type refers to its own type, written as JSON
+	nested := s.Table("package.proto_files.message_type.nested_type")
+	require.NotNil(t, nested)
+	assert.Equal(t, &Column{Name: "value", Type: ColumnTypeString}, nested.Columns[len(nested.Columns)-1])
+}
+
+func TestSchema_Flatten(t *testing.T) {
+	s := NewSchema("modules", (&pbsubstreams.Modules{}).ProtoReflect().Descriptor())
+	rows := s.Flatten(testClock(), testModules().ProtoReflect())
+	require.Len(t, rows, 5)
+
+	assert.Equal(t, []Row{{uint64(42), "0xabc", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), int64(0)}}, rows[0])
+
+	modules := rows[1]
+	require.Len(t, modules, 2)
+	assert.Equal(t, Row{
+		uint64(42), int64(0), int64(0), int64(0),
+		"map_events", "proto:sf.test.Events", nil, nil, nil, nil, nil,
+		int64(0), "", nil, uint64(10), nil, nil, uint64(0),
+	}, modules[0])
+	assert.Equal(t, Row{
+		uint64(42), int64(1), int64(0), int64(1),
+		"store_totals", nil, "UPDATE_POLICY_ADD", "bigint", uint64(0), "", nil,
+		int64(0), "", nil, uint64(0), nil, nil, uint64(0),
+	}, modules[1])
+
+	assert.Empty(t, rows[2])
+
+	inputs := rows[3]
+	require.Len(t, inputs, 3)
+	assert.Equal(t, []any{int64(0), int64(0), int64(0), "sf.test.Block", nil}, []any{inputs[0][1], inputs[0][2], inputs[0][3], inputs[0][4], inputs[0][5]})
+	assert.Equal(t, []any{int64(1), int64(0), int64(1), nil, "a=b"}, []any{inputs[1][1], inputs[1][2], inputs[1][3], inputs[1][4], inputs[1][8]})
+	assert.Equal(t, []any{int64(2), int64(1), int64(0), "map_events"}, []any{inputs[2][1], inputs[2][2], inputs[2][3], inputs[2][5]})
+
+	assert.Equal(t, []Row{{uint64(42), int64(0), int64(0), int64(0), "wasm/rust-v1", []byte{0xca, 0xfe}}}, rows[4])
+}
+
+func columnNames(table *Table) (out []string) {
+	for _, column := range table.Columns {
+		out = append(out, column.Name)
+	}
+	return out
+}
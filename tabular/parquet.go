@@ -0,0 +1,84 @@
+package tabular
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetRowGroupSize is the size, in bytes, of the rows buffered before being written
+// out as a row group
+const parquetRowGroupSize = 64 * 1024 * 1024
+
+// parquetWriter writes rows as a Parquet file of optional, flat columns, compressed
+// with zstd.
+type parquetWriter struct {
+	writer *writer.CSVWriter
+}
+
+func newParquetWriter(w io.Writer, columns []*Column) (*parquetWriter, error) {
+	metadata := make([]string, len(columns))
+	for i, column := range columns {
+		metadata[i] = fmt.Sprintf("name=%s, type=%s, repetitiontype=OPTIONAL", column.Name, parquetColumnType(column.Type))
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(metadata, w, 1)
+	if err != nil {
+		return nil, fmt.Errorf("creating parquet writer: %w", err)
+	}
+	pw.CompressionType = parquet.CompressionCodec_ZSTD
+	pw.RowGroupSize = parquetRowGroupSize
+
+	return &parquetWriter{writer: pw}, nil
+}
+
+func (p *parquetWriter) Write(row Row) error {
+	record := make([]interface{}, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil, bool, int64, float64:
+			record[i] = v
+		case uint64:
+			record[i] = int64(v)
+		case string:
+			record[i] = v
+		case []byte:
+			record[i] = string(v)
+		case time.Time:
+			record[i] = v.UnixMilli()
+		default:
+			return fmt.Errorf("column %d: unsupported value type %T", i, value)
+		}
+	}
+	return p.writer.Write(record)
+}
+
+func (p *parquetWriter) Close() error {
+	return p.writer.WriteStop()
+}
+
+// parquetColumnType returns the type of a column, as a physical type or, for those
+// having one, as a converted type implying its physical type.
+func parquetColumnType(typ ColumnType) string {
+	switch typ {
+	case ColumnTypeBool:
+		return "BOOLEAN"
+	case ColumnTypeInt64:
+		return "INT64"
+	case ColumnTypeUint64:
+		return "UINT_64"
+	case ColumnTypeDouble:
+		return "DOUBLE"
+	case ColumnTypeString:
+		return "UTF8"
+	case ColumnTypeBytes:
+		return "BYTE_ARRAY"
+	case ColumnTypeTimestamp:
+		return "TIMESTAMP_MILLIS"
+	default:
+		panic(fmt.Errorf("unhandled column type %d", typ))
+	}
+}
@@ -0,0 +1,73 @@
+package tabular
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestParquetWriter(t *testing.T) {
+	columns := []*Column{
+		{Name: "flag", Type: ColumnTypeBool},
+		{Name: "count", Type: ColumnTypeInt64},
+		{Name: "total", Type: ColumnTypeUint64},
+		{Name: "ratio", Type: ColumnTypeDouble},
+		{Name: "name", Type: ColumnTypeString},
+		{Name: "data", Type: ColumnTypeBytes},
+		{Name: "at", Type: ColumnTypeTimestamp},
+	}
+	at := time.UnixMilli(1700000000123).UTC()
+	rows := []Row{
+		{true, int64(-1), uint64(math.MaxUint64), 0.5, "first", []byte{1, 2}, at},
+		{nil, nil, nil, nil, nil, nil, nil},
+		{false, int64(3), uint64(0), -2.25, "", []byte{}, at},
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := newParquetWriter(buf, columns)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+
+	file, err := buffer.NewBufferFile(buf.Bytes())
+	require.NoError(t, err)
+	r, err := reader.NewParquetColumnReader(file, 1)
+	require.NoError(t, err)
+	defer r.ReadStop()
+
+	assert.Equal(t, int64(len(rows)), r.GetNumRows())
+	schema := r.Footer.Schema
+	require.Len(t, schema, len(columns)+1)
+	for i, column := range columns {
+		assert.Equal(t, column.Name, r.SchemaHandler.Infos[i+1].ExName)
+		assert.Equal(t, parquet.FieldRepetitionType_OPTIONAL, schema[i+1].GetRepetitionType())
+	}
+	assert.Equal(t, parquet.ConvertedType_UINT_64, schema[3].GetConvertedType())
+	assert.Equal(t, parquet.ConvertedType_UTF8, schema[5].GetConvertedType())
+	assert.Equal(t, parquet.ConvertedType_TIMESTAMP_MILLIS, schema[7].GetConvertedType())
+	assert.Equal(t, parquet.CompressionCodec_ZSTD, r.Footer.RowGroups[0].Columns[0].MetaData.Codec)
+
+	expected := [][]interface{}{
+		{true, nil, false},
+		{int64(-1), nil, int64(3)},
+		{int64(-1), nil, int64(0)},
+		{0.5, nil, -2.25},
+		{"first", nil, ""},
+		{"\x01\x02", nil, ""},
+		{at.UnixMilli(), nil, at.UnixMilli()},
+	}
+	for i, column := range columns {
+		values, _, _, err := r.ReadColumnByIndex(int64(i), int64(len(rows)))
+		require.NoError(t, err)
+		assert.Equal(t, expected[i], values, "column %q", column.Name)
+	}
+}
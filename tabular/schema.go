@@ -0,0 +1,220 @@
+// Package tabular flattens the protobuf output of a module into tables of rows, and
+// writes them as CSV or Parquet files.
+//
+// The output message of a module becomes the root table, with one row per block. Fields
+// of nested messages become columns of their parent's table, named after their path
+// joined with `_`. Repeated fields and maps are exploded into child tables, named
+// `<parent table>.<field path>`, whose rows reference the row of their parent.
+package tabular
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type ColumnType int
+
+const (
+	ColumnTypeBool ColumnType = iota
+	ColumnTypeInt64
+	ColumnTypeUint64
+	ColumnTypeDouble
+	ColumnTypeString
+	ColumnTypeBytes
+	ColumnTypeTimestamp
+)
+
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+const (
+	ColumnBlockNumber    = "block_number"
+	ColumnBlockID        = "block_id"
+	ColumnBlockTimestamp = "block_timestamp"
+	// ColumnRow is the position of the row among the rows of its table for the same block
+	ColumnRow = "_row"
+	// ColumnParentRow is the `_row` of the parent table's row the row belongs to
+	ColumnParentRow = "_parent_row"
+	// ColumnIndex is the position of the element in the repeated field (or the sorted map)
+	ColumnIndex = "_index"
+)
+
+type Table struct {
+	Name    string
+	Columns []*Column
+	Parent  *Table
+
+	index int
+	// element describes how the columns of a row are filled from the message, list
+	// element or map entry the row is made of
+	element elementPlan
+}
+
+type elementKind int
+
+const (
+	elementMessage elementKind = iota
+	elementListValue
+	elementMapEntry
+)
+
+type elementPlan struct {
+	kind   elementKind
+	fields []*fieldPlan
+}
+
+type fieldKind int
+
+const (
+	fieldScalar fieldKind = iota
+	// fieldNested is a singular message, flattened into columns of the same table
+	fieldNested
+	// fieldJSON is a message whose type is already being flattened higher in the
+	// hierarchy, written as JSON to break the recursion
+	fieldJSON
+	// fieldChild is a repeated field or a map, exploded into a child table
+	fieldChild
+)
+
+type fieldPlan struct {
+	kind   fieldKind
+	field  protoreflect.FieldDescriptor
+	column int
+	nested []*fieldPlan
+	child  *Table
+}
+
+// Schema holds the tables the messages of a given type are flattened into, the root
+// table first and then its descendants, depth first.
+type Schema struct {
+	Tables []*Table
+}
+
+// NewSchema computes the tables the messages of type `msgDesc` are flattened into. The
+// root table is named `name`.
+func NewSchema(name string, msgDesc protoreflect.MessageDescriptor) *Schema {
+	s := &Schema{}
+	root := s.newTable(name, nil)
+	root.element = elementPlan{kind: elementMessage}
+	root.element.fields = s.messageFields(root, "", msgDesc, map[protoreflect.FullName]bool{msgDesc.FullName(): true})
+	return s
+}
+
+func (s *Schema) Table(name string) *Table {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+func (s *Schema) newTable(name string, parent *Table) *Table {
+	table := &Table{
+		Name:   name,
+		Parent: parent,
+		index:  len(s.Tables),
+	}
+	table.addColumn(ColumnBlockNumber, ColumnTypeUint64)
+	if parent == nil {
+		table.addColumn(ColumnBlockID, ColumnTypeString)
+		table.addColumn(ColumnBlockTimestamp, ColumnTypeTimestamp)
+		table.addColumn(ColumnRow, ColumnTypeInt64)
+	} else {
+		table.addColumn(ColumnRow, ColumnTypeInt64)
+		table.addColumn(ColumnParentRow, ColumnTypeInt64)
+		table.addColumn(ColumnIndex, ColumnTypeInt64)
+	}
+
+	s.Tables = append(s.Tables, table)
+	return table
+}
+
+func (t *Table) addColumn(name string, typ ColumnType) int {
+	t.Columns = append(t.Columns, &Column{Name: name, Type: typ})
+	return len(t.Columns) - 1
+}
+
+func (s *Schema) messageFields(table *Table, prefix string, msgDesc protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) []*fieldPlan {
+	fields := msgDesc.Fields()
+	out := make([]*fieldPlan, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		out = append(out, s.fieldPlan(table, prefix+string(field.Name()), field, visiting))
+	}
+	return out
+}
+
+func (s *Schema) fieldPlan(table *Table, name string, field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) *fieldPlan {
+	if field.IsList() || field.IsMap() {
+		child := s.newTable(table.Name+"."+name, table)
+		switch {
+		case field.IsMap():
+			child.element = elementPlan{kind: elementMapEntry, fields: []*fieldPlan{
+				s.valuePlan(child, "key", field.MapKey(), visiting),
+				s.valuePlan(child, "value", field.MapValue(), visiting),
+			}}
+		case field.Message() != nil && !visiting[field.Message().FullName()]:
+			child.element = elementPlan{kind: elementMessage}
+			child.element.fields = s.messageFields(child, "", field.Message(), with(visiting, field.Message().FullName()))
+		default:
+			child.element = elementPlan{kind: elementListValue, fields: []*fieldPlan{
+				s.valuePlan(child, "value", field, visiting),
+			}}
+		}
+		return &fieldPlan{kind: fieldChild, field: field, child: child}
+	}
+
+	return s.valuePlan(table, name, field, visiting)
+}
+
+// valuePlan describes a single value of `field`, even for a repeated field whose
+// elements are handled one at a time.
+func (s *Schema) valuePlan(table *Table, name string, field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) *fieldPlan {
+	if field.Message() == nil {
+		return &fieldPlan{kind: fieldScalar, field: field, column: table.addColumn(name, scalarColumnType(field))}
+	}
+
+	if visiting[field.Message().FullName()] {
+		return &fieldPlan{kind: fieldJSON, field: field, column: table.addColumn(name, ColumnTypeString)}
+	}
+
+	return &fieldPlan{
+		kind:   fieldNested,
+		field:  field,
+		nested: s.messageFields(table, name+"_", field.Message(), with(visiting, field.Message().FullName())),
+	}
+}
+
+func with(visiting map[protoreflect.FullName]bool, name protoreflect.FullName) map[protoreflect.FullName]bool {
+	out := make(map[protoreflect.FullName]bool, len(visiting)+1)
+	for k := range visiting {
+		out[k] = true
+	}
+	out[name] = true
+	return out
+}
+
+func scalarColumnType(field protoreflect.FieldDescriptor) ColumnType {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return ColumnTypeBool
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return ColumnTypeInt64
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return ColumnTypeUint64
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return ColumnTypeDouble
+	case protoreflect.StringKind, protoreflect.EnumKind:
+		return ColumnTypeString
+	case protoreflect.BytesKind:
+		return ColumnTypeBytes
+	default:
+		panic(fmt.Errorf("unhandled field kind %s", field.Kind()))
+	}
+}
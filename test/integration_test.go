@@ -18,6 +18,7 @@ import (
 	"github.com/streamingfast/substreams/manifest"
 	"github.com/streamingfast/substreams/orchestrator/stage"
 	"github.com/streamingfast/substreams/orchestrator/work"
+	pbsubstreamstest "github.com/streamingfast/substreams/pb/sf/substreams/v1/test"
 	"github.com/streamingfast/substreams/reqctx"
 	_ "github.com/streamingfast/substreams/wasm/wazero"
 	"github.com/stretchr/testify/assert"
@@ -470,6 +471,87 @@ func TestMultipleStoresUnalignedStartBlocksProdMode(t *testing.T) {
 	assert.Contains(t, string(outs[24]), "store2:sum:300") // 276+24 ...
 }
 
+func TestMultipleOutputModules(t *testing.T) {
+	manifest.TestUseSimpleHash = true
+	spkg := "./testdata/complex_substreams/complex-substreams-v0.1.0.spkg"
+
+	for _, production := range []bool{true, false} {
+		t.Run(fmt.Sprintf("production_%t", production), func(t *testing.T) {
+			single := newTestRun(t, 23, 999, 43, 0, "assert_first_store_init_23", spkg)
+			single.ProductionMode = production
+			require.NoError(t, single.Run(t, "single"))
+
+			run := newTestRun(t, 23, 999, 43, 0, "multi_store_different_23", spkg)
+			run.OutputModules = []string{"multi_store_different_23", "assert_first_store_init_23"}
+			run.ProductionMode = production
+			require.NoError(t, run.Run(t, "multiple"))
+
+			var blocks int
+			for _, response := range run.Responses {
+				data := response.GetBlockScopedData()
+				if data == nil {
+					continue
+				}
+				blocks++
+				require.Len(t, data.Outputs, 2)
+				assert.Equal(t, "multi_store_different_23", data.Outputs[0].Name)
+				assert.Equal(t, "assert_first_store_init_23", data.Outputs[1].Name)
+				assert.Equal(t, data.Output, data.Outputs[0])
+			}
+			assert.Equal(t, 20, blocks)
+
+			outs := run.MapOutput("multi_store_different_23")
+			assert.Contains(t, string(outs[23]), "store2:sum:276") // 1+2+...+23 = 276
+			assert.Equal(t, single.MapOutput("assert_first_store_init_23"), run.MapOutput("assert_first_store_init_23"))
+		})
+	}
+}
+
+func TestOutputFilter(t *testing.T) {
+	manifest.TestUseSimpleHash = true
+	spkg := "./testdata/complex_substreams/complex-substreams-v0.1.0.spkg"
+
+	for _, production := range []bool{true, false} {
+		t.Run(fmt.Sprintf("production_%t", production), func(t *testing.T) {
+			// the output of block N is {number: N-22, id: "store2:sum:<1+2+...+N>"}
+			run := newTestRun(t, 23, 999, 43, 0, "multi_store_different_23", spkg)
+			run.OutputFilter = `number > 10 && id != "store2:sum:630"`
+			run.ProductionMode = production
+			require.NoError(t, run.Run(t, "output_filter"))
+
+			var blocks []uint64
+			for _, response := range run.Responses {
+				if data := response.GetBlockScopedData(); data != nil {
+					out := &pbsubstreamstest.Block{}
+					require.NoError(t, data.Output.MapOutput.UnmarshalTo(out))
+					assert.Equal(t, data.Clock.Number-22, out.Number)
+					blocks = append(blocks, data.Clock.Number)
+				}
+			}
+			assert.Equal(t, []uint64{33, 34, 36, 37, 38, 39, 40, 41, 42}, blocks)
+		})
+	}
+}
+
+func TestOutputFieldMask(t *testing.T) {
+	manifest.TestUseSimpleHash = true
+	run := newTestRun(t, 23, 999, 33, 0, "multi_store_different_23", "./testdata/complex_substreams/complex-substreams-v0.1.0.spkg")
+	run.OutputFilter = `id == "store2:sum:300" || number == 3`
+	run.OutputFields = []string{"number"}
+	require.NoError(t, run.Run(t, "output_field_mask"))
+
+	var numbers []uint64
+	for _, response := range run.Responses {
+		if data := response.GetBlockScopedData(); data != nil {
+			out := &pbsubstreamstest.Block{}
+			require.NoError(t, data.Output.MapOutput.UnmarshalTo(out))
+			assert.Empty(t, out.Id)
+			numbers = append(numbers, out.Number)
+		}
+	}
+	assert.Equal(t, []uint64{2, 3}, numbers)
+}
+
 func TestStoreDeletePrefix(t *testing.T) {
 	run := newTestRun(t, 30, 40, 42, 0, "assert_test_store_delete_prefix", "./testdata/simple_substreams/substreams-test-v0.1.0.spkg")
 	run.BlockProcessedCallback = func(ctx *execContext) {
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/streamingfast/substreams/manifest"
 	"github.com/streamingfast/substreams/orchestrator/stage"
@@ -46,6 +47,9 @@ type testRun struct {
 	StartBlock             int64
 	ExclusiveEndBlock      uint64
 	ModuleName             string
+	OutputModules          []string // all the requested output modules, starting with ModuleName, when more than one
+	OutputFilter           string
+	OutputFields           []string
 	ParallelSubrequests    uint64
 	NewBlockGenerator      BlockGeneratorFactory
 	BlockProcessedCallback blockProcessedCallBack
@@ -108,8 +112,17 @@ func (f *testRun) run(t *testing.T, testName string) error {
 		StartCursor:    opaqueCursor,
 		Modules:        f.Package.Modules,
 		OutputModule:   f.ModuleName,
+		OutputModules:  f.OutputModules,
 		ProductionMode: f.ProductionMode,
 	}
+	if f.OutputFilter != "" {
+		request.OutputFilter = &pbsubstreamsrpc.OutputFilter{Expression: f.OutputFilter}
+		request.OutputProtoFiles = f.Package.ProtoFiles
+	}
+	if f.OutputFields != nil {
+		request.OutputFieldMask = &fieldmaskpb.FieldMask{Paths: f.OutputFields}
+		request.OutputProtoFiles = f.Package.ProtoFiles
+	}
 
 	if f.Params != nil {
 		for k, v := range f.Params {
@@ -0,0 +1,468 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/streamingfast/substreams/manifest"
+	"github.com/streamingfast/substreams/metrics"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/storage/store/state"
+	"github.com/streamingfast/substreams/wasm"
+	_ "github.com/streamingfast/substreams/wasm/wazero"
+)
+
+var storeShellCmd = &cobra.Command{
+	Use:   "store-shell [<manifest_file>] <store_module> <state_url>",
+	Short: "Load the state of a store at a given block and query it interactively",
+	Long: cli.Dedent(`
+		Load the state of a store module as of the start of the '--at' block, from its last full KV snapshot and the
+		partial snapshots that follow it in '<state_url>' (the state store of the server, without the module hash),
+		then read commands from the standard input to query it. Snapshots are only written at boundaries, the state
+		loaded is the one of the last boundary at or below '--at'.
+
+		Commands:
+		  get <key>                       print the value of a key
+		  prefix <prefix> [<limit>]       print the keys starting with a prefix, with their value, sorted
+		  range <start> <end> [<limit>]   print the keys between 'start' (inclusive) and 'end' (exclusive), sorted
+		  count [<prefix>]                print the number of keys, starting with a prefix when given
+		  decode-as <type>                decode values as 'string', 'bytes' (hex) or a protobuf message type of the package
+		  help                            print the commands
+		  exit                            leave the shell
+
+		Arguments holding spaces can be quoted, like 'get "pool: 0xabc"', a backslash escaping the next character
+		outside of single quotes.
+
+		The manifest is optional as it will try to find a file named 'substreams.yaml' in current working directory if
+		nothing entered. You may enter a directory that contains a 'substreams.yaml' file in place of '<manifest_file>',
+		or a link to a remote .spkg file, using urls gs://, http(s)://, ipfs://, etc.'.
+	`),
+	Example: string(cli.ExamplePrefixed("substreams tools store-shell", `
+		uniswap-v3.spkg store_pools gs://[bucket-url-path] --at 12487000
+		store_eth_prices ./localdata --at 1000 <<< 'prefix token:051cf5178f60e9def5d5a39b2a988a9f914107cb: 10'
+	`)),
+	RunE:         runStoreShellE,
+	Args:         cobra.RangeArgs(2, 3),
+	SilenceUsage: true,
+}
+
+func init() {
+	storeShellCmd.Flags().Uint64("at", 0, "Block at which the state is loaded, exclusively (required)")
+	storeShellCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules, which changes their hash. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY")
+	storeShellCmd.Flags().String("network", "", "Specify the network to use for params and initialBlocks, overriding the 'network' field in the substreams package")
+	storeShellCmd.Flags().Uint64("state-bundle-size", uint64(1_000), "State segment size of the server, at which the keys of stores with 'retainBlocks' are dropped when merging partial snapshots")
+	storeShellCmd.Flags().Bool("use-test-simple-hash", false, "Use the 'simple hashing' function to get module hashes instead of regular hashes, for testing purposes")
+
+	Cmd.AddCommand(storeShellCmd)
+}
+
+func runStoreShellE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manifest.TestUseSimpleHash = sflags.MustGetBool(cmd, "use-test-simple-hash")
+
+	manifestPath := ""
+	if len(args) == 3 {
+		manifestPath = args[0]
+		args = args[1:]
+	}
+	moduleName := args[0]
+	stateURL := args[1]
+
+	at := sflags.MustGetUint64(cmd, "at")
+	if at == 0 {
+		return fmt.Errorf("the --at flag is required")
+	}
+
+	params, err := manifest.ParseParams(sflags.MustGetStringArray(cmd, "params"))
+	if err != nil {
+		return fmt.Errorf("parsing params: %w", err)
+	}
+
+	manifestReader, err := manifest.NewReader(manifestPath,
+		manifest.SkipPackageValidationReader(),
+		manifest.WithOverrideNetwork(sflags.MustGetString(cmd, "network")),
+		manifest.WithParams(params),
+	)
+	if err != nil {
+		return fmt.Errorf("manifest reader: %w", err)
+	}
+
+	pkgBundle, err := manifestReader.Read()
+	if err != nil {
+		return fmt.Errorf("read manifest %q: %w", manifestPath, err)
+	}
+	if pkgBundle == nil {
+		return fmt.Errorf("no package found")
+	}
+	pkg := pkgBundle.Package
+
+	var module *pbsubstreams.Module
+	for _, mod := range pkg.Modules.Modules {
+		if mod.Name == moduleName {
+			module = mod
+		}
+	}
+	if module == nil {
+		return fmt.Errorf("module %q not found", moduleName)
+	}
+	if module.GetKindStore() == nil {
+		return fmt.Errorf("module %q is not a store", moduleName)
+	}
+
+	hash, err := manifest.NewModuleHashes().HashModule(pkg.Modules, module, pkgBundle.Graph)
+	if err != nil {
+		return fmt.Errorf("hashing module %q: %w", moduleName, err)
+	}
+	moduleHash := hex.EncodeToString(hash)
+
+	objStore, err := dstore.NewStore(stateURL, "zst", "zstd", false)
+	if err != nil {
+		return fmt.Errorf("initializing dstore for %q: %w", stateURL, err)
+	}
+
+	config, err := store.NewConfig(module.Name, module.InitialBlock, moduleHash, module.GetKindStore().GetUpdatePolicy(), module.GetKindStore().GetValueType(), objStore)
+	if err != nil {
+		return fmt.Errorf("initializing store config module %q: %w", module.Name, err)
+	}
+	config.SetRetainBlocks(module.GetKindStore().GetRetainBlocks())
+	if module.GetKindStore().GetUpdatePolicy() == pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM {
+		code := pkg.Modules.Binaries[module.BinaryIndex]
+		wasmModule, err := wasm.NewRegistry(nil).NewModule(ctx, code.Content, code.Type)
+		if err != nil {
+			return fmt.Errorf("loading binary of module %q: %w", module.Name, err)
+		}
+		defer wasmModule.Close(ctx)
+		stats := metrics.NewReqStats(&metrics.Config{}, zlog)
+		config.SetAggregateFunc(wasm.NewStoreAggregateFunc(ctx, wasmModule, module.Name, module.GetKindStore().GetMergeEntrypoint(), stats, false))
+	}
+
+	zlog.Info("loading store state",
+		zap.String("module_name", moduleName),
+		zap.String("module_hash", moduleHash),
+		zap.String("state_url", stateURL),
+		zap.Uint64("at", at),
+	)
+
+	kv, loadedUpTo, err := loadStoreState(ctx, config, at, sflags.MustGetUint64(cmd, "state-bundle-size"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Loaded %d keys of store %q (%s) up to block %d\n", kv.Length(), module.Name, moduleHash, loadedUpTo)
+
+	shell := &storeShell{
+		kv:         kv,
+		protoFiles: pkg.ProtoFiles,
+		out:        os.Stdout,
+	}
+	if err := shell.setDecoder(config.ValueType()); err != nil {
+		fmt.Printf("Values are printed as bytes: %s\n", err)
+		_ = shell.setDecoder("bytes")
+	}
+
+	return shell.run(os.Stdin, isatty.IsTerminal(os.Stdin.Fd()))
+}
+
+// loadStoreState loads the last full KV of the store ending at or below `at`, merged with the
+// contiguous partial KVs that follow it, and returns it with the block it holds the state up to.
+// Partial KVs ending on a multiple of `segmentSize` are retained as the server does when squashing them.
+func loadStoreState(ctx context.Context, config *store.Config, at uint64, segmentSize uint64) (*store.FullKV, uint64, error) {
+	snapshotsMap, err := state.FetchState(ctx, store.ConfigMap{config.Name(): config}, at)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetching state: %w", err)
+	}
+	snapshots := snapshotsMap.Snapshots[config.Name()]
+
+	kv := config.NewFullKV(zlog)
+	loadedUpTo := config.ModuleInitialBlock()
+	var fullKVFile *store.FileInfo
+	for _, file := range snapshots.FullKVFiles {
+		if file.Range.ExclusiveEndBlock <= at {
+			fullKVFile = file
+		}
+	}
+	if fullKVFile != nil {
+		if err := kv.Load(ctx, fullKVFile); err != nil {
+			return nil, 0, fmt.Errorf("loading full kv: %w", err)
+		}
+		fmt.Printf("Full KV: %s\n", fullKVFile.Range)
+		loadedUpTo = fullKVFile.Range.ExclusiveEndBlock
+	}
+
+	for _, file := range snapshots.Partials {
+		if file.Range.StartBlock != loadedUpTo || file.Range.ExclusiveEndBlock > at {
+			continue
+		}
+
+		partial := config.NewPartialKV(file.Range.StartBlock, zlog)
+		if err := partial.Load(ctx, file); err != nil {
+			return nil, 0, fmt.Errorf("loading partial kv %s: %w", file.Range, err)
+		}
+		if err := kv.Merge(partial); err != nil {
+			return nil, 0, fmt.Errorf("merging partial kv %s: %w", file.Range, err)
+		}
+		if segmentSize != 0 && file.Range.ExclusiveEndBlock%segmentSize == 0 {
+			kv.Retain(file.Range.ExclusiveEndBlock)
+		}
+		fmt.Printf("Partial KV: %s\n", file.Range)
+		loadedUpTo = file.Range.ExclusiveEndBlock
+	}
+
+	if loadedUpTo == config.ModuleInitialBlock() {
+		return nil, 0, fmt.Errorf("no snapshot of store %q found between its initial block %d and block %d", config.Name(), config.ModuleInitialBlock(), at)
+	}
+	return kv, loadedUpTo, nil
+}
+
+// storeShell runs the commands of 'tools store-shell' against a loaded store
+type storeShell struct {
+	kv         *store.FullKV
+	protoFiles []*descriptorpb.FileDescriptorProto
+	out        io.Writer
+
+	decode func(value []byte) (string, error)
+}
+
+func (s *storeShell) run(in io.Reader, interactive bool) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for {
+		if interactive {
+			fmt.Fprint(s.out, "> ")
+		}
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		args, err := splitCommand(scanner.Text())
+		if err != nil {
+			fmt.Fprintf(s.out, "error: %s\n", err)
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if args[0] == "exit" || args[0] == "quit" {
+			return nil
+		}
+
+		if err := s.exec(args); err != nil {
+			fmt.Fprintf(s.out, "error: %s\n", err)
+		}
+	}
+}
+
+// splitCommand splits a command line into its arguments, separated by spaces. Parts of an
+// argument can be quoted with single or double quotes to hold spaces, and a backslash outside
+// of single quotes escapes the next character.
+func splitCommand(line string) (args []string, err error) {
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("unterminated escape at end of line")
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+func (s *storeShell) exec(args []string) error {
+	command, args := args[0], args[1:]
+	switch command {
+	case "get":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: get <key>")
+		}
+		value, found := s.kv.GetLast(args[0])
+		if !found {
+			return fmt.Errorf("key %q not found", args[0])
+		}
+		return s.printValue(args[0], value)
+
+	case "prefix":
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("usage: prefix <prefix> [<limit>]")
+		}
+		limit, err := parseLimit(args[1:])
+		if err != nil {
+			return err
+		}
+		return s.printKeys(func(key string) bool { return strings.HasPrefix(key, args[0]) }, limit)
+
+	case "range":
+		if len(args) < 2 || len(args) > 3 {
+			return fmt.Errorf("usage: range <start> <end> [<limit>]")
+		}
+		limit, err := parseLimit(args[2:])
+		if err != nil {
+			return err
+		}
+		return s.printKeys(func(key string) bool { return key >= args[0] && key < args[1] }, limit)
+
+	case "count":
+		if len(args) > 1 {
+			return fmt.Errorf("usage: count [<prefix>]")
+		}
+		prefix := ""
+		if len(args) == 1 {
+			prefix = args[0]
+		}
+		fmt.Fprintln(s.out, len(s.keys(func(key string) bool { return strings.HasPrefix(key, prefix) })))
+		return nil
+
+	case "decode-as":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: decode-as <type>")
+		}
+		return s.setDecoder(args[0])
+
+	case "help":
+		fmt.Fprintln(s.out, "commands: get <key>, prefix <prefix> [<limit>], range <start> <end> [<limit>], count [<prefix>], decode-as <type>, exit")
+		return nil
+	}
+	return fmt.Errorf("unknown command %q, use 'help' to list the commands", command)
+}
+
+func parseLimit(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(args[0])
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid limit %q", args[0])
+	}
+	return limit, nil
+}
+
+// keys returns the sorted keys of the store matching `match`
+func (s *storeShell) keys(match func(key string) bool) (out []string) {
+	_ = s.kv.Iter(func(key string, _ []byte) error {
+		if match(key) {
+			out = append(out, key)
+		}
+		return nil
+	})
+	sort.Strings(out)
+	return out
+}
+
+func (s *storeShell) printKeys(match func(key string) bool, limit int) error {
+	keys := s.keys(match)
+	if limit != 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	for _, key := range keys {
+		value, _ := s.kv.GetLast(key)
+		if err := s.printValue(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *storeShell) printValue(key string, value []byte) error {
+	decoded, err := s.decode(value)
+	if err != nil {
+		return fmt.Errorf("decoding value of key %q: %w", key, err)
+	}
+	fmt.Fprintf(s.out, "%s\t%s\n", key, decoded)
+	return nil
+}
+
+// setDecoder sets how values are printed, from a store value type: values of numeric types
+// are stored as text.
+func (s *storeShell) setDecoder(valueType string) error {
+	switch valueType {
+	case "bytes":
+		s.decode = func(value []byte) (string, error) { return hex.EncodeToString(value), nil }
+		return nil
+	case "string", "int64", "float64", "bigint", "bigdecimal", "bigfloat":
+		s.decode = func(value []byte) (string, error) { return string(value), nil }
+		return nil
+	}
+
+	msgType := strings.TrimPrefix(valueType, "proto:")
+	fileDescs, err := desc.CreateFileDescriptors(s.protoFiles)
+	if err != nil {
+		return fmt.Errorf("building protobuf descriptors: %w", err)
+	}
+
+	var msgDesc protoreflect.MessageDescriptor
+	for _, file := range fileDescs {
+		if found := file.FindMessage(msgType); found != nil {
+			msgDesc = found.UnwrapMessage()
+			break
+		}
+	}
+	if msgDesc == nil {
+		return fmt.Errorf("unknown type %q, expecting 'string', 'bytes' or a protobuf message type of the package", valueType)
+	}
+
+	s.decode = func(value []byte) (string, error) {
+		msg := dynamicpb.NewMessage(msgDesc)
+		if err := proto.Unmarshal(value, msg); err != nil {
+			return "", err
+		}
+		out, err := protojson.Marshal(msg)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+	return nil
+}
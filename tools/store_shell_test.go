@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+)
+
+func TestLoadStoreState(t *testing.T) {
+	ctx := context.Background()
+	config, err := store.NewConfig("store_a", 0, "abc", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil))
+	require.NoError(t, err)
+
+	full := config.NewFullKV(zap.NewNop())
+	full.Set(0, "a", "1")
+	full.Set(1, "b", "1")
+	writeSnapshot(t, full, 10)
+
+	partial := config.NewPartialKV(10, zap.NewNop())
+	partial.Set(0, "a", "2")
+	partial.Set(1, "c", "2")
+	writeSnapshot(t, partial, 20)
+
+	partial = config.NewPartialKV(20, zap.NewNop())
+	partial.DeletePrefix(0, "b")
+	writeSnapshot(t, partial, 30)
+
+	_, _, err = loadStoreState(ctx, config, 5, 0)
+	assert.EqualError(t, err, `no snapshot of store "store_a" found between its initial block 0 and block 5`)
+
+	for _, test := range []struct {
+		at                 uint64
+		expectedLoadedUpTo uint64
+		expected           map[string]string
+	}{
+		{10, 10, map[string]string{"a": "1", "b": "1"}},
+		{25, 20, map[string]string{"a": "2", "b": "1", "c": "2"}},
+		{30, 30, map[string]string{"a": "2", "c": "2"}},
+	} {
+		kv, loadedUpTo, err := loadStoreState(ctx, config, test.at, 0)
+		require.NoError(t, err)
+		assert.Equal(t, test.expectedLoadedUpTo, loadedUpTo)
+
+		actual := map[string]string{}
+		require.NoError(t, kv.Iter(func(key string, value []byte) error {
+			actual[key] = string(value)
+			return nil
+		}))
+		assert.Equal(t, test.expected, actual, "at %d", test.at)
+	}
+}
+
+func writeSnapshot(t *testing.T, kv store.Store, endBlock uint64) {
+	t.Helper()
+
+	require.NoError(t, kv.Flush())
+	_, writer, err := kv.Save(endBlock)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(context.Background()))
+}
+
+func TestStoreShell_Exec(t *testing.T) {
+	config, err := store.NewConfig("store_a", 0, "abc", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	kv := config.NewFullKV(zap.NewNop())
+	kv.Set(0, "pool:1", "one")
+	kv.Set(1, "pool:2", "two")
+	kv.Set(2, "pool: 3", "three")
+	kv.Set(3, "token:1", "four")
+	require.NoError(t, kv.Flush())
+
+	for _, test := range []struct {
+		line     string
+		expected string
+	}{
+		{"get pool:1", "pool:1\tone\n"},
+		{`get "pool: 3"`, "pool: 3\tthree\n"},
+		{`get pool:\ 3`, "pool: 3\tthree\n"},
+		{"get pool:4", "error: key \"pool:4\" not found\n"},
+		{"prefix pool:", "pool: 3\tthree\npool:1\tone\npool:2\ttwo\n"},
+		{"prefix pool: 2", "pool: 3\tthree\npool:1\tone\n"},
+		{"prefix pool: x", "error: invalid limit \"x\"\n"},
+		{"range pool:1 token:1", "pool:1\tone\npool:2\ttwo\n"},
+		{"count", "4\n"},
+		{"count 'pool:'", "3\n"},
+		{"decode-as bytes\nget token:1", "token:1\t666f7572\n"},
+		{"get 'pool:1", "error: unterminated ' quote\n"},
+		{"get", "error: usage: get <key>\n"},
+		{"unknown", "error: unknown command \"unknown\", use 'help' to list the commands\n"},
+		{"\n  \nexit\nget pool:1", ""},
+	} {
+		out := &bytes.Buffer{}
+		shell := &storeShell{kv: kv, out: out}
+		require.NoError(t, shell.setDecoder("string"))
+
+		require.NoError(t, shell.run(strings.NewReader(test.line), false))
+		assert.Equal(t, test.expected, out.String(), test.line)
+	}
+}
+
+func TestStoreShell_SetDecoder(t *testing.T) {
+	shell := &storeShell{
+		protoFiles: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(pbsubstreams.File_sf_substreams_v1_modules_proto)},
+	}
+
+	require.NoError(t, shell.setDecoder("bigint"))
+	decoded, err := shell.decode([]byte("123"))
+	require.NoError(t, err)
+	assert.Equal(t, "123", decoded)
+
+	require.NoError(t, shell.setDecoder("bytes"))
+	decoded, err = shell.decode([]byte{0xca, 0xfe})
+	require.NoError(t, err)
+	assert.Equal(t, "cafe", decoded)
+
+	require.NoError(t, shell.setDecoder("proto:sf.substreams.v1.Module"))
+	value, err := proto.Marshal(&pbsubstreams.Module{Name: "map_a", InitialBlock: 10})
+	require.NoError(t, err)
+	decoded, err = shell.decode(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "map_a", "initialBlock": "10"}`, decoded)
+
+	_, err = shell.decode([]byte{0xff})
+	assert.Error(t, err)
+
+	assert.EqualError(t, shell.setDecoder("sf.substreams.v1.Unknown"), `unknown type "sf.substreams.v1.Unknown", expecting 'string', 'bytes' or a protobuf message type of the package`)
+}
+
+func TestSplitCommand(t *testing.T) {
+	for _, test := range []struct {
+		line        string
+		expected    []string
+		expectedErr string
+	}{
+		{"", nil, ""},
+		{"  get   key  ", []string{"get", "key"}, ""},
+		{`get "a key" 'another key'`, []string{"get", "a key", "another key"}, ""},
+		{`get pre"fix 1"0`, []string{"get", "prefix 10"}, ""},
+		{`get "" x`, []string{"get", "", "x"}, ""},
+		{`get "a \"quoted\" key"`, []string{"get", `a "quoted" key`}, ""},
+		{`get 'a \ key'`, []string{"get", `a \ key`}, ""},
+		{`get "key`, nil, `unterminated " quote`},
+		{`get key\`, nil, "unterminated escape at end of line"},
+	} {
+		args, err := splitCommand(test.line)
+		if test.expectedErr != "" {
+			assert.EqualError(t, err, test.expectedErr, test.line)
+			continue
+		}
+		require.NoError(t, err, test.line)
+		assert.Equal(t, test.expected, args, test.line)
+	}
+}
@@ -0,0 +1,242 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jhump/protoreflect/dynamic"
+
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+)
+
+// NDJSONVersion is the version of the events written in the NDJSON output mode. It is
+// incremented on any change other than the addition of fields or event types.
+const NDJSONVersion = 1
+
+type ndjsonHeader struct {
+	Version int    `json:"version"`
+	Type    string `json:"type"`
+}
+
+func newNDJSONHeader(eventType string) ndjsonHeader {
+	return ndjsonHeader{Version: NDJSONVersion, Type: eventType}
+}
+
+type NDJSONSession struct {
+	ndjsonHeader
+	TraceID            string `json:"trace_id"`
+	ResolvedStartBlock uint64 `json:"resolved_start_block"`
+	LinearHandoffBlock uint64 `json:"linear_handoff_block"`
+	MaxParallelWorkers uint64 `json:"max_parallel_workers"`
+}
+
+type NDJSONData struct {
+	ndjsonHeader
+	Block            NDJSONBlock    `json:"block"`
+	FinalBlockHeight uint64         `json:"final_block_height"`
+	Cursor           string         `json:"cursor"`
+	Outputs          []NDJSONOutput `json:"outputs"`
+}
+
+type NDJSONBlock struct {
+	Number    uint64 `json:"number"`
+	ID        string `json:"id"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+// NDJSONOutput is the output of a module, `data` holding the JSON form of its message
+// when its type is known, `bytes` its raw content otherwise.
+type NDJSONOutput struct {
+	Module string          `json:"module"`
+	Type   string          `json:"type,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Bytes  []byte          `json:"bytes,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Logs   []string        `json:"logs,omitempty"`
+}
+
+type NDJSONUndo struct {
+	ndjsonHeader
+	LastValidBlock  NDJSONBlock `json:"last_valid_block"`
+	LastValidCursor string      `json:"last_valid_cursor"`
+}
+
+type NDJSONProgress struct {
+	ndjsonHeader
+	RunningJobs       int           `json:"running_jobs"`
+	Stages            []NDJSONStage `json:"stages"`
+	TotalBytesRead    uint64        `json:"total_bytes_read"`
+	TotalBytesWritten uint64        `json:"total_bytes_written"`
+}
+
+type NDJSONStage struct {
+	Modules         []string           `json:"modules"`
+	CompletedRanges []NDJSONBlockRange `json:"completed_ranges"`
+}
+
+type NDJSONBlockRange struct {
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+}
+
+type NDJSONError struct {
+	ndjsonHeader
+	Module        string   `json:"module,omitempty"`
+	Reason        string   `json:"reason"`
+	Logs          []string `json:"logs,omitempty"`
+	LogsTruncated bool     `json:"logs_truncated,omitempty"`
+}
+
+// ndjsonEvent returns the event of a response, or nil for responses without one
+func (ui *TUI) ndjsonEvent(resp *pbsubstreamsrpc.Response) any {
+	switch m := resp.Message.(type) {
+	case *pbsubstreamsrpc.Response_Session:
+		return &NDJSONSession{
+			ndjsonHeader:       newNDJSONHeader("session"),
+			TraceID:            m.Session.TraceId,
+			ResolvedStartBlock: m.Session.ResolvedStartBlock,
+			LinearHandoffBlock: m.Session.LinearHandoffBlock,
+			MaxParallelWorkers: m.Session.MaxParallelWorkers,
+		}
+
+	case *pbsubstreamsrpc.Response_BlockScopedData:
+		data := m.BlockScopedData
+		event := &NDJSONData{
+			ndjsonHeader: newNDJSONHeader("data"),
+			Block: NDJSONBlock{
+				Number: data.Clock.GetNumber(),
+				ID:     data.Clock.GetId(),
+			},
+			FinalBlockHeight: data.FinalBlockHeight,
+			Cursor:           data.Cursor,
+			Outputs:          []NDJSONOutput{},
+		}
+		if timestamp := data.Clock.GetTimestamp(); timestamp != nil {
+			event.Block.Timestamp = timestamp.AsTime().UTC().Format(time.RFC3339Nano)
+		}
+		for _, output := range data.RequestedOutputs() {
+			event.Outputs = append(event.Outputs, ui.ndjsonOutput(output))
+		}
+		return event
+
+	case *pbsubstreamsrpc.Response_BlockUndoSignal:
+		return &NDJSONUndo{
+			ndjsonHeader: newNDJSONHeader("undo"),
+			LastValidBlock: NDJSONBlock{
+				Number: m.BlockUndoSignal.LastValidBlock.GetNumber(),
+				ID:     m.BlockUndoSignal.LastValidBlock.GetId(),
+			},
+			LastValidCursor: m.BlockUndoSignal.LastValidCursor,
+		}
+
+	case *pbsubstreamsrpc.Response_Progress:
+		event := &NDJSONProgress{
+			ndjsonHeader:      newNDJSONHeader("progress"),
+			RunningJobs:       len(m.Progress.RunningJobs),
+			Stages:            []NDJSONStage{},
+			TotalBytesRead:    m.Progress.ProcessedBytes.GetTotalBytesRead(),
+			TotalBytesWritten: m.Progress.ProcessedBytes.GetTotalBytesWritten(),
+		}
+		for _, stage := range m.Progress.Stages {
+			ranges := []NDJSONBlockRange{}
+			for _, rng := range stage.CompletedRanges {
+				ranges = append(ranges, NDJSONBlockRange{StartBlock: rng.StartBlock, EndBlock: rng.EndBlock})
+			}
+			event.Stages = append(event.Stages, NDJSONStage{Modules: stage.Modules, CompletedRanges: ranges})
+		}
+		return event
+
+	case *pbsubstreamsrpc.Response_FatalError:
+		return &NDJSONError{
+			ndjsonHeader:  newNDJSONHeader("error"),
+			Module:        m.FatalError.Module,
+			Reason:        m.FatalError.Reason,
+			Logs:          m.FatalError.Logs,
+			LogsTruncated: m.FatalError.LogsTruncated,
+		}
+	}
+	return nil
+}
+
+func (ui *TUI) ndjsonOutput(output *pbsubstreamsrpc.MapModuleOutput) NDJSONOutput {
+	out := NDJSONOutput{
+		Module: output.Name,
+		Type:   ui.msgTypes[output.Name],
+	}
+	if output.DebugInfo != nil {
+		out.Logs = output.DebugInfo.Logs
+	}
+
+	value := output.GetMapOutput().GetValue()
+	if len(value) == 0 {
+		return out
+	}
+
+	msgDesc := ui.msgDescs[output.Name]
+	if msgDesc == nil {
+		out.Bytes = value
+		return out
+	}
+
+	dynMsg := dynamic.NewMessageFactoryWithDefaults().NewDynamicMessage(msgDesc)
+	if err := dynMsg.Unmarshal(value); err != nil {
+		out.Bytes = value
+		out.Error = fmt.Sprintf("unmarshalling message into %s: %s", out.Type, err)
+		return out
+	}
+	data, err := dynMsg.MarshalJSON()
+	if err != nil {
+		out.Bytes = value
+		out.Error = fmt.Sprintf("encoding protobuf %s into json: %s", out.Type, err)
+		return out
+	}
+	out.Data = data
+	return out
+}
+
+func (ui *TUI) printNDJSON(resp *pbsubstreamsrpc.Response) error {
+	if progress := resp.GetProgress(); progress != nil && progress.ProcessedBytes != nil {
+		ui.TotalReadBytes = progress.ProcessedBytes.TotalBytesRead
+	}
+
+	event := ui.ndjsonEvent(resp)
+	if event == nil {
+		return nil
+	}
+	if resp.GetFatalError() != nil {
+		ui.seenFatalError = true
+	}
+	return printNDJSONEvent(event)
+}
+
+// StreamError writes the error ending the stream as an `error` event in the NDJSON output mode.
+func (ui *TUI) StreamError(err error) {
+	if ui.outputMode != OutputModeNDJSON {
+		return
+	}
+	if event := ui.streamErrorEvent(err); event != nil {
+		_ = printNDJSONEvent(event)
+	}
+}
+
+// streamErrorEvent returns the `error` event of the error ending the stream, or nil when
+// the server's fatal error, ending the stream with that error, was already written.
+func (ui *TUI) streamErrorEvent(err error) *NDJSONError {
+	if ui.seenFatalError {
+		return nil
+	}
+	return &NDJSONError{
+		ndjsonHeader: newNDJSONHeader("error"),
+		Reason:       err.Error(),
+	}
+}
+
+func printNDJSONEvent(event any) error {
+	cnt, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	fmt.Println(string(cnt))
+	return nil
+}
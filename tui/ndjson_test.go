@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestNDJSONEvent(t *testing.T) {
+	fileDescs, err := desc.CreateFileDescriptors([]*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(pbsubstreams.File_sf_substreams_v1_modules_proto)})
+	require.NoError(t, err)
+	var msgDesc *desc.MessageDescriptor
+	for _, file := range fileDescs {
+		if msgDesc = file.FindMessage("sf.substreams.v1.Binary"); msgDesc != nil {
+			break
+		}
+	}
+	require.NotNil(t, msgDesc)
+
+	ui := &TUI{
+		msgTypes: map[string]string{"map_a": "sf.substreams.v1.Binary", "map_b": "sf.substreams.v1.Unknown"},
+		msgDescs: map[string]*desc.MessageDescriptor{"map_a": msgDesc},
+	}
+
+	binary, err := proto.Marshal(&pbsubstreams.Binary{Type: "wasm/rust-v1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		resp     *pbsubstreamsrpc.Response
+		expected string
+	}{
+		{
+			"session",
+			&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_Session{Session: &pbsubstreamsrpc.SessionInit{TraceId: "abc", ResolvedStartBlock: 10, LinearHandoffBlock: 20, MaxParallelWorkers: 4}}},
+			`{"version":1,"type":"session","trace_id":"abc","resolved_start_block":10,"linear_handoff_block":20,"max_parallel_workers":4}`,
+		},
+		{
+			"data",
+			&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockScopedData{BlockScopedData: &pbsubstreamsrpc.BlockScopedData{
+				Clock:            &pbsubstreams.Clock{Number: 12, Id: "0c", Timestamp: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))},
+				Cursor:           "cursor12",
+				FinalBlockHeight: 10,
+				Output:           &pbsubstreamsrpc.MapModuleOutput{Name: "map_a", MapOutput: &anypb.Any{Value: binary}, DebugInfo: &pbsubstreamsrpc.OutputDebugInfo{Logs: []string{"hello"}}},
+				Outputs: []*pbsubstreamsrpc.MapModuleOutput{
+					{Name: "map_a", MapOutput: &anypb.Any{Value: binary}, DebugInfo: &pbsubstreamsrpc.OutputDebugInfo{Logs: []string{"hello"}}},
+					{Name: "map_b", MapOutput: &anypb.Any{Value: []byte{0xca, 0xfe}}},
+					{Name: "map_c", MapOutput: &anypb.Any{}},
+				},
+			}}},
+			`{"version":1,"type":"data","block":{"number":12,"id":"0c","timestamp":"2024-01-02T03:04:05Z"},"final_block_height":10,"cursor":"cursor12","outputs":[` +
+				`{"module":"map_a","type":"sf.substreams.v1.Binary","data":{"type":"wasm/rust-v1"},"logs":["hello"]},` +
+				`{"module":"map_b","type":"sf.substreams.v1.Unknown","bytes":"yv4="},` +
+				`{"module":"map_c"}]}`,
+		},
+		{
+			"undo",
+			&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockUndoSignal{BlockUndoSignal: &pbsubstreamsrpc.BlockUndoSignal{
+				LastValidBlock:  &pbsubstreams.BlockRef{Number: 11, Id: "0b"},
+				LastValidCursor: "cursor11",
+			}}},
+			`{"version":1,"type":"undo","last_valid_block":{"number":11,"id":"0b"},"last_valid_cursor":"cursor11"}`,
+		},
+		{
+			"progress",
+			&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_Progress{Progress: &pbsubstreamsrpc.ModulesProgress{
+				RunningJobs:    []*pbsubstreamsrpc.Job{{}, {}},
+				Stages:         []*pbsubstreamsrpc.Stage{{Modules: []string{"store_a"}, CompletedRanges: []*pbsubstreamsrpc.BlockRange{{StartBlock: 0, EndBlock: 100}}}},
+				ProcessedBytes: &pbsubstreamsrpc.ProcessedBytes{TotalBytesRead: 1000, TotalBytesWritten: 10},
+			}}},
+			`{"version":1,"type":"progress","running_jobs":2,"stages":[{"modules":["store_a"],"completed_ranges":[{"start_block":0,"end_block":100}]}],"total_bytes_read":1000,"total_bytes_written":10}`,
+		},
+		{
+			"error",
+			&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_FatalError{FatalError: &pbsubstreamsrpc.Error{Module: "map_a", Reason: "panic", Logs: []string{"before"}}}},
+			`{"version":1,"type":"error","module":"map_a","reason":"panic","logs":["before"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cnt, err := json.Marshal(ui.ndjsonEvent(test.resp))
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(cnt))
+		})
+	}
+
+	assert.Nil(t, ui.ndjsonEvent(&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_DebugSnapshotComplete{}}))
+}
+
+func TestStreamErrorEvent(t *testing.T) {
+	ui := &TUI{outputMode: OutputModeNDJSON}
+
+	cnt, err := json.Marshal(ui.streamErrorEvent(fmt.Errorf("connection refused")))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":1,"type":"error","reason":"connection refused"}`, string(cnt))
+
+	require.NoError(t, ui.printNDJSON(&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_FatalError{FatalError: &pbsubstreamsrpc.Error{Module: "map_a", Reason: "panic"}}}))
+	assert.Nil(t, ui.streamErrorEvent(fmt.Errorf("rpc error: panic")), "the fatal error is the one event of the failure")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/dustin/go-humanize"
@@ -17,13 +18,13 @@ import (
 )
 
 func (ui *TUI) decoratedBlockScopedData(
-	output *pbsubstreamsrpc.MapModuleOutput,
+	outputs []*pbsubstreamsrpc.MapModuleOutput,
 	debugMapOutputs []*pbsubstreamsrpc.MapModuleOutput,
 	debugStoreOutputs []*pbsubstreamsrpc.StoreModuleOutput,
 	clock *pbsubstreams.Clock,
 ) error {
 	var s []string
-	for _, out := range append([]*pbsubstreamsrpc.MapModuleOutput{output}, debugMapOutputs...) {
+	for _, out := range append(slices.Clip(outputs), debugMapOutputs...) {
 		if _, ok := ui.msgTypes[out.Name]; !ok {
 			continue
 		}
@@ -125,13 +126,13 @@ func indent(in []byte) []byte {
 }
 
 func (ui *TUI) jsonBlockScopedData(
-	output *pbsubstreamsrpc.MapModuleOutput,
+	outputs []*pbsubstreamsrpc.MapModuleOutput,
 	debugMapOutputs []*pbsubstreamsrpc.MapModuleOutput,
 	debugStoreOutputs []*pbsubstreamsrpc.StoreModuleOutput,
 	clock *pbsubstreams.Clock,
 ) error {
 
-	for _, out := range append([]*pbsubstreamsrpc.MapModuleOutput{output}, debugMapOutputs...) {
+	for _, out := range append(slices.Clip(outputs), debugMapOutputs...) {
 		if _, ok := ui.msgTypes[out.Name]; !ok {
 			continue
 		}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/substreams/tabular"
 	"github.com/streamingfast/substreams/tools/test"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,7 +21,7 @@ import (
 
 //go:generate go-enum -f=$GOFILE --nocase --marshal --names
 
-// ENUM(TUI, JSON, JSONL, CLOCK, CURSOR)
+// ENUM(TUI, JSON, JSONL, CLOCK, CURSOR, PARQUET, CSV, NDJSON)
 type OutputMode uint
 
 type TUI struct {
@@ -35,8 +36,15 @@ type TUI struct {
 	outputMode        OutputMode
 	prettyPrintOutput bool
 
+	// file output, for the PARQUET and CSV output modes
+	fileOutputDir      string
+	fileOutputRotation uint64
+	fileOutputClosed   func()
+	exporter           *tabular.Exporter
+
 	prog           *tea.Program
 	seenFirstData  bool
+	seenFatalError bool // the server's fatal error was written as an `error` event, in the NDJSON output mode
 	TotalReadBytes uint64
 
 	msgDescs       map[string]*desc.MessageDescriptor
@@ -100,9 +108,46 @@ func (ui *TUI) Init(outputMode string) error {
 			}
 		}
 	}
+
+	if ui.outputMode == OutputModePARQUET || ui.outputMode == OutputModeCSV {
+		msgDesc := ui.msgDescs[ui.req.OutputModule]
+		if msgDesc == nil {
+			return fmt.Errorf("output module %q does not have a protobuf output type, it cannot be written as %s", ui.req.OutputModule, ui.outputMode)
+		}
+
+		ui.exporter, err = tabular.NewExporter(ui.req.OutputModule, msgDesc.UnwrapMessage(), tabular.Format(strings.ToLower(ui.outputMode.String())), ui.fileOutputDir, ui.fileOutputRotation)
+		if err != nil {
+			return fmt.Errorf("creating file output: %w", err)
+		}
+		if ui.fileOutputClosed != nil {
+			ui.exporter.OnFilesClosed(ui.fileOutputClosed)
+		}
+		fmt.Printf("Writing tables %s to %q\n", strings.Join(ui.exporter.TableNames(), ", "), ui.fileOutputDir)
+	}
 	return nil
 }
 
+// SetFileOutput configures where the PARQUET and CSV output modes write their files,
+// starting new files every `rotationBlocks` blocks (0 to write a single file per table).
+func (ui *TUI) SetFileOutput(dir string, rotationBlocks uint64) {
+	ui.fileOutputDir = dir
+	ui.fileOutputRotation = rotationBlocks
+}
+
+// OnFileOutputClosed registers a function called every time the files of the PARQUET
+// and CSV output modes are completely written, holding all the blocks received until then.
+func (ui *TUI) OnFileOutputClosed(f func()) {
+	ui.fileOutputClosed = f
+}
+
+// CloseFileOutput writes out the files of the PARQUET and CSV output modes.
+func (ui *TUI) CloseFileOutput() error {
+	if ui.exporter == nil {
+		return nil
+	}
+	return ui.exporter.Close()
+}
+
 func (ui *TUI) configureOutputMode(outputMode string) error {
 	ui.isTerminal = isatty.IsTerminal(os.Stdout.Fd())
 
@@ -130,6 +175,8 @@ func (ui *TUI) configureOutputMode(outputMode string) error {
 		fmt.Println("Writing clock information only (no data)")
 	case OutputModeJSON:
 		ui.prettyPrintOutput = true
+	case OutputModePARQUET, OutputModeCSV:
+	case OutputModeNDJSON:
 	default:
 		panic(fmt.Errorf("unhandled output mode %q", ui.outputMode))
 	}
@@ -150,6 +197,15 @@ func (ui *TUI) Cancel() {
 }
 
 func (ui *TUI) IncomingMessage(ctx context.Context, resp *pbsubstreamsrpc.Response, testRunner *test.Runner) error {
+	if ui.outputMode == OutputModeNDJSON {
+		if data := resp.GetBlockScopedData(); data != nil && testRunner != nil {
+			if err := testRunner.Test(ctx, data.Output, data.DebugMapOutputs, data.DebugStoreOutputs, data.Clock); err != nil {
+				return fmt.Errorf("test runner failed: %w", err)
+			}
+		}
+		return ui.printNDJSON(resp)
+	}
+
 	switch m := resp.Message.(type) {
 	case *pbsubstreamsrpc.Response_BlockUndoSignal:
 		switch ui.outputMode {
@@ -166,6 +222,8 @@ func (ui *TUI) IncomingMessage(ctx context.Context, resp *pbsubstreamsrpc.Respon
 				return err
 			}
 			fmt.Println(cur.String())
+		case OutputModePARQUET, OutputModeCSV:
+			return fmt.Errorf("cannot undo block %d: files written in %s output mode only hold final blocks", m.BlockUndoSignal.LastValidBlock.Number, ui.outputMode)
 		}
 
 	case *pbsubstreamsrpc.Response_BlockScopedData:
@@ -192,14 +250,19 @@ func (ui *TUI) IncomingMessage(ctx context.Context, resp *pbsubstreamsrpc.Respon
 			}
 			fmt.Println(cur.String())
 			return nil
+		case OutputModePARQUET, OutputModeCSV:
+			if output := m.BlockScopedData.Output; output != nil && output.MapOutput != nil {
+				return ui.exporter.Write(m.BlockScopedData.Clock, output.MapOutput.Value)
+			}
+			return nil
 		}
 
 		ui.seenFirstData = true
 		if ui.outputMode == OutputModeTUI {
 			ui.ensureTerminalUnlocked()
-			return ui.decoratedBlockScopedData(m.BlockScopedData.Output, m.BlockScopedData.DebugMapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
+			return ui.decoratedBlockScopedData(m.BlockScopedData.RequestedOutputs(), m.BlockScopedData.DebugMapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
 		} else {
-			return ui.jsonBlockScopedData(m.BlockScopedData.Output, m.BlockScopedData.DebugMapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
+			return ui.jsonBlockScopedData(m.BlockScopedData.RequestedOutputs(), m.BlockScopedData.DebugMapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
 		}
 	case *pbsubstreamsrpc.Response_Progress:
 		if m.Progress.ProcessedBytes != nil {
@@ -22,11 +22,17 @@ const (
 	OutputModeCLOCK
 	// OutputModeCURSOR is a OutputMode of type CURSOR.
 	OutputModeCURSOR
+	// OutputModePARQUET is a OutputMode of type PARQUET.
+	OutputModePARQUET
+	// OutputModeCSV is a OutputMode of type CSV.
+	OutputModeCSV
+	// OutputModeNDJSON is a OutputMode of type NDJSON.
+	OutputModeNDJSON
 )
 
 var ErrInvalidOutputMode = fmt.Errorf("not a valid OutputMode, try [%s]", strings.Join(_OutputModeNames, ", "))
 
-const _OutputModeName = "TUIJSONJSONLCLOCKCURSOR"
+const _OutputModeName = "TUIJSONJSONLCLOCKCURSORPARQUETCSVNDJSON"
 
 var _OutputModeNames = []string{
 	_OutputModeName[0:3],
@@ -34,6 +40,9 @@ var _OutputModeNames = []string{
 	_OutputModeName[7:12],
 	_OutputModeName[12:17],
 	_OutputModeName[17:23],
+	_OutputModeName[23:30],
+	_OutputModeName[30:33],
+	_OutputModeName[33:39],
 }
 
 // OutputModeNames returns a list of possible string values of OutputMode.
@@ -44,11 +53,14 @@ func OutputModeNames() []string {
 }
 
 var _OutputModeMap = map[OutputMode]string{
-	OutputModeTUI:    _OutputModeName[0:3],
-	OutputModeJSON:   _OutputModeName[3:7],
-	OutputModeJSONL:  _OutputModeName[7:12],
-	OutputModeCLOCK:  _OutputModeName[12:17],
-	OutputModeCURSOR: _OutputModeName[17:23],
+	OutputModeTUI:     _OutputModeName[0:3],
+	OutputModeJSON:    _OutputModeName[3:7],
+	OutputModeJSONL:   _OutputModeName[7:12],
+	OutputModeCLOCK:   _OutputModeName[12:17],
+	OutputModeCURSOR:  _OutputModeName[17:23],
+	OutputModePARQUET: _OutputModeName[23:30],
+	OutputModeCSV:     _OutputModeName[30:33],
+	OutputModeNDJSON:  _OutputModeName[33:39],
 }
 
 // String implements the Stringer interface.
@@ -77,6 +89,12 @@ var _OutputModeValue = map[string]OutputMode{
 	strings.ToLower(_OutputModeName[12:17]): OutputModeCLOCK,
 	_OutputModeName[17:23]:                  OutputModeCURSOR,
 	strings.ToLower(_OutputModeName[17:23]): OutputModeCURSOR,
+	_OutputModeName[23:30]:                  OutputModePARQUET,
+	strings.ToLower(_OutputModeName[23:30]): OutputModePARQUET,
+	_OutputModeName[30:33]:                  OutputModeCSV,
+	strings.ToLower(_OutputModeName[30:33]): OutputModeCSV,
+	_OutputModeName[33:39]:                  OutputModeNDJSON,
+	strings.ToLower(_OutputModeName[33:39]): OutputModeNDJSON,
 }
 
 // ParseOutputMode attempts to convert a string to a OutputMode.
@@ -52,6 +52,9 @@ func (b *Build) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		b.BuildOutputErrMsgs = []string{}
 	case buildoutput.BuildOutputMsg:
 		b.BuildOutputMsgs = append(b.BuildOutputMsgs, msg.Msg)
+	case WatchBuildMsg:
+		b.BuildOutputMsgs = strings.Split(strings.TrimRight(msg.Output, "\n"), "\n")
+		b.BuildOutputErrMsgs = []string{}
 	}
 	var cmd tea.Cmd
 	b.buildView, cmd = b.buildView.Update(msg)
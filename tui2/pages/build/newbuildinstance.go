@@ -15,6 +15,14 @@ func SetupNewBuildCmd() tea.Cmd {
 	}
 }
 
+// WatchBuildMsg is sent when the sources of the manifest were rebuilt in watch mode, Restart being set
+// when the hashes of the modules changed
+type WatchBuildMsg struct {
+	Output  string
+	Err     error
+	Restart bool
+}
+
 type NewBuildInstance *BuildInstance
 
 type BuildInstance struct {
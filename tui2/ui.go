@@ -168,6 +168,13 @@ func (ui *UI) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, tabs.SelectTabCmd(int(buildPage)))
 		}
 
+	case build.WatchBuildMsg:
+		if msg.Err != nil {
+			cmds = append(cmds, common.SetModalComponentCmd(errorbox.New(ui.Common, "Build failed, keeping the current stream: "+msg.Err.Error())))
+		} else if msg.Restart {
+			cmds = append(cmds, ui.setupNewInstance(true))
+		}
+
 	case request.SetupNewInstanceMsg:
 		return ui, ui.setupNewInstance(msg.StartStream)
 	case request.NewRequestInstance:
@@ -0,0 +1,44 @@
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/streamingfast/substreams/metrics"
+	"github.com/streamingfast/substreams/storage/store"
+)
+
+// NewStoreAggregateFunc returns the aggregate function of a store with the custom update policy,
+// calling the `entrypoint` export of the module's binary with the previous and the next value of
+// a key, as two byte slices like map inputs, and returning its output.
+func NewStoreAggregateFunc(ctx context.Context, module Module, moduleName, entrypoint string, stats *metrics.Stats, instanceCacheEnabled bool) store.AggregateFunc {
+	arguments := []Argument{NewMapInput("prev", 0), NewMapInput("next", 0)}
+
+	var lock sync.Mutex
+	var cachedInstance Instance
+	return func(prev, next []byte) (out []byte, err error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		call := NewCall(nil, moduleName, entrypoint, stats, arguments)
+		inst, err := module.ExecuteNewCall(ctx, call, cachedInstance, arguments, map[string][]byte{"prev": prev, "next": next})
+		if panicErr := call.Err(); panicErr != nil {
+			return nil, fmt.Errorf("module %q: merge function %q panicked: %w", moduleName, entrypoint, panicErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("module %q: merge function %q failed: %w", moduleName, entrypoint, err)
+		}
+
+		if instanceCacheEnabled {
+			if err := inst.Cleanup(ctx); err != nil {
+				return nil, fmt.Errorf("module %q: failed to cleanup module: %w", moduleName, err)
+			}
+			cachedInstance = inst
+		} else if err := inst.Close(ctx); err != nil {
+			return nil, fmt.Errorf("module %q: failed to close module: %w", moduleName, err)
+		}
+
+		return call.Output(), nil
+	}
+}
@@ -0,0 +1,86 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams/metrics"
+)
+
+type testInstance struct {
+	closed, cleaned int
+}
+
+func (i *testInstance) Cleanup(ctx context.Context) error { i.cleaned++; return nil }
+func (i *testInstance) Close(ctx context.Context) error   { i.closed++; return nil }
+
+// testModule concatenates the values it is called with, panicking on empty values
+type testModule struct {
+	entrypoints []string
+	instances   []*testInstance
+}
+
+func (m *testModule) NewInstance(ctx context.Context) (Instance, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *testModule) ExecuteNewCall(ctx context.Context, call *Call, cachedInstance Instance, arguments []Argument, argValues map[string][]byte) (Instance, error) {
+	m.entrypoints = append(m.entrypoints, call.Entrypoint)
+	inst, _ := cachedInstance.(*testInstance)
+	if inst == nil {
+		inst = &testInstance{}
+		m.instances = append(m.instances, inst)
+	}
+
+	prev, next := argValues[arguments[0].Name()], argValues[arguments[1].Name()]
+	if len(prev) == 0 || len(next) == 0 {
+		call.SetPanicError("empty value", "lib.rs", 1, 1)
+		return inst, errors.New("wasm trap")
+	}
+	call.SetReturnValue(append(append([]byte{}, prev...), next...))
+	return inst, nil
+}
+
+func (m *testModule) Close(ctx context.Context) error { return nil }
+
+func TestNewStoreAggregateFunc(t *testing.T) {
+	stats := metrics.NewReqStats(&metrics.Config{}, zap.NewNop())
+
+	module := &testModule{}
+	aggregate := NewStoreAggregateFunc(context.Background(), module, "store_a", "merge_a", stats, false)
+
+	out, err := aggregate([]byte("a"), []byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ab"), out)
+
+	out, err = aggregate([]byte("ab"), []byte("c"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("abc"), out)
+
+	_, err = aggregate([]byte("a"), nil)
+	assert.ErrorContains(t, err, `module "store_a": merge function "merge_a" panicked`)
+
+	assert.Equal(t, []string{"merge_a", "merge_a", "merge_a"}, module.entrypoints)
+	require.Len(t, module.instances, 3)
+	assert.Equal(t, 1, module.instances[0].closed)
+}
+
+func TestNewStoreAggregateFunc_InstanceCache(t *testing.T) {
+	stats := metrics.NewReqStats(&metrics.Config{}, zap.NewNop())
+
+	module := &testModule{}
+	aggregate := NewStoreAggregateFunc(context.Background(), module, "store_a", "merge_a", stats, true)
+
+	for _, next := range []string{"b", "c"} {
+		_, err := aggregate([]byte("a"), []byte(next))
+		require.NoError(t, err)
+	}
+	require.Len(t, module.instances, 1)
+	assert.Equal(t, 2, module.instances[0].cleaned)
+	assert.Equal(t, 0, module.instances[0].closed)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/streamingfast/substreams/metrics"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -128,12 +129,30 @@ func (c *Call) DoAppend(ord uint64, key string, value []byte) {
 	c.outputStore.Append(ord, key, value)
 	c.stats.RecordModuleWasmStoreWrite(c.ModuleName, c.outputStore.SizeBytes(), time.Since(now))
 }
+func (c *Call) DoAggregate(ord uint64, key string, value []byte) {
+	now := time.Now()
+	c.validateSimple("aggregate", pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM, key)
+	c.outputStore.Aggregate(ord, key, value)
+	c.stats.RecordModuleWasmStoreWrite(c.ModuleName, c.outputStore.SizeBytes(), time.Since(now))
+}
 func (c *Call) DoDeletePrefix(ord uint64, prefix string) {
 	now := time.Now()
 	c.traceStateWrites("delete_prefix", prefix)
 	c.outputStore.DeletePrefix(ord, prefix)
 	c.stats.RecordModuleWasmStoreDeletePrefix(c.ModuleName, c.outputStore.SizeBytes(), time.Since(now))
 }
+func (c *Call) DoDeleteRange(ord uint64, lowKey, highKey string) {
+	now := time.Now()
+	c.traceStateWrites("delete_range", lowKey)
+	c.outputStore.DeleteRange(ord, lowKey, highKey)
+	c.stats.RecordModuleWasmStoreDeletePrefix(c.ModuleName, c.outputStore.SizeBytes(), time.Since(now))
+}
+func (c *Call) DoDeleteRangePointers(ord uint64, lowKey, highKey, pointerSeparator string) {
+	now := time.Now()
+	c.traceStateWrites("delete_range_pointers", lowKey)
+	c.outputStore.DeleteRangePointers(ord, lowKey, highKey, pointerSeparator)
+	c.stats.RecordModuleWasmStoreDeletePrefix(c.ModuleName, c.outputStore.SizeBytes(), time.Since(now))
+}
 func (c *Call) DoAddBigInt(ord uint64, key string, value string) {
 	now := time.Now()
 	c.validateWithValueType("add_bigint", pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "bigint", key)
@@ -306,6 +325,74 @@ func (c *Call) DoHasLast(storeIndex int, key string) (found bool) {
 	return readStore.HasLast(key)
 }
 
+// DoScanPrefix returns the page of the keys starting with prefix, after the key `after` when set, as a marshalled
+// StoreScanPage, with the number of entries it holds. No page is returned when it holds no entries.
+func (c *Call) DoScanPrefix(storeIndex int, ord uint64, prefix, after string, limit int) (page []byte, count int) {
+	low, high := store.PrefixRange(prefix)
+	return c.scan("scan_prefix", storeIndex, ord, low, high, after, limit)
+}
+
+// DoScanRange returns the page of the keys between start (inclusive) and end (exclusive, no upper bound when empty),
+// like DoScanPrefix does.
+func (c *Call) DoScanRange(storeIndex int, ord uint64, start, end, after string, limit int) (page []byte, count int) {
+	return c.scan("scan_range", storeIndex, ord, start, end, after, limit)
+}
+
+func (c *Call) scan(stateFunc string, storeIndex int, ord uint64, low, high, after string, limit int) ([]byte, int) {
+	// keys greater than `after` start with it followed by anything, the smallest being a 0 byte
+	if after != "" && after+"\x00" > low {
+		low = after + "\x00"
+	}
+
+	return c.readPage(stateFunc, storeIndex, low, limit, func(readStore store.Reader, f func(key string, value []byte) bool) error {
+		readStore.ScanAt(ord, low, high, f)
+		return nil
+	})
+}
+
+// DoIndexLookup returns the page of the keys having `value` in the secondary index named `index` of the store,
+// after the key `after` when set, like DoScanPrefix does.
+func (c *Call) DoIndexLookup(storeIndex int, ord uint64, index, value, after string, limit int) (page []byte, count int) {
+	return c.readPage("index_lookup", storeIndex, index+"="+value, limit, func(readStore store.Reader, f func(key string, value []byte) bool) error {
+		return readStore.IndexLookupAt(ord, index, value, after, f)
+	})
+}
+
+// readPage returns the marshalled StoreScanPage of at most `limit` entries read from the store by `read`,
+// with the number of entries it holds.
+func (c *Call) readPage(stateFunc string, storeIndex int, traceKey string, limit int, read func(readStore store.Reader, f func(key string, value []byte) bool) error) ([]byte, int) {
+	now := time.Now()
+	defer func() { c.stats.RecordModuleWasmStoreRead(c.ModuleName, time.Since(now)) }()
+	c.validateStoreIndex(storeIndex, stateFunc)
+	if limit <= 0 {
+		c.ReturnError(fmt.Errorf("%q failed: limit must be positive, got %d", stateFunc, limit))
+	}
+
+	readStore := c.inputStores[storeIndex]
+	page := &pbsubstreams.StoreScanPage{}
+	err := read(readStore, func(key string, value []byte) bool {
+		if len(page.Entries) == limit {
+			page.More = true
+			return false
+		}
+		page.Entries = append(page.Entries, &pbsubstreams.StoreEntry{Key: key, Value: value})
+		return true
+	})
+	if err != nil {
+		c.ReturnError(fmt.Errorf("%q failed: %w", stateFunc, err))
+	}
+	c.traceStateReads(stateFunc, storeIndex, len(page.Entries) != 0, traceKey)
+
+	if len(page.Entries) == 0 {
+		return nil, 0
+	}
+	out, err := proto.Marshal(page)
+	if err != nil {
+		c.ReturnError(fmt.Errorf("%q failed: marshalling page: %w", stateFunc, err))
+	}
+	return out, len(page.Entries)
+}
+
 func (c *Call) validateStoreIndex(storeIndex int, stateFunc string) {
 	if storeIndex+1 > len(c.inputStores) {
 		c.ReturnError(fmt.Errorf("%q failed: invalid store index %d, %d stores declared", stateFunc, storeIndex, len(c.inputStores)))
@@ -366,4 +453,5 @@ var policyMap = map[pbsubstreams.Module_KindStore_UpdatePolicy]string{
 	pbsubstreams.Module_KindStore_UPDATE_POLICY_MIN:               "min",
 	pbsubstreams.Module_KindStore_UPDATE_POLICY_MAX:               "max",
 	pbsubstreams.Module_KindStore_UPDATE_POLICY_APPEND:            "append",
+	pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM:            "custom",
 }
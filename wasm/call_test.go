@@ -5,7 +5,9 @@ import (
 
 	"github.com/streamingfast/dstore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/streamingfast/substreams/metrics"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -88,6 +90,22 @@ func Test_CallStoreOps(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"aggregate golden path",
+			newTestCall(pbsubstreams.Module_KindStore_UPDATE_POLICY_CUSTOM, "bytes"),
+			func(c *Call) {
+				c.DoAggregate(0, "key", []byte("value"))
+			},
+			true,
+		},
+		{
+			"aggregate wrong policy",
+			newTestCall(pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "bytes"),
+			func(c *Call) {
+				c.DoAggregate(0, "key", []byte("value"))
+			},
+			false,
+		},
 		{
 			"add_bigint golden path",
 			newTestCall(pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "bigint"),
@@ -480,6 +498,86 @@ func Test_CallStoreOps(t *testing.T) {
 	}
 }
 
+func Test_CallScan(t *testing.T) {
+	c := newTestCall(pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string")
+	inputStore := c.outputStore.(*store.FullKV)
+	for _, key := range []string{"user:a", "user:b", "user:c", "user:d", "other"} {
+		inputStore.Set(0, key, key)
+	}
+	require.NoError(t, inputStore.Flush())
+	c.inputStores = []store.Reader{inputStore}
+
+	readPage := func(page []byte, count int) (keys []string, more bool) {
+		out := &pbsubstreams.StoreScanPage{}
+		require.NoError(t, proto.Unmarshal(page, out))
+		require.Len(t, out.Entries, count)
+		for _, entry := range out.Entries {
+			assert.Equal(t, entry.Key, string(entry.Value))
+			keys = append(keys, entry.Key)
+		}
+		return keys, out.More
+	}
+
+	keys, more := readPage(c.DoScanPrefix(0, 0, "user:", "", 3))
+	assert.Equal(t, []string{"user:a", "user:b", "user:c"}, keys)
+	assert.True(t, more)
+
+	keys, more = readPage(c.DoScanPrefix(0, 0, "user:", "user:c", 3))
+	assert.Equal(t, []string{"user:d"}, keys)
+	assert.False(t, more)
+
+	keys, more = readPage(c.DoScanRange(0, 0, "other", "user:c", "", 10))
+	assert.Equal(t, []string{"other", "user:a", "user:b"}, keys)
+	assert.False(t, more)
+
+	page, count := c.DoScanPrefix(0, 0, "none:", "", 10)
+	assert.Nil(t, page)
+	assert.Equal(t, 0, count)
+
+	assert.Panics(t, func() { c.DoScanPrefix(0, 0, "user:", "", 0) })
+	assert.Panics(t, func() { c.DoScanPrefix(1, 0, "user:", "", 10) })
+}
+
+func Test_CallIndexLookup(t *testing.T) {
+	c := newTestCall(pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string")
+	storeConf, err := store.NewConfig("test", 0, "", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	require.NoError(t, storeConf.SetIndexes([]*pbsubstreams.Module_KindStore_Index{
+		{Name: "owner", Extract: &pbsubstreams.Module_KindStore_Index_KeySegment{KeySegment: 1}},
+	}))
+	inputStore := storeConf.NewFullKV(zap.NewNop())
+	for _, key := range []string{"token:alice:1", "token:bob:2", "token:alice:3", "token:alice:4"} {
+		inputStore.Set(0, key, key)
+	}
+	require.NoError(t, inputStore.Flush())
+	c.inputStores = []store.Reader{inputStore}
+
+	readKeys := func(page []byte, count int) (keys []string, more bool) {
+		out := &pbsubstreams.StoreScanPage{}
+		require.NoError(t, proto.Unmarshal(page, out))
+		require.Len(t, out.Entries, count)
+		for _, entry := range out.Entries {
+			keys = append(keys, entry.Key)
+		}
+		return keys, out.More
+	}
+
+	keys, more := readKeys(c.DoIndexLookup(0, 0, "owner", "alice", "", 2))
+	assert.Equal(t, []string{"token:alice:1", "token:alice:3"}, keys)
+	assert.True(t, more)
+
+	keys, more = readKeys(c.DoIndexLookup(0, 0, "owner", "alice", "token:alice:3", 2))
+	assert.Equal(t, []string{"token:alice:4"}, keys)
+	assert.False(t, more)
+
+	page, count := c.DoIndexLookup(0, 0, "owner", "carol", "", 10)
+	assert.Nil(t, page)
+	assert.Equal(t, 0, count)
+
+	assert.Panics(t, func() { c.DoIndexLookup(0, 0, "missing", "alice", "", 10) })
+	assert.Panics(t, func() { c.DoIndexLookup(0, 0, "owner", "alice", "", 0) })
+}
+
 func newTestCall(updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy, valueType string) *Call {
 	myStore := dstore.NewMockStore(nil)
 	storeConf, err := store.NewConfig("test", 0, "", updatePolicy, valueType, myStore)
@@ -116,7 +116,10 @@ func (i *instance) registerStateImports(linker *wasmtime.Linker) error {
 	functions["set"] = i.set
 	functions["set_if_not_exists"] = i.setIfNotExists
 	functions["append"] = i.append
+	functions["aggregate"] = i.aggregate
 	functions["delete_prefix"] = i.deletePrefix
+	functions["delete_range"] = i.deleteRange
+	functions["delete_range_pointers"] = i.deleteRangePointers
 	functions["set_sum_int64"] = i.setSumInt64
 	functions["set_sum_float64"] = i.setSumFloat64
 	functions["set_sum_bigint"] = i.setSumBigInt
@@ -142,6 +145,9 @@ func (i *instance) registerStateImports(linker *wasmtime.Linker) error {
 	functions["has_at"] = i.hasAt
 	functions["has_first"] = i.hasFirst
 	functions["has_last"] = i.hasLast
+	functions["scan_prefix"] = i.scanPrefix
+	functions["scan_range"] = i.scanRange
+	functions["index_lookup"] = i.indexLookup
 
 	for n, f := range functions {
 		if err := linker.FuncWrap("state", n, f); err != nil {
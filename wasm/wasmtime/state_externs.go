@@ -22,11 +22,30 @@ func (i *instance) append(ord int64, keyPtr, keyLength, valPtr, valLength int32)
 	i.CurrentCall.DoAppend(uint64(ord), key, value)
 }
 
+func (i *instance) aggregate(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
+	key := i.Heap.ReadString(keyPtr, keyLength)
+	value := i.Heap.ReadBytes(valPtr, valLength)
+	i.CurrentCall.DoAggregate(uint64(ord), key, value)
+}
+
 func (i *instance) deletePrefix(ord int64, keyPtr, keyLength int32) {
 	prefix := i.Heap.ReadString(keyPtr, keyLength)
 	i.CurrentCall.DoDeletePrefix(uint64(ord), prefix)
 }
 
+func (i *instance) deleteRange(ord int64, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength int32) {
+	lowKey := i.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := i.Heap.ReadString(highKeyPtr, highKeyLength)
+	i.CurrentCall.DoDeleteRange(uint64(ord), lowKey, highKey)
+}
+
+func (i *instance) deleteRangePointers(ord int64, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength, separatorPtr, separatorLength int32) {
+	lowKey := i.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := i.Heap.ReadString(highKeyPtr, highKeyLength)
+	pointerSeparator := i.Heap.ReadString(separatorPtr, separatorLength)
+	i.CurrentCall.DoDeleteRangePointers(uint64(ord), lowKey, highKey, pointerSeparator)
+}
+
 func (i *instance) setSumInt64(ord int64, keyPtr, keyLength int32, valPtr, valLength int32) {
 	key := i.Heap.ReadString(keyPtr, keyLength)
 	value := i.Heap.ReadString(valPtr, valLength)
@@ -153,6 +172,32 @@ func (i *instance) hasLast(storeIndex int32, keyPtr, keyLength int32) int32 {
 	return returnIfFound(found)
 }
 
+func (i *instance) scanPrefix(storeIndex int32, ord int64, prefixPtr, prefixLength, afterPtr, afterLength, limit, outputPtr int32) int32 {
+	prefix := i.Heap.ReadString(prefixPtr, prefixLength)
+	after := i.Heap.ReadString(afterPtr, afterLength)
+	page, count := i.CurrentCall.DoScanPrefix(int(storeIndex), uint64(ord), prefix, after, int(limit))
+	writeToHeapIfFound(i, outputPtr, page, count != 0)
+	return int32(count)
+}
+
+func (i *instance) scanRange(storeIndex int32, ord int64, startPtr, startLength, endPtr, endLength, afterPtr, afterLength, limit, outputPtr int32) int32 {
+	start := i.Heap.ReadString(startPtr, startLength)
+	end := i.Heap.ReadString(endPtr, endLength)
+	after := i.Heap.ReadString(afterPtr, afterLength)
+	page, count := i.CurrentCall.DoScanRange(int(storeIndex), uint64(ord), start, end, after, int(limit))
+	writeToHeapIfFound(i, outputPtr, page, count != 0)
+	return int32(count)
+}
+
+func (i *instance) indexLookup(storeIndex int32, ord int64, indexPtr, indexLength, valuePtr, valueLength, afterPtr, afterLength, limit, outputPtr int32) int32 {
+	index := i.Heap.ReadString(indexPtr, indexLength)
+	value := i.Heap.ReadString(valuePtr, valueLength)
+	after := i.Heap.ReadString(afterPtr, afterLength)
+	page, count := i.CurrentCall.DoIndexLookup(int(storeIndex), uint64(ord), index, value, after, int(limit))
+	writeToHeapIfFound(i, outputPtr, page, count != 0)
+	return int32(count)
+}
+
 func writeToHeapIfFound(i *instance, outputPtr int32, value []byte, found bool) int32 {
 	if !found {
 		return 0
@@ -62,6 +62,19 @@ var StateFuncs = []funcs{
 			call.DoAppend(ord, key, value)
 		}),
 	},
+	{
+		"aggregate",
+		[]parm{i64, i32, i32, i32, i32},
+		[]parm{},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			ord := stack[0]
+			key := readStringFromStack(mod, stack[1:])
+			value := readBytesFromStack(mod, stack[3:])
+			call := wasm.FromContext(ctx)
+
+			call.DoAggregate(ord, key, value)
+		}),
+	},
 	{
 		"delete_prefix",
 		[]parm{i64, i32, i32},
@@ -74,6 +87,33 @@ var StateFuncs = []funcs{
 			call.DoDeletePrefix(ord, prefix)
 		}),
 	},
+	{
+		"delete_range",
+		[]parm{i64, i32, i32, i32, i32},
+		[]parm{},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			ord := stack[0]
+			lowKey := readStringFromStack(mod, stack[1:])
+			highKey := readStringFromStack(mod, stack[3:])
+			call := wasm.FromContext(ctx)
+
+			call.DoDeleteRange(ord, lowKey, highKey)
+		}),
+	},
+	{
+		"delete_range_pointers",
+		[]parm{i64, i32, i32, i32, i32, i32, i32},
+		[]parm{},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			ord := stack[0]
+			lowKey := readStringFromStack(mod, stack[1:])
+			highKey := readStringFromStack(mod, stack[3:])
+			pointerSeparator := readStringFromStack(mod, stack[5:])
+			call := wasm.FromContext(ctx)
+
+			call.DoDeleteRangePointers(ord, lowKey, highKey, pointerSeparator)
+		}),
+	},
 	{
 		"add_bigint",
 		[]parm{i64, i32, i32, i32, i32},
@@ -372,6 +412,70 @@ var StateFuncs = []funcs{
 			setStack0Bool(stack, found)
 		}),
 	},
+
+	// Scan and index functions, writing a page of entries as a sf.substreams.v1.StoreScanPage and returning their count
+
+	{
+		"scan_prefix",
+		[]parm{i32, i64, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			ord := stack[1]
+			prefix := readStringFromStack(mod, stack[2:])
+			after := readStringFromStack(mod, stack[4:])
+			limit := int32(stack[6])
+			outputPtr := uint32(stack[7])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, count := call.DoScanPrefix(int(storeIndex), ord, prefix, after, int(limit))
+			setStackAndPage(ctx, stack, call, inst, outputPtr, page, count)
+		}),
+	},
+	{
+		"scan_range",
+		[]parm{i32, i64, i32, i32, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			ord := stack[1]
+			start := readStringFromStack(mod, stack[2:])
+			end := readStringFromStack(mod, stack[4:])
+			after := readStringFromStack(mod, stack[6:])
+			limit := int32(stack[8])
+			outputPtr := uint32(stack[9])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, count := call.DoScanRange(int(storeIndex), ord, start, end, after, int(limit))
+			setStackAndPage(ctx, stack, call, inst, outputPtr, page, count)
+		}),
+	},
+	{
+		"index_lookup",
+		[]parm{i32, i64, i32, i32, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			ord := stack[1]
+			index := readStringFromStack(mod, stack[2:])
+			value := readStringFromStack(mod, stack[4:])
+			after := readStringFromStack(mod, stack[6:])
+			limit := int32(stack[8])
+			outputPtr := uint32(stack[9])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, count := call.DoIndexLookup(int(storeIndex), ord, index, value, after, int(limit))
+			setStackAndPage(ctx, stack, call, inst, outputPtr, page, count)
+		}),
+	},
+}
+
+func setStackAndPage(ctx context.Context, stack []uint64, call *wasm.Call, inst *Instance, outputPtr uint32, page []byte, count int) {
+	setStackAndOutput(ctx, stack, call, count != 0, inst, outputPtr, page)
+	stack[0] = uint64(count)
 }
 
 func setStackAndOutput(ctx context.Context, stack []uint64, call *wasm.Call, found bool, inst *Instance, outputPtr uint32, value []byte) {